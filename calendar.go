@@ -0,0 +1,771 @@
+package php2go
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CalGregorian CAL_GREGORIAN
+	CalGregorian = 0
+	// CalJulian CAL_JULIAN
+	CalJulian = 1
+	// CalJewish CAL_JEWISH
+	CalJewish = 2
+	// CalFrench CAL_FRENCH
+	CalFrench = 3
+	// CalNumCals CAL_NUM_CALS
+	CalNumCals = 4
+
+	// CalDowDayno CAL_DOW_DAYNO
+	CalDowDayno = 0
+	// CalDowLong CAL_DOW_LONG
+	CalDowLong = 1
+	// CalDowShort CAL_DOW_SHORT
+	CalDowShort = 2
+
+	// CalMonthGregorianShort CAL_MONTH_GREGORIAN_SHORT
+	CalMonthGregorianShort = 0
+	// CalMonthGregorianLong CAL_MONTH_GREGORIAN_LONG
+	CalMonthGregorianLong = 1
+	// CalMonthJulianShort CAL_MONTH_JULIAN_SHORT
+	CalMonthJulianShort = 2
+	// CalMonthJulianLong CAL_MONTH_JULIAN_LONG
+	CalMonthJulianLong = 3
+	// CalMonthJewish CAL_MONTH_JEWISH
+	CalMonthJewish = 4
+	// CalMonthFrench CAL_MONTH_FRENCH
+	CalMonthFrench = 5
+
+	// CalEasterDefault CAL_EASTER_DEFAULT
+	CalEasterDefault = 0
+	// CalEasterRoman CAL_EASTER_ROMAN
+	CalEasterRoman = 1
+	// CalEasterAlwaysGregorian CAL_EASTER_ALWAYS_GREGORIAN
+	CalEasterAlwaysGregorian = 2
+	// CalEasterAlwaysJulian CAL_EASTER_ALWAYS_JULIAN
+	CalEasterAlwaysJulian = 3
+
+	// CalJewishAddAlafimGeresh CAL_JEWISH_ADD_ALAFIM_GERESH
+	CalJewishAddAlafimGeresh = 2
+	// CalJewishAddAlafim CAL_JEWISH_ADD_ALAFIM
+	CalJewishAddAlafim = 4
+	// CalJewishAddGereshayim CAL_JEWISH_ADD_GERESHAYIM
+	CalJewishAddGereshayim = 8
+)
+
+var (
+	calMonthNameShort = []string{"", "Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+	calMonthNameLong  = []string{"", "January", "February", "March", "April", "May", "June", "July", "August",
+		"September", "October", "November", "December"}
+	calDayNameShort = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+	calDayNameLong  = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	calFrenchMonth  = []string{"", "Vendemiaire", "Brumaire", "Frimaire", "Nivose", "Pluviose", "Ventose",
+		"Germinal", "Floreal", "Prairial", "Messidor", "Thermidor", "Fructidor", "Extra"}
+	calJewishMonth = []string{"", "Tishri", "Heshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar",
+		"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul"}
+	calJewishMonthLeap = []string{"", "Tishri", "Heshvan", "Kislev", "Tevet", "Shevat", "Adar I", "Adar II",
+		"Nisan", "Iyyar", "Sivan", "Tammuz", "Av", "Elul"}
+	calJewishMonthHeb = []string{"", "תשרי", "חשון", "כסלו", "טבת", "שבט", "אדר", "אדר",
+		"ניסן", "אייר", "סיון", "תמוז", "אב", "אלול"}
+	calJewishMonthHebLeap = []string{"", "תשרי", "חשון", "כסלו", "טבת", "שבט", "אדר א'", "אדר ב'",
+		"ניסן", "אייר", "סיון", "תמוז", "אב", "אלול"}
+)
+
+type calEntry struct {
+	name, symbol          string
+	toJd                  func(year, month, day int) int
+	fromJd                func(jd int) (year, month, day int)
+	numMonths, maxDays    int
+	monthShort, monthLong []string
+}
+
+var calConversionTable = []calEntry{
+	{"Gregorian", "CAL_GREGORIAN", calGregorianToSdn, calSdnToGregorian, 12, 31, calMonthNameShort, calMonthNameLong},
+	{"Julian", "CAL_JULIAN", calJulianToSdn, calSdnToJulian, 12, 31, calMonthNameShort, calMonthNameLong},
+	{"Jewish", "CAL_JEWISH", calJewishToSdn, calSdnToJewish, 13, 30, calJewishMonthLeap, calJewishMonthLeap},
+	{"French", "CAL_FRENCH", calFrenchToSdn, calSdnToFrench, 13, 30, calFrenchMonth, calFrenchMonth},
+}
+
+func calCalendar(calendar int) (calEntry, error) {
+	if calendar < 0 || calendar >= CalNumCals {
+		return calEntry{}, errors.New("invalid calendar ID " + strconv.Itoa(calendar))
+	}
+	return calConversionTable[calendar], nil
+}
+
+// CalDaysInMonth cal_days_in_month()
+// CalDaysInMonth(CalGregorian, 2, 2020) = 29
+func CalDaysInMonth(calendar, month, year int) (int, error) {
+	cal, err := calCalendar(calendar)
+	if err != nil {
+		return 0, err
+	}
+	start := cal.toJd(year, month, 1)
+	if start == 0 {
+		return 0, errors.New("invalid date")
+	}
+	next := cal.toJd(year, month+1, 1)
+	if next == 0 {
+		// the year after 1 BCE is 1 AD
+		if year == -1 {
+			next = cal.toJd(1, 1, 1)
+		} else {
+			next = cal.toJd(year+1, 1, 1)
+			if calendar == CalFrench && next == 0 {
+				// the French calendar ends on 0014-13-05
+				next = 2380953
+			}
+		}
+	}
+	return next - start, nil
+}
+
+// CalToJd cal_to_jd()
+func CalToJd(calendar, month, day, year int) (int, error) {
+	cal, err := calCalendar(calendar)
+	if err != nil {
+		return 0, err
+	}
+	return cal.toJd(year, month, day), nil
+}
+
+// CalFromJd cal_from_jd()
+// keys: date, month, day, year, dow, abbrevdayname, dayname, abbrevmonth, monthname
+func CalFromJd(jd int, calendar int) (map[string]interface{}, error) {
+	cal, err := calCalendar(calendar)
+	if err != nil {
+		return nil, err
+	}
+	year, month, day := cal.fromJd(jd)
+	ret := map[string]interface{}{
+		"date":  strconv.Itoa(month) + "/" + strconv.Itoa(day) + "/" + strconv.Itoa(year),
+		"month": month,
+		"day":   day,
+		"year":  year,
+	}
+	if calendar != CalJewish || year > 0 {
+		dow := calDayOfWeek(jd)
+		ret["dow"], ret["abbrevdayname"], ret["dayname"] = dow, calDayNameShort[dow], calDayNameLong[dow]
+	} else {
+		ret["dow"], ret["abbrevdayname"], ret["dayname"] = nil, "", ""
+	}
+	if calendar == CalJewish {
+		ret["abbrevmonth"], ret["monthname"] = "", ""
+		if year > 0 {
+			ret["abbrevmonth"], ret["monthname"] = calJewishMonthName(year)[month], calJewishMonthName(year)[month]
+		}
+	} else {
+		ret["abbrevmonth"], ret["monthname"] = cal.monthShort[month], cal.monthLong[month]
+	}
+	return ret, nil
+}
+
+// CalInfo cal_info()
+// keys: months, abbrevmonths, maxdaysinmonth, calname, calsymbol.
+// With calendar -1 the info of every calendar, keyed "0" to "3".
+func CalInfo(calendar int) (map[string]interface{}, error) {
+	if calendar == -1 {
+		ret := make(map[string]interface{}, CalNumCals)
+		for i := 0; i < CalNumCals; i++ {
+			ret[strconv.Itoa(i)], _ = CalInfo(i)
+		}
+		return ret, nil
+	}
+	cal, err := calCalendar(calendar)
+	if err != nil {
+		return nil, err
+	}
+	months := make(map[int]string, cal.numMonths)
+	abbrevMonths := make(map[int]string, cal.numMonths)
+	for i := 1; i <= cal.numMonths; i++ {
+		months[i] = cal.monthLong[i]
+		abbrevMonths[i] = cal.monthShort[i]
+	}
+	return map[string]interface{}{
+		"months":         months,
+		"abbrevmonths":   abbrevMonths,
+		"maxdaysinmonth": cal.maxDays,
+		"calname":        cal.name,
+		"calsymbol":      cal.symbol,
+	}, nil
+}
+
+// EasterDate easter_date()
+// Midnight of Easter in the default timezone, year must be 1970 or later like PHP on 64-bit platforms.
+// mode is one of the CalEaster* constants, CalEasterDefault when not given.
+func EasterDate(year int, mode ...int) (int64, error) {
+	if year < 1970 {
+		return 0, errors.New("year must be greater than or equal to 1970")
+	}
+	easter := calEaster(year, mode)
+	month, day := time.March, easter+21
+	if easter >= 11 {
+		month, day = time.April, easter-10
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, dateLocation(nil)).Unix(), nil
+}
+
+// EasterDays easter_days()
+// The number of days after March 21 on which Easter falls.
+// EasterDays(1999) = 14
+func EasterDays(year int, mode ...int) int {
+	return calEaster(year, mode)
+}
+
+// calEaster based on code by Simon Kershaw
+func calEaster(year int, mode []int) int {
+	method := CalEasterDefault
+	if len(mode) > 0 {
+		method = mode[0]
+	}
+	golden := year%19 + 1
+	var dom, pfm int
+	if year <= 1582 && method != CalEasterAlwaysGregorian ||
+		year >= 1583 && year <= 1752 && method != CalEasterRoman && method != CalEasterAlwaysGregorian ||
+		method == CalEasterAlwaysJulian {
+		// Julian calendar
+		dom = (year + year/4 + 5) % 7
+		if dom < 0 {
+			dom += 7
+		}
+		pfm = (3 - 11*golden - 7) % 30
+		if pfm < 0 {
+			pfm += 30
+		}
+	} else {
+		// Gregorian calendar, with the solar and lunar corrections
+		dom = (year + year/4 - year/100 + year/400) % 7
+		if dom < 0 {
+			dom += 7
+		}
+		solar := (year-1600)/100 - (year-1600)/400
+		lunar := (year - 1400) / 100 * 8 / 25
+		pfm = (3 - 11*golden + solar - lunar) % 30
+		if pfm < 0 {
+			pfm += 30
+		}
+	}
+	// corrected date of the Paschal full moon, days after 21st March
+	if pfm == 29 || pfm == 28 && golden > 11 {
+		pfm--
+	}
+	tmp := (4 - pfm - dom) % 7
+	if tmp < 0 {
+		tmp += 7
+	}
+	return pfm + tmp + 1
+}
+
+// GregorianToJd gregoriantojd()
+// GregorianToJd(10, 11, 1970) = 2440871, 0 for invalid dates
+func GregorianToJd(month, day, year int) int {
+	return calGregorianToSdn(year, month, day)
+}
+
+// JdToGregorian jdtogregorian()
+// "month/day/year"
+func JdToGregorian(jd int) string {
+	return calDateString(calSdnToGregorian(jd))
+}
+
+// JulianToJd juliantojd()
+func JulianToJd(month, day, year int) int {
+	return calJulianToSdn(year, month, day)
+}
+
+// JdToJulian jdtojulian()
+func JdToJulian(jd int) string {
+	return calDateString(calSdnToJulian(jd))
+}
+
+// JewishToJd jewishtojd()
+// Month 6 is Adar I and 7 Adar II in leap years, 7 is Adar otherwise.
+func JewishToJd(month, day, year int) int {
+	return calJewishToSdn(year, month, day)
+}
+
+// JdToJewish jdtojewish()
+// "month/day/year", or the date in Hebrew when hebrew is true, flags are the CalJewishAdd* constants.
+// php returns the Hebrew date in ISO-8859-8, this is UTF-8.
+func JdToJewish(jd int, hebrew bool, flags int) (string, error) {
+	year, month, day := calSdnToJewish(jd)
+	if !hebrew {
+		return calDateString(year, month, day), nil
+	}
+	if year <= 0 || year > 9999 {
+		return "", errors.New("year out of range (0-9999)")
+	}
+	return calHebNumber(day, flags) + " " + calJewishMonthHebName(year)[month] + " " + calHebNumber(year, flags), nil
+}
+
+// FrenchToJd frenchtojd()
+// The French republican calendar, years 1 to 14
+func FrenchToJd(month, day, year int) int {
+	return calFrenchToSdn(year, month, day)
+}
+
+// JdToFrench jdtofrench()
+func JdToFrench(jd int) string {
+	return calDateString(calSdnToFrench(jd))
+}
+
+// JdDayOfWeek jddayofweek()
+// mode CalDowDayno: int 0 (Sunday) to 6, CalDowLong: "Sunday", CalDowShort: "Sun"
+func JdDayOfWeek(jd int, mode int) interface{} {
+	dow := calDayOfWeek(jd)
+	switch mode {
+	case CalDowLong:
+		return calDayNameLong[dow]
+	case CalDowShort:
+		return calDayNameShort[dow]
+	}
+	return dow
+}
+
+// JdMonthName jdmonthname()
+// mode is one of the CalMonth* constants
+func JdMonthName(jd int, mode int) string {
+	switch mode {
+	case CalMonthGregorianLong:
+		_, month, _ := calSdnToGregorian(jd)
+		return calMonthNameLong[month]
+	case CalMonthJulianShort:
+		_, month, _ := calSdnToJulian(jd)
+		return calMonthNameShort[month]
+	case CalMonthJulianLong:
+		_, month, _ := calSdnToJulian(jd)
+		return calMonthNameLong[month]
+	case CalMonthJewish:
+		year, month, _ := calSdnToJewish(jd)
+		if year <= 0 {
+			return ""
+		}
+		return calJewishMonthName(year)[month]
+	case CalMonthFrench:
+		_, month, _ := calSdnToFrench(jd)
+		return calFrenchMonth[month]
+	}
+	_, month, _ := calSdnToGregorian(jd)
+	return calMonthNameShort[month]
+}
+
+// UnixToJd unixtojd()
+// The Julian Day of timestamp in the default timezone
+func UnixToJd(timestamp int64) (int, error) {
+	if timestamp < 0 {
+		return 0, errors.New("timestamp must be greater than or equal to 0")
+	}
+	t := time.Unix(timestamp, 0).In(dateLocation(nil))
+	return calGregorianToSdn(t.Year(), int(t.Month()), t.Day()), nil
+}
+
+// JdToUnix jdtounix()
+// The timestamp of midnight UTC of the Julian Day
+func JdToUnix(jd int) (int64, error) {
+	days := int64(jd) - 2440588
+	if days < 0 || days > (1<<63-1)/86400 {
+		return 0, errors.New("jday must be between 2440588 and " + strconv.FormatInt((1<<63-1)/86400+2440588, 10))
+	}
+	return days * 86400, nil
+}
+
+func calDateString(year, month, day int) string {
+	return strconv.Itoa(month) + "/" + strconv.Itoa(day) + "/" + strconv.Itoa(year)
+}
+
+// calDayOfWeek 0 is Sunday
+func calDayOfWeek(sdn int) int {
+	dow := (sdn + 1) % 7
+	if dow < 0 {
+		dow += 7
+	}
+	return dow
+}
+
+const (
+	calGregorSdnOffset = 32045
+	calJulianSdnOffset = 32083
+	calDaysPer5Months  = 153
+	calDaysPer4Years   = 1461
+	calDaysPer400Years = 146097
+)
+
+func calSdnToGregorian(sdn int) (year, month, day int) {
+	if sdn <= 0 || sdn > (math.MaxInt-4*calGregorSdnOffset)/4 {
+		return 0, 0, 0
+	}
+	temp := (sdn+calGregorSdnOffset)*4 - 1
+	// century (year/100), then the year and day of year (1 <= dayOfYear <= 366)
+	century := temp / calDaysPer400Years
+	temp = temp%calDaysPer400Years/4*4 + 3
+	year = century*100 + temp/calDaysPer4Years
+	dayOfYear := temp%calDaysPer4Years/4 + 1
+	// the year starts on March 1
+	temp = dayOfYear*5 - 3
+	month = temp / calDaysPer5Months
+	day = temp%calDaysPer5Months/5 + 1
+	if month < 10 {
+		month += 3
+	} else {
+		year++
+		month -= 9
+	}
+	// there is no year 0
+	year -= 4800
+	if year <= 0 {
+		year--
+	}
+	return year, month, day
+}
+
+func calGregorianToSdn(year, month, day int) int {
+	if year == 0 || year < -4714 || month <= 0 || month > 12 || day <= 0 || day > 31 {
+		return 0
+	}
+	// dates before SDN 1 (Nov 25, 4714 B.C.)
+	if year == -4714 && (month < 11 || month == 11 && day < 25) {
+		return 0
+	}
+	y := year + 4800
+	if year < 0 {
+		y = year + 4801
+	}
+	m := month - 3
+	if month <= 2 {
+		m = month + 9
+		y--
+	}
+	return y/100*calDaysPer400Years/4 + y%100*calDaysPer4Years/4 + (m*calDaysPer5Months+2)/5 + day - calGregorSdnOffset
+}
+
+func calSdnToJulian(sdn int) (year, month, day int) {
+	if sdn <= 0 || sdn > (math.MaxInt-calJulianSdnOffset*4+1)/4 {
+		return 0, 0, 0
+	}
+	temp := sdn*4 + calJulianSdnOffset*4 - 1
+	year = temp / calDaysPer4Years
+	dayOfYear := temp%calDaysPer4Years/4 + 1
+	temp = dayOfYear*5 - 3
+	month = temp / calDaysPer5Months
+	day = temp%calDaysPer5Months/5 + 1
+	if month < 10 {
+		month += 3
+	} else {
+		year++
+		month -= 9
+	}
+	year -= 4800
+	if year <= 0 {
+		year--
+	}
+	return year, month, day
+}
+
+func calJulianToSdn(year, month, day int) int {
+	if year == 0 || year < -4713 || month <= 0 || month > 12 || day <= 0 || day > 31 {
+		return 0
+	}
+	// dates before SDN 1 (Jan 2, 4713 B.C.)
+	if year == -4713 && month == 1 && day == 1 {
+		return 0
+	}
+	y := year + 4800
+	if year < 0 {
+		y = year + 4801
+	}
+	m := month - 3
+	if month <= 2 {
+		m = month + 9
+		y--
+	}
+	return y*calDaysPer4Years/4 + (m*calDaysPer5Months+2)/5 + day - calJulianSdnOffset
+}
+
+const (
+	calFrenchSdnOffset  = 2375474
+	calFrenchFirstValid = 2375840
+	calFrenchLastValid  = 2380952
+)
+
+func calSdnToFrench(sdn int) (year, month, day int) {
+	if sdn < calFrenchFirstValid || sdn > calFrenchLastValid {
+		return 0, 0, 0
+	}
+	temp := (sdn-calFrenchSdnOffset)*4 - 1
+	dayOfYear := temp % calDaysPer4Years / 4
+	return temp / calDaysPer4Years, dayOfYear/30 + 1, dayOfYear%30 + 1
+}
+
+func calFrenchToSdn(year, month, day int) int {
+	if year < 1 || year > 14 || month < 1 || month > 13 || day < 1 || day > 30 {
+		return 0
+	}
+	return year*calDaysPer4Years/4 + (month-1)*30 + day + calFrenchSdnOffset
+}
+
+// The Jewish calendar, in halakim (parts, 1080 to the hour) since the molad of creation
+const (
+	calHalakimPerDay          = 25920
+	calHalakimPerLunarCycle   = 29*calHalakimPerDay + 13753
+	calHalakimPerMetonicCycle = calHalakimPerLunarCycle * (12*19 + 7)
+	calJewishSdnOffset        = 347997
+	calJewishSdnMax           = 324542846
+	calNewMoonOfCreation      = 31524
+	calNoon                   = 18 * 1080
+	calAM3h11m20s             = 9*1080 + 204
+	calAM9h32m43s             = 15*1080 + 589
+)
+
+var (
+	calMonthsPerYear = []int{12, 12, 13, 12, 12, 13, 12, 13, 12, 12, 13, 12, 12, 13, 12, 12, 13, 12, 13}
+	calYearOffset    = []int{0, 12, 24, 37, 49, 61, 74, 86, 99, 111, 123, 136, 148, 160, 173, 185, 197, 210, 222}
+)
+
+func calJewishLeap(year int) bool {
+	return calMonthsPerYear[(year-1)%19] == 13
+}
+
+func calJewishMonthName(year int) []string {
+	if calJewishLeap(year) {
+		return calJewishMonthLeap
+	}
+	return calJewishMonth
+}
+
+func calJewishMonthHebName(year int) []string {
+	if calJewishLeap(year) {
+		return calJewishMonthHebLeap
+	}
+	return calJewishMonthHeb
+}
+
+// calTishri1 the day of Tishri 1 from the molad, applying the postponement rules
+func calTishri1(metonicYear, moladDay, moladHalakim int) int {
+	tishri1 := moladDay
+	dow := tishri1 % 7
+	leapYear := metonicYear == 2 || metonicYear == 5 || metonicYear == 7 || metonicYear == 10 ||
+		metonicYear == 13 || metonicYear == 16 || metonicYear == 18
+	lastWasLeapYear := metonicYear == 3 || metonicYear == 6 || metonicYear == 8 || metonicYear == 11 ||
+		metonicYear == 14 || metonicYear == 17 || metonicYear == 0
+	// rules 2, 3 and 4
+	if moladHalakim >= calNoon || !leapYear && dow == 2 && moladHalakim >= calAM3h11m20s ||
+		lastWasLeapYear && dow == 1 && moladHalakim >= calAM9h32m43s {
+		tishri1++
+		dow = (dow + 1) % 7
+	}
+	// rule 1 after the others, it can delay one more day
+	if dow == 3 || dow == 5 || dow == 0 {
+		tishri1++
+	}
+	return tishri1
+}
+
+func calMoladOfMetonicCycle(metonicCycle int) (moladDay, moladHalakim int) {
+	// int64, the halakim since creation overflow a 32-bit int
+	total := calNewMoonOfCreation + int64(metonicCycle)*calHalakimPerMetonicCycle
+	return int(total / calHalakimPerDay), int(total % calHalakimPerDay)
+}
+
+func calMoladAdd(moladDay, moladHalakim, halakim int) (int, int) {
+	moladHalakim += halakim
+	return moladDay + moladHalakim/calHalakimPerDay, moladHalakim % calHalakimPerDay
+}
+
+// calFindTishriMolad the molad of Tishri closest to inputDay
+func calFindTishriMolad(inputDay int) (metonicCycle, metonicYear, moladDay, moladHalakim int) {
+	metonicCycle = (inputDay + 310) / 6940
+	moladDay, moladHalakim = calMoladOfMetonicCycle(metonicCycle)
+	for moladDay < inputDay-6940+310 {
+		metonicCycle++
+		moladDay, moladHalakim = calMoladAdd(moladDay, moladHalakim, calHalakimPerMetonicCycle)
+	}
+	for metonicYear = 0; metonicYear < 18; metonicYear++ {
+		if moladDay > inputDay-74 {
+			break
+		}
+		moladDay, moladHalakim = calMoladAdd(moladDay, moladHalakim, calHalakimPerLunarCycle*calMonthsPerYear[metonicYear])
+	}
+	return
+}
+
+func calFindStartOfYear(year int) (metonicCycle, metonicYear, moladDay, moladHalakim, tishri1 int) {
+	metonicCycle = (year - 1) / 19
+	metonicYear = (year - 1) % 19
+	moladDay, moladHalakim = calMoladOfMetonicCycle(metonicCycle)
+	moladDay, moladHalakim = calMoladAdd(moladDay, moladHalakim, calHalakimPerLunarCycle*calYearOffset[metonicYear])
+	tishri1 = calTishri1(metonicYear, moladDay, moladHalakim)
+	return
+}
+
+func calSdnToJewish(sdn int) (year, month, day int) {
+	if sdn <= calJewishSdnOffset || sdn > calJewishSdnMax {
+		return 0, 0, 0
+	}
+	inputDay := sdn - calJewishSdnOffset
+	metonicCycle, metonicYear, moladDay, halakim := calFindTishriMolad(inputDay)
+	tishri1 := calTishri1(metonicYear, moladDay, halakim)
+	var tishri1After int
+	if inputDay >= tishri1 {
+		// Tishri 1 at the start of the year
+		year = metonicCycle*19 + metonicYear + 1
+		if inputDay < tishri1+30 {
+			return year, 1, inputDay - tishri1 + 1
+		}
+		if inputDay < tishri1+59 {
+			return year, 2, inputDay - tishri1 - 29
+		}
+		// the length of the year decides, find Tishri 1 of the next year
+		moladDay, halakim = calMoladAdd(moladDay, halakim, calHalakimPerLunarCycle*calMonthsPerYear[metonicYear])
+		tishri1After = calTishri1((metonicYear+1)%19, moladDay, halakim)
+	} else {
+		// Tishri 1 at the end of the year
+		year = metonicCycle*19 + metonicYear
+		if inputDay >= tishri1-177 {
+			// one of the last 6 months of the year
+			switch {
+			case inputDay > tishri1-30:
+				return year, 13, inputDay - tishri1 + 30
+			case inputDay > tishri1-60:
+				return year, 12, inputDay - tishri1 + 60
+			case inputDay > tishri1-89:
+				return year, 11, inputDay - tishri1 + 89
+			case inputDay > tishri1-119:
+				return year, 10, inputDay - tishri1 + 119
+			case inputDay > tishri1-148:
+				return year, 9, inputDay - tishri1 + 148
+			}
+			return year, 8, inputDay - tishri1 + 178
+		}
+		month, day = 7, inputDay-tishri1+207
+		if day > 0 {
+			return
+		}
+		if calJewishLeap(year) {
+			month, day = month-1, day+30
+			if day > 0 {
+				return
+			}
+			month, day = month-1, day+30
+		} else {
+			month, day = month-2, day+30
+		}
+		if day > 0 {
+			return
+		}
+		month, day = month-1, day+29
+		if day > 0 {
+			return
+		}
+		// the length of the year decides, find Tishri 1 of this year
+		tishri1After = tishri1
+		_, metonicYear, moladDay, halakim = calFindTishriMolad(moladDay - 365)
+		tishri1 = calTishri1(metonicYear, moladDay, halakim)
+	}
+	yearLength := tishri1After - tishri1
+	day = inputDay - tishri1 - 29
+	heshvan := 29
+	if yearLength == 355 || yearLength == 385 {
+		heshvan = 30
+	}
+	if day <= heshvan {
+		return year, 2, day
+	}
+	return year, 3, day - heshvan
+}
+
+func calJewishToSdn(year, month, day int) int {
+	if year <= 0 || day <= 0 || day > 30 {
+		return 0
+	}
+	var sdn int
+	switch month {
+	case 1, 2:
+		// Tishri or Heshvan, the year length is not needed
+		_, _, _, _, tishri1 := calFindStartOfYear(year)
+		if month == 1 {
+			sdn = tishri1 + day - 1
+		} else {
+			sdn = tishri1 + day + 29
+		}
+	case 3:
+		// Kislev, the year length decides
+		_, metonicYear, moladDay, moladHalakim, tishri1 := calFindStartOfYear(year)
+		moladDay, moladHalakim = calMoladAdd(moladDay, moladHalakim, calHalakimPerLunarCycle*calMonthsPerYear[metonicYear])
+		yearLength := calTishri1((metonicYear+1)%19, moladDay, moladHalakim) - tishri1
+		if yearLength == 355 || yearLength == 385 {
+			sdn = tishri1 + day + 59
+		} else {
+			sdn = tishri1 + day + 58
+		}
+	case 4, 5, 6:
+		// Tevet, Shevat or Adar I, counted back from the next year
+		_, _, _, _, tishri1After := calFindStartOfYear(year + 1)
+		lengthOfAdarIAndII := 59
+		if !calJewishLeap(year) {
+			lengthOfAdarIAndII = 29
+		}
+		sdn = tishri1After + day - lengthOfAdarIAndII - []int{237, 208, 178}[month-4]
+	case 7, 8, 9, 10, 11, 12, 13:
+		// Adar II or later
+		_, _, _, _, tishri1After := calFindStartOfYear(year + 1)
+		sdn = tishri1After + day - []int{207, 178, 148, 119, 89, 60, 30}[month-7]
+	default:
+		return 0
+	}
+	return sdn + calJewishSdnOffset
+}
+
+var calAlefBet = []string{"0", "א", "ב", "ג", "ד", "ה", "ו", "ז", "ח", "ט", "י", "כ", "ל", "מ", "נ", "ס", "ע", "פ", "צ", "ק", "ר", "ש", "ת"}
+
+// calHebNumber n in Hebrew numerals, 1 to 9999
+func calHebNumber(n int, flags int) string {
+	if n < 1 || n > 9999 {
+		return ""
+	}
+	var alafim string
+	var letters []string
+	// thousands
+	if n >= 1000 {
+		alafim = calAlefBet[n/1000]
+		if flags&CalJewishAddAlafimGeresh != 0 {
+			alafim += "'"
+		}
+		if flags&CalJewishAddAlafim != 0 {
+			alafim += " אלפים "
+		}
+		n %= 1000
+	}
+	for ; n >= 400; n -= 400 {
+		letters = append(letters, calAlefBet[22])
+	}
+	if n >= 100 {
+		letters = append(letters, calAlefBet[18+n/100])
+		n %= 100
+	}
+	// 15 and 16 are written tet-vav and tet-zain
+	if n == 15 || n == 16 {
+		letters = append(letters, calAlefBet[9], calAlefBet[n-9])
+	} else {
+		if n >= 10 {
+			letters = append(letters, calAlefBet[9+n/10])
+			n %= 10
+		}
+		if n > 0 {
+			letters = append(letters, calAlefBet[n])
+		}
+	}
+	if flags&CalJewishAddGereshayim != 0 {
+		switch len(letters) {
+		case 0:
+		case 1:
+			letters = append(letters, "'")
+		default:
+			letters = append(letters[:len(letters)-1], "\"", letters[len(letters)-1])
+		}
+	}
+	return alafim + strings.Join(letters, "")
+}
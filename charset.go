@@ -0,0 +1,494 @@
+package php2go
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	xunicode "golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// charset an encoding known by the mb and iconv functions
+type charset struct {
+	// name the mbstring name of the encoding
+	name string
+	// encoder nil for UTF-8
+	encoder encoding.Encoding
+	// decoder when it differs from encoder: UTF-16 and UTF-32 are read with a BOM but written without
+	decoder encoding.Encoding
+}
+
+func (c *charset) newDecoder() transform.Transformer {
+	if c.decoder != nil {
+		return c.decoder.NewDecoder()
+	}
+	return c.encoder.NewDecoder()
+}
+
+var charsets = map[string]*charset{}
+
+func init() {
+	for _, v := range []struct {
+		charset
+		aliases []string
+	}{
+		{charset{"UTF-8", nil, nil}, []string{"utf8"}},
+		{charset{"ASCII", charsetASCII{}, nil}, []string{"US-ASCII", "ANSI_X3.4-1968", "646"}},
+		{charset{"UTF-16", xunicode.UTF16(xunicode.BigEndian, xunicode.IgnoreBOM), xunicode.UTF16(xunicode.BigEndian, xunicode.UseBOM)}, nil},
+		{charset{"UTF-16BE", xunicode.UTF16(xunicode.BigEndian, xunicode.IgnoreBOM), nil}, nil},
+		{charset{"UTF-16LE", xunicode.UTF16(xunicode.LittleEndian, xunicode.IgnoreBOM), nil}, nil},
+		{charset{"UTF-32", utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM), utf32.UTF32(utf32.BigEndian, utf32.UseBOM)}, nil},
+		{charset{"UTF-32BE", utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM), nil}, nil},
+		{charset{"UTF-32LE", utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM), nil}, nil},
+		{charset{"CP936", simplifiedchinese.GBK, nil}, []string{"CP-936", "GBK"}},
+		{charset{"GB18030", simplifiedchinese.GB18030, nil}, nil},
+		{charset{"HZ", simplifiedchinese.HZGB2312, nil}, []string{"HZ-GB-2312"}},
+		{charset{"BIG-5", traditionalchinese.Big5, nil}, []string{"BIG5", "CN-BIG5", "BIG-FIVE", "BIGFIVE", "CP950"}},
+		{charset{"SJIS", japanese.ShiftJIS, nil}, []string{"Shift_JIS", "x-sjis", "MS_Kanji", "SJIS-win", "CP932", "Windows-31J"}},
+		{charset{"EUC-JP", japanese.EUCJP, nil}, []string{"x-euc-jp", "eucJP"}},
+		{charset{"ISO-2022-JP", japanese.ISO2022JP, nil}, []string{"JIS"}},
+		{charset{"EUC-KR", korean.EUCKR, nil}, []string{"x-euc-kr", "UHC", "CP949"}},
+		{charset{"ISO-8859-1", charmap.ISO8859_1, nil}, []string{"ISO8859-1", "latin1", "l1"}},
+		{charset{"ISO-8859-2", charmap.ISO8859_2, nil}, []string{"ISO8859-2", "latin2", "l2"}},
+		{charset{"ISO-8859-5", charmap.ISO8859_5, nil}, []string{"ISO8859-5", "cyrillic"}},
+		{charset{"ISO-8859-7", charmap.ISO8859_7, nil}, []string{"ISO8859-7", "greek"}},
+		{charset{"ISO-8859-9", charmap.ISO8859_9, nil}, []string{"ISO8859-9", "latin5", "l5"}},
+		{charset{"ISO-8859-15", charmap.ISO8859_15, nil}, []string{"ISO8859-15", "latin9"}},
+		{charset{"Windows-1251", charmap.Windows1251, nil}, []string{"CP1251", "CP-1251", "WINDOWS-1251"}},
+		{charset{"Windows-1252", charmap.Windows1252, nil}, []string{"CP1252", "CP-1252", "WINDOWS-1252"}},
+		{charset{"KOI8-R", charmap.KOI8R, nil}, []string{"KOI8R"}},
+	} {
+		c := v.charset
+		for _, name := range append(v.aliases, c.name) {
+			charsets[charsetKey(name)] = &c
+		}
+	}
+}
+
+// charsetKey the lookup key of an encoding name, case and the separators - _ are ignored
+func charsetKey(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToUpper(name))
+}
+
+func charsetLookup(name string) (*charset, bool) {
+	c, ok := charsets[charsetKey(name)]
+	return c, ok
+}
+
+// charsetList the encodings of a list of names, each name can be a comma separated list, "auto" is "ASCII,UTF-8"
+func charsetList(names []string) ([]*charset, error) {
+	var list []*charset
+	for _, names := range names {
+		for _, name := range strings.Split(names, ",") {
+			name = strings.TrimSpace(name)
+			if strings.EqualFold(name, "auto") {
+				list = append(list, charsets["ASCII"], charsets["UTF8"])
+				continue
+			}
+			c, ok := charsetLookup(name)
+			if !ok {
+				return nil, fmt.Errorf(`contains invalid encoding "%s"`, name)
+			}
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// MbConvertEncoding mb_convert_encoding()
+// fromEncoding defaults to the internal encoding, with several encodings or "auto" the encoding is detected.
+// Invalid and unmappable characters are replaced by '?'.
+// MbConvertEncoding(gbkString, "UTF-8", "GBK"), MbConvertEncoding(str, "UTF-8", "GBK,BIG-5")
+func MbConvertEncoding(str, toEncoding string, fromEncoding ...string) (string, error) {
+	to, ok := charsetLookup(toEncoding)
+	if !ok {
+		return "", fmt.Errorf(`mb_convert_encoding(): Argument #2 ($to_encoding) must be a valid encoding, "%s" given`, toEncoding)
+	}
+	if len(fromEncoding) == 0 {
+		fromEncoding = []string{MbInternalEncoding()}
+	}
+	list, err := charsetList(fromEncoding)
+	if err != nil {
+		return "", errors.New("mb_convert_encoding(): Argument #3 ($from_encoding) " + err.Error())
+	}
+	from := list[0]
+	if len(list) > 1 {
+		if from = charsetDetect(str, list, true); from == nil {
+			return "", errors.New("mb_convert_encoding(): Unable to detect character encoding")
+		}
+	}
+	s, _, err := transform.String(charsetTransformer(from, to, charsetReplace), str)
+	return s, err
+}
+
+// MbConvertEncodingReader converts the content of r from fromEncoding to toEncoding while it is read, like MbConvertEncoding
+func MbConvertEncodingReader(r io.Reader, toEncoding, fromEncoding string) (io.Reader, error) {
+	from, to, err := charsetPair("mb_convert_encoding", toEncoding, fromEncoding)
+	if err != nil {
+		return nil, err
+	}
+	return transform.NewReader(r, charsetTransformer(from, to, charsetReplace)), nil
+}
+
+// MbConvertEncodingWriter converts what is written from fromEncoding to toEncoding before writing it to w, like MbConvertEncoding.
+// Close flushes the remaining data, it does not close w.
+func MbConvertEncodingWriter(w io.Writer, toEncoding, fromEncoding string) (io.WriteCloser, error) {
+	from, to, err := charsetPair("mb_convert_encoding", toEncoding, fromEncoding)
+	if err != nil {
+		return nil, err
+	}
+	return transform.NewWriter(w, charsetTransformer(from, to, charsetReplace)), nil
+}
+
+// charsetPair the charsets of a conversion, fn is the name of the PHP function in the errors
+func charsetPair(fn, toEncoding, fromEncoding string) (from, to *charset, err error) {
+	if to, ok := charsetLookup(toEncoding); !ok {
+		return nil, nil, fmt.Errorf(`%s(): Argument #2 ($to_encoding) must be a valid encoding, "%s" given`, fn, toEncoding)
+	} else if from, ok := charsetLookup(fromEncoding); !ok {
+		return nil, nil, fmt.Errorf(`%s(): Argument #3 ($from_encoding) must be a valid encoding, "%s" given`, fn, fromEncoding)
+	} else {
+		return from, to, nil
+	}
+}
+
+// MbCheckEncoding mb_check_encoding()
+// Whether str is valid in encoding, the internal encoding by default
+func MbCheckEncoding(str string, encodingName ...string) (bool, error) {
+	name := MbInternalEncoding()
+	if len(encodingName) > 0 {
+		name = encodingName[0]
+	}
+	c, ok := charsetLookup(name)
+	if !ok {
+		return false, fmt.Errorf(`mb_check_encoding(): Argument #2 ($encoding) must be a valid encoding, "%s" given`, name)
+	}
+	invalid, _ := charsetScore(str, c, nil)
+	return invalid == 0, nil
+}
+
+// MbDetectEncoding mb_detect_encoding()
+// encodings are the candidates in order of preference, "ASCII,UTF-8" when empty.
+// With strict only the encodings str is valid in are considered, otherwise the closest one is returned.
+// The encoding is chosen by the characters str decodes to: ASCII and frequent CJK characters are free,
+// control, private use and rare CJK characters are penalized.
+// "" when no encoding matches.
+func MbDetectEncoding(str string, encodings []string, strict bool) (string, error) {
+	if len(encodings) == 0 {
+		encodings = []string{"ASCII", "UTF-8"}
+	}
+	list, err := charsetList(encodings)
+	if err != nil {
+		return "", errors.New("mb_detect_encoding(): Argument #2 ($encodings) " + err.Error())
+	}
+	if c := charsetDetect(str, list, strict); c != nil {
+		return c.name, nil
+	}
+	return "", nil
+}
+
+// charsetDetect the candidate with the lowest demerits for str
+func charsetDetect(str string, list []*charset, strict bool) *charset {
+	var best *charset
+	bestDemerits := 0
+	common := newCharsetCommon()
+	for _, c := range list {
+		invalid, demerits := charsetScore(str, c, common)
+		if invalid > 0 {
+			if strict {
+				continue
+			}
+			demerits += 100 * invalid
+		}
+		if best == nil || demerits < bestDemerits {
+			best, bestDemerits = c, demerits
+		}
+	}
+	return best
+}
+
+// charsetScore the number of invalid sequences of str in c, and the demerits of its characters when common is not nil
+func charsetScore(str string, c *charset, common *charsetCommon) (invalid, demerits int) {
+	decoded := str
+	if c.encoder != nil {
+		decoded, _, _ = transform.String(c.newDecoder(), str)
+	}
+	for i := 0; i < len(decoded); {
+		r, size := utf8.DecodeRuneInString(decoded[i:])
+		i += size
+		// the decoders turn invalid sequences into U+FFFD
+		if r == utf8.RuneError && (size == 1 || c.encoder != nil) {
+			invalid++
+		} else if common != nil {
+			demerits += common.demerits(r)
+		}
+	}
+	return invalid, demerits
+}
+
+// charsetCommon decides if CJK characters are frequent ones,
+// from their level 1 (frequently used) code points in GB 2312, Big5, JIS X 0208 and KS X 1001
+type charsetCommon struct {
+	gbk, big5, sjis, euckr *encoding.Encoder
+}
+
+func newCharsetCommon() *charsetCommon {
+	return &charsetCommon{simplifiedchinese.GBK.NewEncoder(), traditionalchinese.Big5.NewEncoder(),
+		japanese.ShiftJIS.NewEncoder(), korean.EUCKR.NewEncoder()}
+}
+
+func (c *charsetCommon) demerits(r rune) int {
+	switch {
+	case r < 0x80:
+		if r >= 0x20 && r < 0x7F || r == '\t' || r == '\n' || r == '\r' {
+			return 0
+		}
+		return 40
+	case r < 0xA0, 0xE000 <= r && r <= 0xF8FF, r >= 0xF0000, !unicode.IsPrint(r) && !unicode.IsSpace(r):
+		// C1 controls, private use and unassigned
+		return 40
+	case 0xFF61 <= r && r <= 0xFF9F:
+		// halfwidth katakana
+		return 20
+	case unicode.Is(unicode.Han, r):
+		if c.level1(c.gbk, r, 0xB0, 0xD7, 0xA1) || c.level1(c.big5, r, 0xA4, 0xC6, 0x40) || c.level1(c.sjis, r, 0x88, 0x98, 0x40) {
+			return 0
+		}
+		return 20
+	case 0xAC00 <= r && r <= 0xD7A3:
+		if c.level1(c.euckr, r, 0xB0, 0xC8, 0xA1) {
+			return 0
+		}
+		return 20
+	}
+	return 1
+}
+
+// level1 whether r is encoded by e with a lead byte in [lo, hi] and a trail byte from trail
+func (c *charsetCommon) level1(e *encoding.Encoder, r rune, lo, hi, trail byte) bool {
+	b, err := e.Bytes([]byte(string(r)))
+	return err == nil && len(b) == 2 && lo <= b[0] && b[0] <= hi && b[1] >= trail
+}
+
+// Iconv iconv()
+// outCharset can end with //TRANSLIT to approximate the characters it can't represent and //IGNORE to drop them.
+// Iconv("UTF-8", "ASCII//TRANSLIT", "Café €5") = "Cafe EUR5"
+func Iconv(inCharset, outCharset, str string) (string, error) {
+	inName := strings.SplitN(inCharset, "//", 2)[0]
+	parts := strings.Split(outCharset, "//")
+	from, okFrom := charsetLookup(inName)
+	to, okTo := charsetLookup(parts[0])
+	if !okFrom || !okTo {
+		return "", fmt.Errorf(`iconv(): Wrong encoding, conversion from "%s" to "%s" is not allowed`, inCharset, outCharset)
+	}
+	mode := charsetFail
+	for _, flag := range parts[1:] {
+		switch strings.ToUpper(flag) {
+		case "IGNORE":
+			mode |= charsetIgnore
+		case "TRANSLIT":
+			mode |= charsetTranslit
+		}
+	}
+	s, _, err := transform.String(charsetTransformer(from, to, mode), str)
+	if err != nil {
+		return "", errors.New("iconv(): Detected an illegal character in input string")
+	}
+	return s, nil
+}
+
+// the handling of invalid input and of characters the output encoding can't represent
+const (
+	charsetFail = 0
+	// charsetReplace replaces them with '?', like mbstring
+	charsetReplace = 1 << iota
+	charsetIgnore
+	charsetTranslit
+)
+
+var errCharsetIllegal = errors.New("illegal character")
+
+// charsetTransformer the conversion from one encoding to another
+func charsetTransformer(from, to *charset, mode int) transform.Transformer {
+	var ts []transform.Transformer
+	if from.encoder != nil {
+		ts = append(ts, from.newDecoder())
+	}
+	ts = append(ts, &charsetValidator{mode: mode, fromUTF8: from.encoder == nil})
+	if to.encoder != nil {
+		ts = append(ts, &charsetEncoder{Transformer: to.encoder.NewEncoder(), encoding: to.encoder, mode: mode})
+	}
+	return transform.Chain(ts...)
+}
+
+// charsetValidator handles the invalid sequences of the decoded input, U+FFFD when it wasn't UTF-8
+type charsetValidator struct {
+	transform.NopResetter
+	mode     int
+	fromUTF8 bool
+}
+
+func (v *charsetValidator) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		if r == utf8.RuneError && (size == 1 || !v.fromUTF8) {
+			switch {
+			case v.mode&charsetIgnore != 0:
+				nSrc += size
+				continue
+			case v.mode&charsetReplace != 0:
+				if nDst >= len(dst) {
+					return nDst, nSrc, transform.ErrShortDst
+				}
+				dst[nDst] = '?'
+				nDst++
+				nSrc += size
+				continue
+			}
+			return nDst, nSrc, errCharsetIllegal
+		}
+		if nDst+size > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], src[nSrc:nSrc+size])
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+// charsetEncoder an encoder that handles the characters it can't represent according to mode
+type charsetEncoder struct {
+	transform.Transformer
+	encoding encoding.Encoding
+	mode     int
+}
+
+func (e *charsetEncoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	nDst, nSrc, err = e.Transformer.Transform(dst, src, atEOF)
+	for err != nil {
+		// the x/text encoders stop at the rune they can't encode with an error that has a Replacement method
+		if _, ok := err.(interface{ Replacement() byte }); !ok {
+			return nDst, nSrc, err
+		}
+		r, size := utf8.DecodeRune(src[nSrc:])
+		var replacement []byte
+		switch {
+		case e.mode&charsetTranslit != 0:
+			replacement = []byte{'?'}
+			if s, err := e.encoding.NewEncoder().String(charsetTranslitRune(r)); err == nil {
+				replacement = []byte(s)
+			}
+		case e.mode&charsetIgnore != 0:
+		case e.mode&charsetReplace != 0:
+			replacement = []byte{'?'}
+		default:
+			return nDst, nSrc, errCharsetIllegal
+		}
+		if nDst+len(replacement) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], replacement)
+		if nSrc += size; nSrc >= len(src) {
+			return nDst, nSrc, nil
+		}
+		var d, s int
+		d, s, err = e.Transformer.Transform(dst[nDst:], src[nSrc:], atEOF)
+		nDst, nSrc = nDst+d, nSrc+s
+	}
+	return nDst, nSrc, nil
+}
+
+// charsetTranslitTable the approximations of //TRANSLIT that aren't decompositions
+var charsetTranslitTable = map[rune]string{
+	'€': "EUR", '£': "GBP", '¥': "JPY", '©': "(C)", '®': "(R)", '™': "(TM)", '°': "^0",
+	'«': "<<", '»': ">>", '‘': "'", '’': "'", '‚': ",", '“': `"`, '”': `"`, '„': `"`,
+	'–': "-", '—': "-", '…': "...", '•': "o", '·': ".", '×': "x", '÷': ":",
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE", 'ø': "o", 'Ø': "O",
+	'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D", 'þ': "th", 'Þ': "TH", 'ð': "d", 'ı': "i",
+	'¼': " 1/4", '½': " 1/2", '¾': " 3/4", ' ': " ",
+}
+
+// charsetTranslitRune the approximation of r: from the table, or its compatibility decomposition without the marks
+func charsetTranslitRune(r rune) string {
+	if s, ok := charsetTranslitTable[r]; ok {
+		return s
+	}
+	var b strings.Builder
+	for _, d := range norm.NFKD.String(string(r)) {
+		if !unicode.Is(unicode.Mn, d) {
+			b.WriteRune(d)
+		}
+	}
+	if b.Len() == 0 {
+		return "?"
+	}
+	return b.String()
+}
+
+// charsetASCII the ASCII encoding, which x/text doesn't provide
+type charsetASCII struct{}
+
+func (charsetASCII) NewDecoder() *encoding.Decoder {
+	return &encoding.Decoder{Transformer: charsetASCIIDecoder{}}
+}
+
+func (charsetASCII) NewEncoder() *encoding.Encoder {
+	return &encoding.Encoder{Transformer: charsetASCIIEncoder{}}
+}
+
+type charsetASCIIDecoder struct{ transform.NopResetter }
+
+func (charsetASCIIDecoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for ; nSrc < len(src); nSrc++ {
+		s := []byte{src[nSrc]}
+		if src[nSrc] >= utf8.RuneSelf {
+			s = []byte("�")
+		}
+		if nDst+len(s) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], s)
+	}
+	return nDst, nSrc, nil
+}
+
+type charsetASCIIEncoder struct{ transform.NopResetter }
+
+// charsetRepertoireError the error of charsetASCIIEncoder for the runes above 0x7F
+type charsetRepertoireError struct{}
+
+func (charsetRepertoireError) Error() string {
+	return "rune not supported by ASCII"
+}
+
+func (charsetRepertoireError) Replacement() byte {
+	return '?'
+}
+
+func (charsetASCIIEncoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for ; nSrc < len(src); nSrc++ {
+		if src[nSrc] >= utf8.RuneSelf {
+			return nDst, nSrc, charsetRepertoireError{}
+		}
+		if nDst >= len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		dst[nDst] = src[nSrc]
+		nDst++
+	}
+	return nDst, nSrc, nil
+}
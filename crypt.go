@@ -0,0 +1,551 @@
+package php2go
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"hash"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/blowfish"
+)
+
+// cryptItoa64 the alphabet of the crypt(3) encodings
+const cryptItoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// bcryptEncoding the base64 of bcrypt, with the crypt alphabet in a different order
+var bcryptEncoding = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// Crypt crypt()
+// One-way string hashing, the scheme is chosen by salt:
+// "rl" standard DES, "_J9..rasm" extended DES, "$1$rasmusle$" MD5, "$2y$07$usesomesillystringforsalt$" Blowfish,
+// "$5$rounds=5000$usesomesillystringforsalt$" SHA-256 and "$6$rounds=5000$usesomesillystringforsalt$" SHA-512.
+// On failure "*0" is returned, or "*1" when salt starts with "*0".
+// Crypt("rasmuslerdorf", "$1$rasmusle$") = "$1$rasmusle$rISCgZzpwk3UhDidwXvin0"
+func Crypt(str, salt string) string {
+	// the password is a C string
+	if i := strings.IndexByte(str, 0); i >= 0 {
+		str = str[:i]
+	}
+	var hashed string
+	var ok bool
+	switch {
+	case strings.HasPrefix(salt, "$1$"):
+		hashed, ok = md5Crypt(str, salt), true
+	case len(salt) > 3 && salt[0] == '$' && salt[1] == '2' && salt[3] == '$':
+		hashed, ok = bcryptCrypt(str, salt)
+	case strings.HasPrefix(salt, "$5$"):
+		hashed, ok = shaCrypt(str, salt, "$5$", sha256.New, sha256CryptPerm)
+	case strings.HasPrefix(salt, "$6$"):
+		hashed, ok = shaCrypt(str, salt, "$6$", sha512.New, sha512CryptPerm)
+	case len(salt) > 0 && salt[0] == '_', len(salt) > 1 && cryptIsSaltChar(salt[0]) && cryptIsSaltChar(salt[1]):
+		hashed, ok = desCrypt(str, salt)
+	}
+	if !ok {
+		if strings.HasPrefix(salt, "*0") {
+			return "*1"
+		}
+		return "*0"
+	}
+	return hashed
+}
+
+func cryptIsSaltChar(c byte) bool {
+	return '.' <= c && c <= '9' || 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z'
+}
+
+// cryptTo64 appends the n low 6 bit groups of v, least significant first
+func cryptTo64(buf []byte, v uint32, n int) []byte {
+	for ; n > 0; n-- {
+		buf = append(buf, cryptItoa64[v&0x3f])
+		v >>= 6
+	}
+	return buf
+}
+
+// md5Crypt php_md5_crypt_r()
+func md5Crypt(pw, salt string) string {
+	const magic = "$1$"
+	salt = salt[len(magic):]
+	if i := strings.IndexByte(salt, '$'); i >= 0 {
+		salt = salt[:i]
+	}
+	if len(salt) > 8 {
+		salt = salt[:8]
+	}
+
+	ctx := md5.New()
+	ctx.Write([]byte(pw + magic + salt))
+	alt := md5.Sum([]byte(pw + salt + pw))
+	for pl := len(pw); pl > 0; pl -= 16 {
+		if pl > 16 {
+			ctx.Write(alt[:])
+		} else {
+			ctx.Write(alt[:pl])
+		}
+	}
+	for i := len(pw); i != 0; i >>= 1 {
+		if i&1 != 0 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write([]byte{pw[0]})
+		}
+	}
+	final := ctx.Sum(nil)
+
+	// and now, just to make sure things don't run too fast
+	for i := 0; i < 1000; i++ {
+		ctx.Reset()
+		if i&1 != 0 {
+			ctx.Write([]byte(pw))
+		} else {
+			ctx.Write(final)
+		}
+		if i%3 != 0 {
+			ctx.Write([]byte(salt))
+		}
+		if i%7 != 0 {
+			ctx.Write([]byte(pw))
+		}
+		if i&1 != 0 {
+			ctx.Write(final)
+		} else {
+			ctx.Write([]byte(pw))
+		}
+		final = ctx.Sum(final[:0])
+	}
+
+	buf := []byte(magic + salt + "$")
+	for _, g := range [5][3]int{{0, 6, 12}, {1, 7, 13}, {2, 8, 14}, {3, 9, 15}, {4, 10, 5}} {
+		buf = cryptTo64(buf, uint32(final[g[0]])<<16|uint32(final[g[1]])<<8|uint32(final[g[2]]), 4)
+	}
+	return string(cryptTo64(buf, uint32(final[11]), 2))
+}
+
+// sha256CryptPerm and sha512CryptPerm the order the digest bytes are encoded in, by groups of 3
+var (
+	sha256CryptPerm = []int{
+		0, 10, 20, 21, 1, 11, 12, 22, 2, 3, 13, 23, 24, 4, 14,
+		15, 25, 5, 6, 16, 26, 27, 7, 17, 18, 28, 8, 9, 19, 29,
+		-1, 31, 30,
+	}
+	sha512CryptPerm = []int{
+		0, 21, 42, 22, 43, 1, 44, 2, 23, 3, 24, 45, 25, 46, 4,
+		47, 5, 26, 6, 27, 48, 28, 49, 7, 50, 8, 29, 9, 30, 51,
+		31, 52, 10, 53, 11, 32, 12, 33, 54, 34, 55, 13, 56, 14, 35,
+		15, 36, 57, 37, 58, 16, 59, 17, 38, 18, 39, 60, 40, 61, 19,
+		62, 20, 41, -1, -1, 63,
+	}
+)
+
+// shaCrypt php_sha256_crypt_r() and php_sha512_crypt_r(), Ulrich Drepper's SHA-crypt
+func shaCrypt(pw, salt, magic string, newHash func() hash.Hash, perm []int) (string, bool) {
+	const roundsPrefix = "rounds="
+	salt = salt[len(magic):]
+	rounds, roundsCustom := 5000, false
+	if strings.HasPrefix(salt, roundsPrefix) {
+		num := salt[len(roundsPrefix):]
+		end := strings.IndexByte(num, '$')
+		if end < 0 {
+			return "", false
+		}
+		n, err := strconv.ParseUint(num[:end], 10, 64)
+		if err != nil || n < 1000 || n > 999999999 {
+			return "", false
+		}
+		salt = num[end+1:]
+		rounds, roundsCustom = int(n), true
+	}
+	if i := strings.IndexByte(salt, '$'); i >= 0 {
+		salt = salt[:i]
+	}
+	if len(salt) > 16 {
+		salt = salt[:16]
+	}
+
+	ctx := newHash()
+	size := ctx.Size()
+	ctx.Write([]byte(pw + salt + pw))
+	alt := ctx.Sum(nil)
+
+	ctx.Reset()
+	ctx.Write([]byte(pw + salt))
+	cnt := len(pw)
+	for ; cnt > size; cnt -= size {
+		ctx.Write(alt)
+	}
+	ctx.Write(alt[:cnt])
+	for cnt = len(pw); cnt > 0; cnt >>= 1 {
+		if cnt&1 != 0 {
+			ctx.Write(alt)
+		} else {
+			ctx.Write([]byte(pw))
+		}
+	}
+	final := ctx.Sum(nil)
+
+	// the P sequence
+	ctx.Reset()
+	for i := 0; i < len(pw); i++ {
+		ctx.Write([]byte(pw))
+	}
+	dp := ctx.Sum(nil)
+	p := make([]byte, 0, len(pw)+size)
+	for len(p) < len(pw) {
+		p = append(p, dp...)
+	}
+	p = p[:len(pw)]
+
+	// the S sequence
+	ctx.Reset()
+	for i := 0; i < 16+int(final[0]); i++ {
+		ctx.Write([]byte(salt))
+	}
+	ds := ctx.Sum(nil)
+	s := make([]byte, 0, len(salt)+size)
+	for len(s) < len(salt) {
+		s = append(s, ds...)
+	}
+	s = s[:len(salt)]
+
+	for r := 0; r < rounds; r++ {
+		ctx.Reset()
+		if r&1 != 0 {
+			ctx.Write(p)
+		} else {
+			ctx.Write(final)
+		}
+		if r%3 != 0 {
+			ctx.Write(s)
+		}
+		if r%7 != 0 {
+			ctx.Write(p)
+		}
+		if r&1 != 0 {
+			ctx.Write(final)
+		} else {
+			ctx.Write(p)
+		}
+		final = ctx.Sum(final[:0])
+	}
+
+	buf := []byte(magic)
+	if roundsCustom {
+		buf = append(buf, roundsPrefix+strconv.Itoa(rounds)+"$"...)
+	}
+	buf = append(buf, salt+"$"...)
+	for i := 0; i < len(perm); i += 3 {
+		var v uint32
+		n := 4
+		for _, j := range perm[i : i+3] {
+			v <<= 8
+			if j < 0 {
+				n--
+			} else {
+				v |= uint32(final[j])
+			}
+		}
+		buf = cryptTo64(buf, v, n)
+	}
+	return string(buf), true
+}
+
+// bcryptCrypt php_crypt_blowfish_rn(), the setting is $2a$, $2b$ or $2y$, the cost and 22 characters of salt.
+// The $2x$ variant only hashes passwords without 8-bit characters, its sign extension bug isn't emulated.
+func bcryptCrypt(pw, setting string) (string, bool) {
+	if len(setting) < 29 || setting[6] != '$' || !isDigit(setting[4]) || !isDigit(setting[5]) {
+		return "", false
+	}
+	switch setting[2] {
+	case 'a', 'b', 'y':
+	case 'x':
+		for i := 0; i < len(pw); i++ {
+			if pw[i] >= 0x80 {
+				return "", false
+			}
+		}
+	default:
+		return "", false
+	}
+	cost := int(setting[4]-'0')*10 + int(setting[5]-'0')
+	if cost < 4 || cost > 31 {
+		return "", false
+	}
+	for i := 7; i < 29; i++ {
+		if strings.IndexByte(cryptItoa64, setting[i]) < 0 {
+			return "", false
+		}
+	}
+	salt, err := bcryptEncoding.DecodeString(setting[7:29])
+	if err != nil {
+		return "", false
+	}
+	sum := bcrypt([]byte(pw), salt, cost)
+	return setting[:7] + bcryptEncoding.EncodeToString(salt) + bcryptEncoding.EncodeToString(sum), true
+}
+
+// bcrypt the 23 bytes of the Blowfish encrypted "OrpheanBeholderScryDoubt"
+func bcrypt(pw, salt []byte, cost int) []byte {
+	key := append(pw[:len(pw):len(pw)], 0)
+	if len(key) > 72 {
+		key = key[:72]
+	}
+	c, _ := blowfish.NewSaltedCipher(key, salt)
+	for i := uint64(0); i < 1<<uint(cost); i++ {
+		blowfish.ExpandKey(key, c)
+		blowfish.ExpandKey(salt, c)
+	}
+	ctext := []byte("OrpheanBeholderScryDoubt")
+	for i := 0; i < len(ctext); i += blowfish.BlockSize {
+		for j := 0; j < 64; j++ {
+			c.Encrypt(ctext[i:i+blowfish.BlockSize], ctext[i:i+blowfish.BlockSize])
+		}
+	}
+	return ctext[:23]
+}
+
+// desCrypt _crypt_extended_r() of FreeSec, the standard DES "rl" and the extended BSDi DES "_J9..rasm" settings
+func desCrypt(pw, setting string) (string, bool) {
+	at := func(i int) byte {
+		if i < len(setting) {
+			return setting[i]
+		}
+		return 0
+	}
+	var keybuf [8]byte
+	k := 0
+	for i := range keybuf {
+		if k < len(pw) {
+			keybuf[i] = pw[k] << 1
+			k++
+		}
+	}
+	var d cryptDES
+	d.setKey(keybuf)
+
+	var count, salt uint32
+	var buf []byte
+	if at(0) == '_' {
+		// "new"-style: an underscore, then 4 characters of count, then 4 characters of salt
+		for i := 1; i < 9; i++ {
+			v := cryptAsciiToBin(at(i))
+			if cryptItoa64[v] != at(i) {
+				return "", false
+			}
+			if i < 5 {
+				count |= v << ((i - 1) * 6)
+			} else {
+				salt |= v << ((i - 5) * 6)
+			}
+		}
+		if count == 0 {
+			return "", false
+		}
+		for k < len(pw) {
+			// encrypt the key with itself, and XOR with the next 8 characters of the key
+			d.setSalt(0)
+			l, r := d.encrypt(beUint32(keybuf[:4]), beUint32(keybuf[4:]), 1)
+			bePutUint32(keybuf[:4], l)
+			bePutUint32(keybuf[4:], r)
+			for i := 0; i < len(keybuf) && k < len(pw); i++ {
+				keybuf[i] ^= pw[k] << 1
+				k++
+			}
+			d.setKey(keybuf)
+		}
+		buf = []byte(setting[:9])
+	} else {
+		// "old"-style: 2 characters of salt, the key is up to 8 characters
+		count = 25
+		salt = cryptAsciiToBin(at(1))<<6 | cryptAsciiToBin(at(0))
+		buf = []byte(setting[:2])
+	}
+
+	d.setSalt(salt)
+	r0, r1 := d.encrypt(0, 0, int(count))
+	buf = cryptTo64Be(buf, r0>>8, 4)
+	buf = cryptTo64Be(buf, r0<<16|r1>>16&0xffff, 4)
+	return string(cryptTo64Be(buf, r1<<2, 3)), true
+}
+
+// cryptTo64Be appends the n low 6 bit groups of v, most significant first
+func cryptTo64Be(buf []byte, v uint32, n int) []byte {
+	for n--; n >= 0; n-- {
+		buf = append(buf, cryptItoa64[v>>(uint(n)*6)&0x3f])
+	}
+	return buf
+}
+
+// cryptAsciiToBin ascii_to_bin()
+func cryptAsciiToBin(c byte) uint32 {
+	ch := int(int8(c))
+	v := ch - '.'
+	if ch >= 'A' {
+		v = ch - ('A' - 12)
+		if ch >= 'a' {
+			v = ch - ('a' - 38)
+		}
+	}
+	return uint32(v) & 0x3f
+}
+
+func beUint32(b []byte) uint32 {
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+func bePutUint32(b []byte, v uint32) {
+	b[0], b[1], b[2], b[3] = byte(v>>24), byte(v>>16), byte(v>>8), byte(v)
+}
+
+// cryptDES the DES of crypt(3): the E expansion bits i and i+24 are swapped for the bits i set in the salt
+type cryptDES struct {
+	subkeys   [16]uint64
+	saltbits  uint64
+	saltShift [24]uint
+}
+
+var (
+	desIP = [64]uint8{
+		58, 50, 42, 34, 26, 18, 10, 2, 60, 52, 44, 36, 28, 20, 12, 4,
+		62, 54, 46, 38, 30, 22, 14, 6, 64, 56, 48, 40, 32, 24, 16, 8,
+		57, 49, 41, 33, 25, 17, 9, 1, 59, 51, 43, 35, 27, 19, 11, 3,
+		61, 53, 45, 37, 29, 21, 13, 5, 63, 55, 47, 39, 31, 23, 15, 7,
+	}
+	desE = [48]uint8{
+		32, 1, 2, 3, 4, 5, 4, 5, 6, 7, 8, 9,
+		8, 9, 10, 11, 12, 13, 12, 13, 14, 15, 16, 17,
+		16, 17, 18, 19, 20, 21, 20, 21, 22, 23, 24, 25,
+		24, 25, 26, 27, 28, 29, 28, 29, 30, 31, 32, 1,
+	}
+	desP = [32]uint8{
+		16, 7, 20, 21, 29, 12, 28, 17, 1, 15, 23, 26, 5, 18, 31, 10,
+		2, 8, 24, 14, 32, 27, 3, 9, 19, 13, 30, 6, 22, 11, 4, 25,
+	}
+	desPC1 = [56]uint8{
+		57, 49, 41, 33, 25, 17, 9, 1, 58, 50, 42, 34, 26, 18,
+		10, 2, 59, 51, 43, 35, 27, 19, 11, 3, 60, 52, 44, 36,
+		63, 55, 47, 39, 31, 23, 15, 7, 62, 54, 46, 38, 30, 22,
+		14, 6, 61, 53, 45, 37, 29, 21, 13, 5, 28, 20, 12, 4,
+	}
+	desPC2 = [48]uint8{
+		14, 17, 11, 24, 1, 5, 3, 28, 15, 6, 21, 10,
+		23, 19, 12, 4, 26, 8, 16, 7, 27, 20, 13, 2,
+		41, 52, 31, 37, 47, 55, 30, 40, 51, 45, 33, 48,
+		44, 49, 39, 56, 34, 53, 46, 42, 50, 36, 29, 32,
+	}
+	desShifts = [16]uint8{1, 1, 2, 2, 2, 2, 2, 2, 1, 2, 2, 2, 2, 2, 2, 1}
+	desS      = [8][64]uint8{
+		{
+			14, 4, 13, 1, 2, 15, 11, 8, 3, 10, 6, 12, 5, 9, 0, 7,
+			0, 15, 7, 4, 14, 2, 13, 1, 10, 6, 12, 11, 9, 5, 3, 8,
+			4, 1, 14, 8, 13, 6, 2, 11, 15, 12, 9, 7, 3, 10, 5, 0,
+			15, 12, 8, 2, 4, 9, 1, 7, 5, 11, 3, 14, 10, 0, 6, 13,
+		},
+		{
+			15, 1, 8, 14, 6, 11, 3, 4, 9, 7, 2, 13, 12, 0, 5, 10,
+			3, 13, 4, 7, 15, 2, 8, 14, 12, 0, 1, 10, 6, 9, 11, 5,
+			0, 14, 7, 11, 10, 4, 13, 1, 5, 8, 12, 6, 9, 3, 2, 15,
+			13, 8, 10, 1, 3, 15, 4, 2, 11, 6, 7, 12, 0, 5, 14, 9,
+		},
+		{
+			10, 0, 9, 14, 6, 3, 15, 5, 1, 13, 12, 7, 11, 4, 2, 8,
+			13, 7, 0, 9, 3, 4, 6, 10, 2, 8, 5, 14, 12, 11, 15, 1,
+			13, 6, 4, 9, 8, 15, 3, 0, 11, 1, 2, 12, 5, 10, 14, 7,
+			1, 10, 13, 0, 6, 9, 8, 7, 4, 15, 14, 3, 11, 5, 2, 12,
+		},
+		{
+			7, 13, 14, 3, 0, 6, 9, 10, 1, 2, 8, 5, 11, 12, 4, 15,
+			13, 8, 11, 5, 6, 15, 0, 3, 4, 7, 2, 12, 1, 10, 14, 9,
+			10, 6, 9, 0, 12, 11, 7, 13, 15, 1, 3, 14, 5, 2, 8, 4,
+			3, 15, 0, 6, 10, 1, 13, 8, 9, 4, 5, 11, 12, 7, 2, 14,
+		},
+		{
+			2, 12, 4, 1, 7, 10, 11, 6, 8, 5, 3, 15, 13, 0, 14, 9,
+			14, 11, 2, 12, 4, 7, 13, 1, 5, 0, 15, 10, 3, 9, 8, 6,
+			4, 2, 1, 11, 10, 13, 7, 8, 15, 9, 12, 5, 6, 3, 0, 14,
+			11, 8, 12, 7, 1, 14, 2, 13, 6, 15, 0, 9, 10, 4, 5, 3,
+		},
+		{
+			12, 1, 10, 15, 9, 2, 6, 8, 0, 13, 3, 4, 14, 7, 5, 11,
+			10, 15, 4, 2, 7, 12, 9, 5, 6, 1, 13, 14, 0, 11, 3, 8,
+			9, 14, 15, 5, 2, 8, 12, 3, 7, 0, 4, 10, 1, 13, 11, 6,
+			4, 3, 2, 12, 9, 5, 15, 10, 11, 14, 1, 7, 6, 0, 8, 13,
+		},
+		{
+			4, 11, 2, 14, 15, 0, 8, 13, 3, 12, 9, 7, 5, 10, 6, 1,
+			13, 0, 11, 7, 4, 9, 1, 10, 14, 3, 5, 12, 2, 15, 8, 6,
+			1, 4, 11, 13, 12, 3, 7, 14, 10, 15, 6, 8, 0, 5, 9, 2,
+			6, 11, 13, 8, 1, 4, 10, 7, 9, 5, 0, 15, 14, 2, 3, 12,
+		},
+		{
+			13, 2, 8, 4, 6, 15, 11, 1, 10, 9, 3, 14, 5, 0, 12, 7,
+			1, 15, 13, 8, 10, 3, 7, 4, 12, 5, 6, 11, 0, 14, 9, 2,
+			7, 11, 4, 1, 9, 12, 14, 2, 0, 6, 10, 13, 15, 3, 5, 8,
+			2, 1, 14, 7, 4, 10, 8, 13, 15, 12, 9, 0, 3, 5, 6, 11,
+		},
+	}
+)
+
+// desPermute picks the bits of table, numbered from 1 at the most significant of the width bits of in
+func desPermute(in uint64, width uint, table []uint8) uint64 {
+	var out uint64
+	for _, pos := range table {
+		out = out<<1 | in>>(width-uint(pos))&1
+	}
+	return out
+}
+
+func (d *cryptDES) setKey(key [8]byte) {
+	cd := desPermute(uint64(beUint32(key[:4]))<<32|uint64(beUint32(key[4:])), 64, desPC1[:])
+	c, dd := cd>>28, cd&0xfffffff
+	for i, shift := range desShifts {
+		c = (c<<shift | c>>(28-shift)) & 0xfffffff
+		dd = (dd<<shift | dd>>(28-shift)) & 0xfffffff
+		d.subkeys[i] = desPermute(c<<28|dd, 56, desPC2[:])
+	}
+}
+
+// setSalt setup_salt(), salt bit i swaps the bits i and i+24 of the 48 expanded bits, counting from the most significant
+func (d *cryptDES) setSalt(salt uint32) {
+	d.saltbits = 0
+	for i := uint(0); i < 24; i++ {
+		if salt&(1<<i) != 0 {
+			d.saltbits |= 1 << (47 - i)
+		}
+	}
+}
+
+// encrypt do_des(), count encryptions of the block l, r
+func (d *cryptDES) encrypt(l, r uint32, count int) (uint32, uint32) {
+	block := desPermute(uint64(l)<<32|uint64(r), 64, desIP[:])
+	l, r = uint32(block>>32), uint32(block)
+	for ; count > 0; count-- {
+		for i := 0; i < 16; i++ {
+			e := desPermute(uint64(r), 32, desE[:])
+			// swap the salted bits of the two 24 bit halves
+			f := (e>>24 ^ e) & (d.saltbits >> 24)
+			e ^= f<<24 | f
+			e ^= d.subkeys[i]
+			var s uint64
+			for j := 0; j < 8; j++ {
+				b := e >> (42 - 6*uint(j)) & 0x3f
+				s = s<<4 | uint64(desS[j][b&0x20|b&1<<4|b>>1&0xf])
+			}
+			l, r = r, l^uint32(desPermute(s, 32, desP[:]))
+		}
+		l, r = r, l
+	}
+	block = desFinalPermute(uint64(l)<<32 | uint64(r))
+	return uint32(block >> 32), uint32(block)
+}
+
+// desFinalPermute the inverse of desIP
+func desFinalPermute(in uint64) uint64 {
+	var out uint64
+	for i, pos := range desIP {
+		out |= (in >> (63 - uint(i)) & 1) << (64 - uint(pos))
+	}
+	return out
+}
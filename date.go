@@ -0,0 +1,1095 @@
+package php2go
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dateDayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+var dateMonthNames = []string{"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December"}
+
+// DateFormat date_format()
+// Format a time.Time with php's date() format characters, "\" escapes the next character.
+// DateFormat(t, "Y-m-d H:i:s") == "2018-04-27 03:23:14"
+func DateFormat(t time.Time, format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		switch c {
+		// day
+		case 'd':
+			b.WriteString(datePad(t.Day(), 2))
+		case 'D':
+			b.WriteString(dateDayNames[t.Weekday()][:3])
+		case 'j':
+			b.WriteString(strconv.Itoa(t.Day()))
+		case 'l':
+			b.WriteString(dateDayNames[t.Weekday()])
+		case 'N':
+			n := int(t.Weekday())
+			if n == 0 {
+				n = 7
+			}
+			b.WriteString(strconv.Itoa(n))
+		case 'S':
+			b.WriteString(dateDaySuffix(t.Day()))
+		case 'w':
+			b.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'z':
+			b.WriteString(strconv.Itoa(t.YearDay() - 1))
+		// week
+		case 'W':
+			_, week := t.ISOWeek()
+			b.WriteString(datePad(week, 2))
+		// month
+		case 'F':
+			b.WriteString(dateMonthNames[t.Month()-1])
+		case 'm':
+			b.WriteString(datePad(int(t.Month()), 2))
+		case 'M':
+			b.WriteString(dateMonthNames[t.Month()-1][:3])
+		case 'n':
+			b.WriteString(strconv.Itoa(int(t.Month())))
+		case 't':
+			b.WriteString(strconv.Itoa(dateDaysInMonth(t.Year(), int(t.Month()))))
+		// year
+		case 'L':
+			if dateIsLeapYear(t.Year()) {
+				b.WriteByte('1')
+			} else {
+				b.WriteByte('0')
+			}
+		case 'o':
+			year, _ := t.ISOWeek()
+			b.WriteString(dateYear(year))
+		case 'Y':
+			b.WriteString(dateYear(t.Year()))
+		case 'y':
+			b.WriteString(datePad(t.Year()%100, 2))
+		// time
+		case 'a':
+			if t.Hour() < 12 {
+				b.WriteString("am")
+			} else {
+				b.WriteString("pm")
+			}
+		case 'A':
+			if t.Hour() < 12 {
+				b.WriteString("AM")
+			} else {
+				b.WriteString("PM")
+			}
+		case 'B':
+			b.WriteString(datePad(dateSwatch(t), 3))
+		case 'g':
+			b.WriteString(strconv.Itoa(dateHour12(t.Hour())))
+		case 'G':
+			b.WriteString(strconv.Itoa(t.Hour()))
+		case 'h':
+			b.WriteString(datePad(dateHour12(t.Hour()), 2))
+		case 'H':
+			b.WriteString(datePad(t.Hour(), 2))
+		case 'i':
+			b.WriteString(datePad(t.Minute(), 2))
+		case 's':
+			b.WriteString(datePad(t.Second(), 2))
+		case 'u':
+			b.WriteString(datePad(t.Nanosecond()/1000, 6))
+		case 'v':
+			b.WriteString(datePad(t.Nanosecond()/1000000, 3))
+		// timezone
+		case 'e':
+			b.WriteString(dateZoneName(t))
+		case 'I':
+			if t.IsDST() {
+				b.WriteByte('1')
+			} else {
+				b.WriteByte('0')
+			}
+		case 'O':
+			b.WriteString(dateOffset(t, false, false))
+		case 'P':
+			b.WriteString(dateOffset(t, true, false))
+		case 'p':
+			b.WriteString(dateOffset(t, true, true))
+		case 'T':
+			b.WriteString(dateZoneAbbr(t))
+		case 'Z':
+			_, offset := t.Zone()
+			b.WriteString(strconv.Itoa(offset))
+		// full date/time
+		case 'c':
+			b.WriteString(DateFormat(t, "Y-m-d\\TH:i:sP"))
+		case 'r':
+			b.WriteString(DateFormat(t, "D, d M Y H:i:s O"))
+		case 'U':
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case '\\':
+			if i+1 < len(format) {
+				i++
+				b.WriteByte(format[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// datePad zero pads a non negative number to width digits.
+func datePad(n int, width int) string {
+	s := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + datePad(-n, width)
+	}
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
+
+// dateYear full year, at least 4 digits, "-" for years BCE
+func dateYear(year int) string {
+	if year < 0 {
+		return "-" + datePad(-year, 4)
+	}
+	return datePad(year, 4)
+}
+
+func dateHour12(hour int) int {
+	hour %= 12
+	if hour == 0 {
+		return 12
+	}
+	return hour
+}
+
+func dateDaySuffix(day int) string {
+	if day >= 10 && day <= 19 {
+		return "th"
+	}
+	switch day % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+// dateSwatch Swatch Internet time, computed on UTC+1
+func dateSwatch(t time.Time) int {
+	beat := ((t.Unix() % 86400) + 3600) * 10
+	if beat < 0 {
+		beat += 864000
+	}
+	return int((beat / 864) % 1000)
+}
+
+func dateIsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func dateDaysInMonth(year, month int) int {
+	switch month {
+	case 4, 6, 9, 11:
+		return 30
+	case 2:
+		if dateIsLeapYear(year) {
+			return 29
+		}
+		return 28
+	}
+	return 31
+}
+
+// dateOffset +0200, +02:00 or Z for p when the offset is zero
+func dateOffset(t time.Time, colon bool, zulu bool) string {
+	_, offset := t.Zone()
+	if zulu && offset == 0 {
+		return "Z"
+	}
+	return dateFormatOffset(offset, colon)
+}
+
+func dateFormatOffset(offset int, colon bool) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	s := sign + datePad(offset/3600, 2)
+	if colon {
+		s += ":"
+	}
+	return s + datePad(offset%3600/60, 2)
+}
+
+// dateZoneName timezone identifier, offset zones are printed like +02:00
+func dateZoneName(t time.Time) string {
+	name := t.Location().String()
+	if name == "Local" && dateLocalName() != "" {
+		return dateLocalName()
+	}
+	if name == "" || name == "Local" {
+		abbr, _ := t.Zone()
+		if name == "Local" && abbr != "" && abbr[0] != '+' && abbr[0] != '-' {
+			return abbr
+		}
+		return dateOffset(t, true, false)
+	}
+	return name
+}
+
+// dateZoneAbbr timezone abbreviation, offset zones are printed like +02:00
+func dateZoneAbbr(t time.Time) string {
+	abbr, _ := t.Zone()
+	if abbr == "" {
+		return dateOffset(t, true, false)
+	}
+	return abbr
+}
+
+// dateStrftime format t with C strftime() conversion specifications
+func dateStrftime(t time.Time, format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'a':
+			b.WriteString(dateDayNames[t.Weekday()][:3])
+		case 'A':
+			b.WriteString(dateDayNames[t.Weekday()])
+		case 'd':
+			b.WriteString(datePad(t.Day(), 2))
+		case 'e':
+			b.WriteString(dateSpacePad(t.Day()))
+		case 'j':
+			b.WriteString(datePad(t.YearDay(), 3))
+		case 'u':
+			b.WriteString(DateFormat(t, "N"))
+		case 'w':
+			b.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'U':
+			b.WriteString(datePad((t.YearDay()+6-int(t.Weekday()))/7, 2))
+		case 'V':
+			b.WriteString(DateFormat(t, "W"))
+		case 'W':
+			b.WriteString(datePad((t.YearDay()+6-(int(t.Weekday())+6)%7)/7, 2))
+		case 'b', 'h':
+			b.WriteString(dateMonthNames[t.Month()-1][:3])
+		case 'B':
+			b.WriteString(dateMonthNames[t.Month()-1])
+		case 'm':
+			b.WriteString(datePad(int(t.Month()), 2))
+		case 'C':
+			b.WriteString(datePad(t.Year()/100, 2))
+		case 'g':
+			year, _ := t.ISOWeek()
+			b.WriteString(datePad(year%100, 2))
+		case 'G':
+			b.WriteString(DateFormat(t, "o"))
+		case 'y':
+			b.WriteString(datePad(t.Year()%100, 2))
+		case 'Y':
+			b.WriteString(strconv.Itoa(t.Year()))
+		case 'H':
+			b.WriteString(datePad(t.Hour(), 2))
+		case 'k':
+			b.WriteString(dateSpacePad(t.Hour()))
+		case 'I':
+			b.WriteString(datePad(dateHour12(t.Hour()), 2))
+		case 'l':
+			b.WriteString(dateSpacePad(dateHour12(t.Hour())))
+		case 'M':
+			b.WriteString(datePad(t.Minute(), 2))
+		case 'p':
+			b.WriteString(DateFormat(t, "A"))
+		case 'P':
+			b.WriteString(DateFormat(t, "a"))
+		case 'r':
+			b.WriteString(dateStrftime(t, "%I:%M:%S %p"))
+		case 'R':
+			b.WriteString(dateStrftime(t, "%H:%M"))
+		case 'S':
+			b.WriteString(datePad(t.Second(), 2))
+		case 'T', 'X':
+			b.WriteString(dateStrftime(t, "%H:%M:%S"))
+		case 'z':
+			b.WriteString(DateFormat(t, "O"))
+		case 'Z':
+			b.WriteString(DateFormat(t, "T"))
+		case 'c':
+			b.WriteString(dateStrftime(t, "%a %b %e %H:%M:%S %Y"))
+		case 'D', 'x':
+			b.WriteString(dateStrftime(t, "%m/%d/%y"))
+		case 'F':
+			b.WriteString(dateStrftime(t, "%Y-%m-%d"))
+		case 's':
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
+
+func dateSpacePad(n int) string {
+	if n < 10 {
+		return " " + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+//////////// date parsing ////////////
+
+// dateUnset marks a field that was not found, like TIMELIB_UNSET
+const dateUnset = -9999999
+
+const (
+	dateZoneTypeOffset = 1
+	dateZoneTypeAbbr   = 2
+	dateZoneTypeID     = 3
+)
+
+// dateParsed the result of parsing a date string, fields not found are dateUnset
+type dateParsed struct {
+	y, m, d    int
+	h, i, s    int
+	us         int
+	doy        int
+	weekday    int
+	haveDate   bool
+	haveTime   bool
+	haveZone   bool
+	rel        dateRelative
+	haveRel    bool
+	unix       int64
+	haveUnix   bool
+	zoneType   int
+	zoneOffset int
+	zoneDst    bool
+	zoneAbbr   string
+	loc        *time.Location
+	warnings   map[int]string
+	errors     map[int]string
+}
+
+func newDateParsed() *dateParsed {
+	return &dateParsed{
+		y: dateUnset, m: dateUnset, d: dateUnset,
+		h: dateUnset, i: dateUnset, s: dateUnset,
+		us: dateUnset, doy: dateUnset, weekday: dateUnset,
+		warnings: map[int]string{},
+		errors:   map[int]string{},
+	}
+}
+
+func (p *dateParsed) addError(pos int, msg string) {
+	p.errors[pos] = msg
+}
+
+// firstError the error with the lowest position, nil when there is none
+func (p *dateParsed) firstError(length int) error {
+	for pos := 0; pos <= length; pos++ {
+		if msg, ok := p.errors[pos]; ok {
+			return errors.New(msg + " at position " + strconv.Itoa(pos))
+		}
+	}
+	return nil
+}
+
+func (p *dateParsed) addWarning(pos int, msg string) {
+	p.warnings[pos] = msg
+}
+
+// reset "!" resets all fields to the Unix Epoch
+func (p *dateParsed) reset() {
+	p.y, p.m, p.d = 1970, 1, 1
+	p.h, p.i, p.s, p.us = 0, 0, 0, 0
+	p.zoneType, p.zoneOffset, p.zoneDst, p.zoneAbbr, p.loc = 0, 0, false, "", nil
+}
+
+// resetUnset "|" resets the fields not parsed yet to the Unix Epoch
+func (p *dateParsed) resetUnset() {
+	if p.y == dateUnset {
+		p.y = 1970
+	}
+	if p.m == dateUnset {
+		p.m = 1
+	}
+	if p.d == dateUnset {
+		p.d = 1
+	}
+	if p.h == dateUnset {
+		p.h = 0
+	}
+	if p.i == dateUnset {
+		p.i = 0
+	}
+	if p.s == dateUnset {
+		p.s = 0
+	}
+	if p.us == dateUnset {
+		p.us = 0
+	}
+}
+
+// location the timezone found in the string, or loc
+func (p *dateParsed) location(loc *time.Location) *time.Location {
+	switch {
+	case p.loc != nil:
+		return p.loc
+	case p.zoneType != 0:
+		if p.zoneType == dateZoneTypeOffset {
+			return time.FixedZone("", p.zoneOffset)
+		}
+		offset := p.zoneOffset
+		if p.zoneDst {
+			offset += 3600
+		}
+		return time.FixedZone(p.zoneAbbr, offset)
+	}
+	return loc
+}
+
+// toTime fill the fields not found from now
+func (p *dateParsed) toTime(now time.Time) time.Time {
+	loc := p.location(now.Location())
+	now = now.In(loc)
+	if p.haveUnix {
+		return time.Unix(p.unix, 0).In(loc)
+	}
+	y, m, d, h, i, s, us := p.y, p.m, p.d, p.h, p.i, p.s, p.us
+	if y == dateUnset {
+		y = now.Year()
+	}
+	if m == dateUnset {
+		m = int(now.Month())
+	}
+	if d == dateUnset {
+		d = now.Day()
+	}
+	if p.doy != dateUnset {
+		m, d = 1, p.doy+1
+	}
+	if h == dateUnset {
+		h = now.Hour()
+	}
+	if i == dateUnset {
+		i = now.Minute()
+	}
+	if s == dateUnset {
+		s = now.Second()
+	}
+	if us == dateUnset {
+		us = 0
+		if p.y == dateUnset && p.m == dateUnset && p.d == dateUnset && p.h == dateUnset && p.i == dateUnset && p.s == dateUnset {
+			us = now.Nanosecond() / 1000
+		}
+	}
+	t := time.Date(y, time.Month(m), d, h, i, s, us*1000, loc)
+	if p.weekday != dateUnset {
+		t = t.AddDate(0, 0, (p.weekday-int(t.Weekday())+7)%7)
+	}
+	return t
+}
+
+// result date_parse() style array
+func (p *dateParsed) result() map[string]interface{} {
+	field := func(v int) interface{} {
+		if v == dateUnset {
+			return false
+		}
+		return v
+	}
+	ret := map[string]interface{}{
+		"year":          field(p.y),
+		"month":         field(p.m),
+		"day":           field(p.d),
+		"hour":          field(p.h),
+		"minute":        field(p.i),
+		"second":        field(p.s),
+		"fraction":      false,
+		"warning_count": len(p.warnings),
+		"warnings":      p.warnings,
+		"error_count":   len(p.errors),
+		"errors":        p.errors,
+		"is_localtime":  p.zoneType != 0,
+	}
+	if p.us != dateUnset {
+		ret["fraction"] = float64(p.us) / 1e6
+	}
+	if p.zoneType != 0 {
+		ret["zone_type"] = p.zoneType
+		ret["zone"] = p.zoneOffset
+		ret["is_dst"] = p.zoneDst
+		switch p.zoneType {
+		case dateZoneTypeAbbr:
+			ret["tz_abbr"] = p.zoneAbbr
+		case dateZoneTypeID:
+			ret["tz_id"] = p.loc.String()
+		}
+	}
+	if p.haveRel {
+		r := p.rel
+		relative := map[string]interface{}{
+			"year": r.y, "month": r.m, "day": r.d,
+			"hour": r.h, "minute": r.i, "second": r.s,
+		}
+		if r.haveWeekday {
+			relative["weekday"] = r.weekday
+		}
+		if r.haveSpecial && r.special == dateSpecialWeekday {
+			relative["weekdays"] = r.specialAmount
+		}
+		switch r.firstLast {
+		case dateFirstDayOf:
+			relative["first_day_of_month"] = true
+		case dateLastDayOf:
+			relative["last_day_of_month"] = true
+		}
+		ret["relative"] = relative
+	}
+	return ret
+}
+
+// dateZoneAbbrs timezone abbreviations, utc offset in seconds without dst and dst
+var dateZoneAbbrs = map[string]struct {
+	offset int
+	dst    bool
+}{
+	"utc":  {0, false},
+	"gmt":  {0, false},
+	"z":    {0, false},
+	"wet":  {0, false},
+	"west": {0, true},
+	"bst":  {0, true},
+	"cet":  {3600, false},
+	"cest": {3600, true},
+	"met":  {3600, false},
+	"mest": {3600, true},
+	"eet":  {7200, false},
+	"eest": {7200, true},
+	"msk":  {10800, false},
+	"ist":  {19800, false},
+	"hkt":  {28800, false},
+	"awst": {28800, false},
+	"sgt":  {28800, false},
+	"jst":  {32400, false},
+	"kst":  {32400, false},
+	"acst": {34200, false},
+	"aest": {36000, false},
+	"aedt": {36000, true},
+	"nzst": {43200, false},
+	"nzdt": {43200, true},
+	"hst":  {-36000, false},
+	"akst": {-32400, false},
+	"akdt": {-32400, true},
+	"pst":  {-28800, false},
+	"pdt":  {-28800, true},
+	"mst":  {-25200, false},
+	"mdt":  {-25200, true},
+	"cst":  {-21600, false},
+	"cdt":  {-21600, true},
+	"est":  {-18000, false},
+	"edt":  {-18000, true},
+	"ast":  {-14400, false},
+	"adt":  {-14400, true},
+}
+
+// dateParseZone parse a timezone at the start of str: offset, abbreviation or identifier.
+// It returns the number of bytes used, 0 if no timezone was found.
+func dateParseZone(str string, p *dateParsed) int {
+	n := 0
+	for n < len(str) && (str[n] == ' ' || str[n] == '\t' || str[n] == '(') {
+		n++
+	}
+	str = str[n:]
+	if len(str) > 4 && strings.EqualFold(str[:3], "gmt") && (str[3] == '+' || str[3] == '-') {
+		n += 3
+		str = str[3:]
+	}
+	if str == "" {
+		return 0
+	}
+	if str[0] == '+' || str[0] == '-' {
+		l := 1
+		for l < len(str) && (str[l] >= '0' && str[l] <= '9' || str[l] == ':') && l < 9 {
+			l++
+		}
+		offset, ok := dateParseOffset(str[:l])
+		if !ok {
+			return 0
+		}
+		p.zoneType, p.zoneOffset, p.zoneDst, p.zoneAbbr, p.loc = dateZoneTypeOffset, offset, false, "", nil
+		return n + l
+	}
+	l := 0
+	for l < len(str) && (str[l] >= 'a' && str[l] <= 'z' || str[l] >= 'A' && str[l] <= 'Z' ||
+		str[l] >= '0' && str[l] <= '9' && l > 0 || str[l] == '/' || str[l] == '_' || str[l] == '-' && l > 0) {
+		l++
+	}
+	if l == 0 {
+		return 0
+	}
+	word := str[:l]
+	if abbr, ok := dateZoneAbbrs[strings.ToLower(word)]; ok {
+		p.zoneType, p.zoneOffset, p.zoneDst, p.zoneAbbr, p.loc = dateZoneTypeAbbr, abbr.offset, abbr.dst, strings.ToUpper(word), nil
+		if len(word) == 3 && strings.EqualFold(word, "utc") {
+			p.zoneAbbr = "UTC"
+		}
+		if l < len(str) && str[l] == ')' {
+			l++
+		}
+		return n + l
+	}
+	if strings.Contains(word, "/") {
+		loc, err := time.LoadLocation(word)
+		if err != nil {
+			return 0
+		}
+		p.zoneType, p.zoneOffset, p.zoneDst, p.zoneAbbr, p.loc = dateZoneTypeID, 0, false, "", loc
+		return n + l
+	}
+	return 0
+}
+
+// dateParseOffset +hh, +hhmm, +hh:mm, +hh:mm:ss
+func dateParseOffset(str string) (int, bool) {
+	if len(str) < 2 {
+		return 0, false
+	}
+	sign := 1
+	if str[0] == '-' {
+		sign = -1
+	}
+	str = str[1:]
+	var h, m, s int
+	var err error
+	switch {
+	case strings.Contains(str, ":"):
+		parts := strings.Split(str, ":")
+		if len(parts) > 3 {
+			return 0, false
+		}
+		vals := make([]int, 3)
+		for k, part := range parts {
+			if vals[k], err = strconv.Atoi(part); err != nil || len(part) > 2 {
+				return 0, false
+			}
+		}
+		h, m, s = vals[0], vals[1], vals[2]
+	case len(str) <= 2:
+		h, err = strconv.Atoi(str)
+	case len(str) <= 4:
+		h, err = strconv.Atoi(str[:len(str)-2])
+		if err == nil {
+			m, err = strconv.Atoi(str[len(str)-2:])
+		}
+	case len(str) == 6:
+		h, _ = strconv.Atoi(str[:2])
+		m, _ = strconv.Atoi(str[2:4])
+		s, err = strconv.Atoi(str[4:])
+	default:
+		return 0, false
+	}
+	if err != nil || h > 99 || m > 59 || s > 59 {
+		return 0, false
+	}
+	return sign * (h*3600 + m*60 + s), true
+}
+
+// dateLookupName match a day or month name (full or 3 letters) at the start of str
+func dateLookupName(str string, names []string) (int, int) {
+	for k, name := range names {
+		if len(str) >= len(name) && strings.EqualFold(str[:len(name)], name) {
+			return k, len(name)
+		}
+	}
+	for k, name := range names {
+		if len(str) >= 3 && strings.EqualFold(str[:3], name[:3]) {
+			return k, 3
+		}
+	}
+	return -1, 0
+}
+
+// dateGetNr read at most maxLen digits
+func dateGetNr(str string, pos *int, maxLen int) int {
+	start := *pos
+	for *pos < len(str) && *pos-start < maxLen && str[*pos] >= '0' && str[*pos] <= '9' {
+		*pos++
+	}
+	if *pos == start {
+		return dateUnset
+	}
+	n, _ := strconv.Atoi(str[start:*pos])
+	return n
+}
+
+func dateIsDigit(str string, pos int) bool {
+	return pos < len(str) && str[pos] >= '0' && str[pos] <= '9'
+}
+
+// dateParseFromFormat parse str according to a php createFromFormat format
+func dateParseFromFormat(format, str string) *dateParsed {
+	p := newDateParsed()
+	pos := 0
+	allowExtra := false
+	meridian := func(begin int) {
+		if p.h == dateUnset {
+			p.addError(begin, "Meridian can only come after an hour has been found")
+			return
+		}
+		rest := strings.ToLower(str[pos:])
+		var pm bool
+		switch {
+		case strings.HasPrefix(rest, "a.m."), strings.HasPrefix(rest, "p.m."):
+			pm = rest[0] == 'p'
+			pos += 4
+		case strings.HasPrefix(rest, "am"), strings.HasPrefix(rest, "pm"):
+			pm = rest[0] == 'p'
+			pos += 2
+		default:
+			p.addError(begin, "A meridian could not be found")
+			return
+		}
+		if p.h == 12 && !pm {
+			p.h = 0
+		} else if p.h != 12 && pm {
+			p.h += 12
+		}
+	}
+	number := func(begin int, maxLen int, exact bool, msg string) int {
+		if !dateIsDigit(str, pos) {
+			p.addError(begin, "Unexpected data found.")
+			return dateUnset
+		}
+		n := dateGetNr(str, &pos, maxLen)
+		if n == dateUnset || exact && pos-begin != maxLen {
+			p.addError(begin, msg)
+			return dateUnset
+		}
+		return n
+	}
+	fi := 0
+	for ; fi < len(format) && pos < len(str); fi++ {
+		begin := pos
+		switch c := format[fi]; c {
+		case 'D', 'l':
+			k, l := dateLookupName(str[pos:], dateDayNames)
+			if k < 0 {
+				p.addError(begin, "A textual day could not be found")
+				break
+			}
+			pos += l
+			p.weekday = k
+		case 'd', 'j':
+			p.d = number(begin, 2, false, "A two digit day could not be found")
+		case 'S':
+			if pos+2 <= len(str) {
+				switch strings.ToLower(str[pos : pos+2]) {
+				case "st", "nd", "rd", "th":
+					pos += 2
+				}
+			}
+		case 'z':
+			p.doy = number(begin, 3, false, "A three digit day-of-year could not be found")
+			if p.doy != dateUnset && p.y == dateUnset {
+				p.addError(begin, "A 'day of year' can only come after a year has been found")
+			}
+		case 'm', 'n':
+			p.m = number(begin, 2, false, "A two digit month could not be found")
+		case 'M', 'F':
+			k, l := dateLookupName(str[pos:], dateMonthNames)
+			if k < 0 {
+				p.addError(begin, "A textual month could not be found")
+				break
+			}
+			pos += l
+			p.m = k + 1
+		case 'y':
+			if p.y = number(begin, 2, false, "A two digit year could not be found"); p.y != dateUnset {
+				p.y = dateProcessYear(p.y, pos-begin)
+			}
+		case 'Y':
+			p.y = number(begin, 4, false, "A four digit year could not be found")
+		case 'a', 'A':
+			meridian(begin)
+		case 'g', 'h', 'G', 'H':
+			if p.h = number(begin, 2, false, "A two digit hour could not be found"); p.h != dateUnset {
+				if (c == 'g' || c == 'h') && p.h > 12 {
+					p.addError(begin, "Hour cannot be higher than 12")
+				}
+				p.haveTime = true
+			}
+		case 'i':
+			p.i = number(begin, 2, true, "A two digit minute could not be found")
+			p.haveTime = true
+		case 's':
+			p.s = number(begin, 2, true, "A two digit second could not be found")
+			p.haveTime = true
+		case 'v':
+			if ms := number(begin, 3, true, "A three digit millisecond could not be found"); ms != dateUnset {
+				p.us = ms * 1000
+			}
+		case 'u':
+			if us := number(begin, 6, false, "A six digit microsecond could not be found"); us != dateUnset {
+				for l := pos - begin; l < 6; l++ {
+					us *= 10
+				}
+				p.us = us
+			}
+		case ' ':
+			for pos < len(str) && (str[pos] == ' ' || str[pos] == '\t') {
+				pos++
+			}
+		case 'U':
+			sign := int64(1)
+			if str[pos] == '-' || str[pos] == '+' {
+				if str[pos] == '-' {
+					sign = -1
+				}
+				pos++
+			}
+			start := pos
+			for pos < len(str) && str[pos] >= '0' && str[pos] <= '9' {
+				pos++
+			}
+			n, err := strconv.ParseInt(str[start:pos], 10, 64)
+			if err != nil {
+				p.addError(begin, "A unix timestamp could not be found")
+				break
+			}
+			t := time.Unix(sign*n, 0).UTC()
+			p.y, p.m, p.d = t.Year(), int(t.Month()), t.Day()
+			p.h, p.i, p.s = t.Hour(), t.Minute(), t.Second()
+			p.zoneType, p.zoneOffset, p.zoneAbbr, p.zoneDst, p.loc = dateZoneTypeOffset, 0, "", false, nil
+			p.haveTime = true
+		case 'e', 'P', 'p', 'T', 'O':
+			l := dateParseZone(str[pos:], p)
+			if l == 0 {
+				p.addError(begin, "The timezone could not be found in the database")
+				break
+			}
+			pos += l
+		case '#':
+			if strings.IndexByte(";:/.,-()", str[pos]) >= 0 {
+				pos++
+			} else {
+				p.addError(begin, "The separation symbol ([;:/.,-]) could not be found")
+			}
+		case ';', ':', '/', '.', ',', '-', '(', ')':
+			if str[pos] == c {
+				pos++
+			} else {
+				p.addError(begin, "The separation symbol could not be found")
+			}
+		case '!':
+			p.reset()
+		case '|':
+			p.resetUnset()
+		case '?':
+			pos++
+		case '\\':
+			if fi+1 < len(format) {
+				fi++
+			}
+			if str[pos] == format[fi] {
+				pos++
+			} else {
+				p.addError(begin, "The escaped character could not be found")
+			}
+		case '*':
+			for pos < len(str) && strings.IndexByte(" ,;:/.-()", str[pos]) < 0 && !dateIsDigit(str, pos) {
+				pos++
+			}
+		case '+':
+			allowExtra = true
+		default:
+			if str[pos] == c {
+				pos++
+			} else {
+				p.addError(begin, "The format separator does not match")
+			}
+		}
+	}
+	if pos < len(str) {
+		if allowExtra {
+			p.addWarning(pos, "Trailing data")
+		} else {
+			p.addError(pos, "Trailing data")
+		}
+	}
+	for ; fi < len(format); fi++ {
+		switch format[fi] {
+		case '!':
+			p.reset()
+		case '|':
+			p.resetUnset()
+		case '+':
+		default:
+			p.addError(pos, "Not enough data available to satisfy format")
+			fi = len(format)
+		}
+	}
+	if p.h != dateUnset || p.i != dateUnset || p.s != dateUnset || p.us != dateUnset {
+		if p.h == dateUnset {
+			p.h = 0
+		}
+		if p.i == dateUnset {
+			p.i = 0
+		}
+		if p.s == dateUnset {
+			p.s = 0
+		}
+		if p.us == dateUnset {
+			p.us = 0
+		}
+	}
+	if p.h != dateUnset && (p.h < 0 || p.h > 23 || p.i < 0 || p.i > 59 || p.s < 0 || p.s > 59) {
+		p.addWarning(pos, "The parsed time was invalid")
+	}
+	if p.y != dateUnset && p.m != dateUnset && p.d != dateUnset && !Checkdate(p.m, p.d, p.y) {
+		p.addWarning(pos, "The parsed date was invalid")
+	}
+	return p
+}
+
+// dateProcessYear two digit years, 00-69 => 2000-2069, 70-99 => 1970-1999
+func dateProcessYear(year int, length int) int {
+	if length >= 4 {
+		return year
+	}
+	if year < 70 {
+		return year + 2000
+	}
+	return year + 1900
+}
+
+// DateCreateFromFormat date_create_from_format()
+// Fields not present in format take the current time, "!" and "|" reset them to the Unix Epoch.
+// The time is in the timezone found in datetime, loc, or the default timezone.
+// DateCreateFromFormat("Y-m-d H:i:s", "2018-04-27 03:23:14")
+func DateCreateFromFormat(format, datetime string, loc ...*time.Location) (time.Time, error) {
+	p := dateParseFromFormat(format, datetime)
+	if err := p.firstError(len(datetime)); err != nil {
+		return time.Time{}, err
+	}
+	return p.toTime(time.Now().In(dateLocation(loc))), nil
+}
+
+// DateParseFromFormat date_parse_from_format()
+// Returns the same array as php: year, month, day, hour, minute, second, fraction,
+// warning_count, warnings, error_count, errors, is_localtime, and zone_type, zone, is_dst when a timezone was found.
+func DateParseFromFormat(format, datetime string) map[string]interface{} {
+	return dateParseFromFormat(format, datetime).result()
+}
+
+// dateAstroRiseSet timelib_astro_rise_set_altitude(), Paul Schlyter's sunriset.c
+// Rise, set and transit of the sun at altitude altit (degrees) on the day of t, as UT hours and Unix timestamps.
+// rc is -1 when the sun is always below altit, 1 when always above.
+func dateAstroRiseSet(t time.Time, lon, lat, altit float64, upperLimb bool) (hRise, hSet float64, tsRise, tsSet, tsTransit int64, rc int) {
+	// 12:00 local and 00:00 UTC of the day
+	noon := time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, t.Location()).Unix()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix()
+
+	// days since 2000 Jan 0.0 of 12h local mean solar time
+	d := float64(midnight)/86400 + 2440587.5 - 2451545 + 2 - lon/360
+	sidtime := dateAstroRevolution(dateAstroGMST0(d) + 180 + lon)
+	sRA, sdec, sr := dateAstroSunRADec(d)
+	// the time when the sun is at south in hours UT, and its apparent radius
+	tsouth := 12 - dateAstroRev180(sidtime-sRA)/15
+	sradius := 0.2666 / sr
+	if upperLimb {
+		altit -= sradius
+	}
+
+	// the diurnal arc that the sun traverses to reach altit
+	cost := (dateSind(altit) - dateSind(lat)*dateSind(sdec)) / (dateCosd(lat) * dateCosd(sdec))
+	tsTransit = int64(float64(midnight) + tsouth*3600)
+	var arc float64
+	switch {
+	case cost >= 1:
+		rc, arc = -1, 0
+		tsRise, tsSet = tsTransit, tsTransit
+	case cost <= -1:
+		rc, arc = 1, 12
+		tsRise, tsSet = noon-12*3600, noon+12*3600
+	default:
+		arc = math.Acos(cost) * 180 / math.Pi / 15
+		tsRise = int64((tsouth-arc)*3600 + float64(midnight))
+		tsSet = int64((tsouth+arc)*3600 + float64(midnight))
+	}
+	return tsouth - arc, tsouth + arc, tsRise, tsSet, tsTransit, rc
+}
+
+func dateSind(x float64) float64 {
+	return math.Sin(x * math.Pi / 180)
+}
+
+func dateCosd(x float64) float64 {
+	return math.Cos(x * math.Pi / 180)
+}
+
+// dateAstroRevolution reduce an angle to [0, 360)
+func dateAstroRevolution(x float64) float64 {
+	return x - 360*math.Floor(x/360)
+}
+
+// dateAstroRev180 reduce an angle to [-180, 180)
+func dateAstroRev180(x float64) float64 {
+	return x - 360*math.Floor(x/360+0.5)
+}
+
+// dateAstroGMST0 the Greenwich mean sidereal time at 0h UT, in degrees
+func dateAstroGMST0(d float64) float64 {
+	return dateAstroRevolution((180 + 356.0470 + 282.9404) + (0.9856002585+4.70935e-5)*d)
+}
+
+// dateAstroSunPos the sun's ecliptic longitude and distance (astronomical units)
+func dateAstroSunPos(d float64) (lon, r float64) {
+	m := dateAstroRevolution(356.0470 + 0.9856002585*d)
+	w := 282.9404 + 4.70935e-5*d
+	e := 0.016709 - 1.151e-9*d
+	// eccentric anomaly, then the true anomaly
+	ea := m + e*180/math.Pi*dateSind(m)*(1+e*dateCosd(m))
+	x := dateCosd(ea) - e
+	y := math.Sqrt(1-e*e) * dateSind(ea)
+	r = math.Sqrt(x*x + y*y)
+	lon = math.Atan2(y, x)*180/math.Pi + w
+	if lon >= 360 {
+		lon -= 360
+	}
+	return lon, r
+}
+
+// dateAstroSunRADec the sun's right ascension, declination and distance
+func dateAstroSunRADec(d float64) (ra, dec, r float64) {
+	lon, r := dateAstroSunPos(d)
+	x := r * dateCosd(lon)
+	y := r * dateSind(lon)
+	oblEcl := 23.4393 - 3.563e-7*d
+	z := y * dateSind(oblEcl)
+	y = y * dateCosd(oblEcl)
+	return math.Atan2(y, x) * 180 / math.Pi, math.Atan2(z, math.Sqrt(x*x+y*y)) * 180 / math.Pi, r
+}
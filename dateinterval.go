@@ -0,0 +1,317 @@
+package php2go
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateInterval DateInterval
+// Days is the total number of days when the interval was created by DateDiff, -1 otherwise (false in php).
+type DateInterval struct {
+	Y, M, D int
+	H, I, S int
+	F       float64
+	Invert  int
+	Days    int
+}
+
+var dateIntervalDesignators = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+var dateIntervalCombined = regexp.MustCompile(`^P(\d{4})-?(\d{2})-?(\d{2})T(\d{2}):?(\d{2}):?(\d{2})$`)
+
+// NewDateInterval new DateInterval()
+// Parse an ISO 8601 duration: NewDateInterval("P1Y2M10DT2H30M"), NewDateInterval("P2W"), NewDateInterval("P0001-02-10T02:30:00")
+func NewDateInterval(duration string) (*DateInterval, error) {
+	di := &DateInterval{Days: -1}
+	fields := []*int{&di.Y, &di.M, nil, &di.D, &di.H, &di.I, &di.S}
+	if m := dateIntervalDesignators.FindStringSubmatch(duration); m != nil && duration != "P" && !strings.HasSuffix(duration, "T") {
+		for k, v := range m[1:] {
+			if v == "" {
+				continue
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, errors.New("Unknown or bad format (" + duration + ")")
+			}
+			if fields[k] == nil {
+				di.D += n * 7
+			} else {
+				*fields[k] += n
+			}
+		}
+		return di, nil
+	}
+	if m := dateIntervalCombined.FindStringSubmatch(duration); m != nil {
+		fields = []*int{&di.Y, &di.M, &di.D, &di.H, &di.I, &di.S}
+		for k, v := range m[1:] {
+			*fields[k], _ = strconv.Atoi(v)
+		}
+		return di, nil
+	}
+	return nil, errors.New("Unknown or bad format (" + duration + ")")
+}
+
+// Format DateInterval::format()
+// %Y %y %M %m %D %d %a %H %h %I %i %S %s %F %f %R %r %%
+func (di *DateInterval) Format(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'Y':
+			b.WriteString(datePad(di.Y, 2))
+		case 'y':
+			b.WriteString(strconv.Itoa(di.Y))
+		case 'M':
+			b.WriteString(datePad(di.M, 2))
+		case 'm':
+			b.WriteString(strconv.Itoa(di.M))
+		case 'D':
+			b.WriteString(datePad(di.D, 2))
+		case 'd':
+			b.WriteString(strconv.Itoa(di.D))
+		case 'H':
+			b.WriteString(datePad(di.H, 2))
+		case 'h':
+			b.WriteString(strconv.Itoa(di.H))
+		case 'I':
+			b.WriteString(datePad(di.I, 2))
+		case 'i':
+			b.WriteString(strconv.Itoa(di.I))
+		case 'S':
+			b.WriteString(datePad(di.S, 2))
+		case 's':
+			b.WriteString(strconv.Itoa(di.S))
+		case 'F':
+			b.WriteString(datePad(di.microseconds(), 6))
+		case 'f':
+			b.WriteString(strconv.Itoa(di.microseconds()))
+		case 'a':
+			if di.Days < 0 {
+				b.WriteString("(unknown)")
+			} else {
+				b.WriteString(strconv.Itoa(di.Days))
+			}
+		case 'R':
+			if di.Invert == 1 {
+				b.WriteByte('-')
+			} else {
+				b.WriteByte('+')
+			}
+		case 'r':
+			if di.Invert == 1 {
+				b.WriteByte('-')
+			}
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
+
+func (di *DateInterval) microseconds() int {
+	return int(math.Round(di.F * 1e6))
+}
+
+// DateAdd date_add()
+func DateAdd(t time.Time, interval *DateInterval) time.Time {
+	return dateApplyInterval(t, interval, 1)
+}
+
+// DateSub date_sub()
+func DateSub(t time.Time, interval *DateInterval) time.Time {
+	return dateApplyInterval(t, interval, -1)
+}
+
+func dateApplyInterval(t time.Time, di *DateInterval, sign int) time.Time {
+	if di.Invert == 1 {
+		sign = -sign
+	}
+	return time.Date(t.Year()+sign*di.Y, t.Month()+time.Month(sign*di.M), t.Day()+sign*di.D,
+		t.Hour()+sign*di.H, t.Minute()+sign*di.I, t.Second()+sign*di.S,
+		t.Nanosecond()+sign*di.microseconds()*1000, t.Location())
+}
+
+// DateDiff date_diff()
+// The difference between two times, invert is 1 when target is before base.
+// Times in different timezones are compared in UTC.
+func DateDiff(base, target time.Time, absolute bool) *DateInterval {
+	di := &DateInterval{}
+	one, two := base, target
+	if one.Location().String() != two.Location().String() {
+		one, two = one.UTC(), two.UTC()
+	}
+	if one.After(two) {
+		one, two = two, one
+		di.Invert = 1
+	}
+	y := two.Year() - one.Year()
+	m := int(two.Month()) - int(one.Month())
+	d := two.Day() - one.Day()
+	h := two.Hour() - one.Hour()
+	i := two.Minute() - one.Minute()
+	s := two.Second() - one.Second()
+	us := (two.Nanosecond() - one.Nanosecond()) / 1000
+	dateRangeLimit(0, 1000000, 1000000, &us, &s)
+	dateRangeLimit(0, 60, 60, &s, &i)
+	dateRangeLimit(0, 60, 60, &i, &h)
+	dateRangeLimit(0, 24, 24, &h, &d)
+	dateRangeLimit(0, 12, 12, &m, &y)
+	// borrow days from the month before the later date, or from the earlier date onwards when inverted
+	if di.Invert == 0 {
+		year, month := two.Year(), int(two.Month())
+		for d < 0 {
+			month--
+			if month < 1 {
+				month += 12
+				year--
+			}
+			d += dateDaysInMonth(year, month)
+			m--
+		}
+	} else {
+		year, month := one.Year(), int(one.Month())
+		for d < 0 {
+			d += dateDaysInMonth(year, month)
+			m--
+			month++
+			if month > 12 {
+				month -= 12
+				year++
+			}
+		}
+	}
+	dateRangeLimit(0, 12, 12, &m, &y)
+	di.Y, di.M, di.D, di.H, di.I, di.S = y, m, d, h, i, s
+	di.F = float64(us) / 1e6
+	di.Days = dateDiffDays(one, two)
+	if absolute {
+		di.Invert = 0
+	}
+	return di
+}
+
+// dateRangeLimit carry a value outside of [start, end) into the next unit
+func dateRangeLimit(start, end, adj int, a, b *int) {
+	if *a < start {
+		*b -= (start-*a-1)/adj + 1
+		*a += adj * ((start-*a-1)/adj + 1)
+	}
+	if *a >= end {
+		*b += *a / adj
+		*a -= adj * (*a / adj)
+	}
+}
+
+// dateDiffDays full days between one and two, one <= two
+func dateDiffDays(one, two time.Time) int {
+	dayOne := time.Date(one.Year(), one.Month(), one.Day(), 0, 0, 0, 0, time.UTC)
+	dayTwo := time.Date(two.Year(), two.Month(), two.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(dayTwo.Sub(dayOne).Hours() / 24)
+	clockOne := time.Duration(one.Hour())*time.Hour + time.Duration(one.Minute())*time.Minute +
+		time.Duration(one.Second())*time.Second + time.Duration(one.Nanosecond())
+	clockTwo := time.Duration(two.Hour())*time.Hour + time.Duration(two.Minute())*time.Minute +
+		time.Duration(two.Second())*time.Second + time.Duration(two.Nanosecond())
+	if days > 0 && clockTwo < clockOne {
+		days--
+	}
+	return days
+}
+
+const (
+	// DatePeriodExcludeStartDate DatePeriod::EXCLUDE_START_DATE
+	DatePeriodExcludeStartDate = 1
+	// DatePeriodIncludeEndDate DatePeriod::INCLUDE_END_DATE
+	DatePeriodIncludeEndDate = 2
+)
+
+// DatePeriod DatePeriod
+// Iterates from Start by Interval, either Recurrences times or up to End.
+type DatePeriod struct {
+	Start       time.Time
+	Interval    *DateInterval
+	End         time.Time
+	Recurrences int
+	Options     int
+}
+
+// NewDatePeriod new DatePeriod(start, interval, end, options)
+func NewDatePeriod(start time.Time, interval *DateInterval, end time.Time, options int) *DatePeriod {
+	return &DatePeriod{Start: start, Interval: interval, End: end, Options: options}
+}
+
+// NewDatePeriodRecurrences new DatePeriod(start, interval, recurrences, options)
+func NewDatePeriodRecurrences(start time.Time, interval *DateInterval, recurrences int, options int) *DatePeriod {
+	return &DatePeriod{Start: start, Interval: interval, Recurrences: recurrences, Options: options}
+}
+
+// NewDatePeriodISO new DatePeriod(isostr, options)
+// NewDatePeriodISO("R4/2012-07-01T00:00:00Z/P7D", 0)
+func NewDatePeriodISO(isostr string, options int) (*DatePeriod, error) {
+	parts := strings.Split(isostr, "/")
+	if len(parts) != 3 || len(parts[0]) < 2 || parts[0][0] != 'R' {
+		return nil, errors.New("Unknown or bad format (" + isostr + ")")
+	}
+	recurrences, err := strconv.Atoi(parts[0][1:])
+	if err != nil || recurrences < 1 {
+		return nil, errors.New("Unknown or bad format (" + isostr + ")")
+	}
+	start, err := time.Parse(time.RFC3339, parts[1])
+	if err != nil {
+		return nil, errors.New("Unknown or bad format (" + isostr + ")")
+	}
+	interval, err := NewDateInterval(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	return NewDatePeriodRecurrences(start, interval, recurrences, options), nil
+}
+
+// Each call fn with every date of the period, stop when fn returns false
+func (p *DatePeriod) Each(fn func(t time.Time) bool) {
+	current := p.Start
+	recurrences := p.Recurrences
+	if p.Options&DatePeriodExcludeStartDate == 0 {
+		recurrences++
+	} else {
+		current = DateAdd(current, p.Interval)
+	}
+	for n := 0; ; n++ {
+		if p.End.IsZero() {
+			if n >= recurrences {
+				return
+			}
+		} else if current.After(p.End) || current.Equal(p.End) && p.Options&DatePeriodIncludeEndDate == 0 {
+			return
+		}
+		if !fn(current) {
+			return
+		}
+		next := DateAdd(current, p.Interval)
+		if !next.After(current) {
+			return
+		}
+		current = next
+	}
+}
+
+// Dates all dates of the period
+func (p *DatePeriod) Dates() []time.Time {
+	var dates []time.Time
+	p.Each(func(t time.Time) bool {
+		dates = append(dates, t)
+		return true
+	})
+	return dates
+}
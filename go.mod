@@ -1,10 +1,13 @@
 module github.com/mj520/php2go
 
-go 1.18
+go 1.19
 
 require (
 	github.com/fatedier/frp v0.51.2
 	github.com/hashicorp/consul/api v1.23.0
+	golang.org/x/crypto v0.11.0
+	golang.org/x/sys v0.10.0
+	golang.org/x/text v0.11.0
 )
 
 require (
@@ -55,14 +58,11 @@ require (
 	github.com/templexxx/cpufeat v0.0.0-20180724012125-cef66df7f161 // indirect
 	github.com/templexxx/xor v0.0.0-20191217153810-f85b25db303b // indirect
 	github.com/tjfoc/gmsm v1.4.1 // indirect
-	golang.org/x/crypto v0.11.0 // indirect
 	golang.org/x/exp v0.0.0-20230801115018-d63ba01acd4b // indirect
 	golang.org/x/mod v0.11.0 // indirect
 	golang.org/x/net v0.12.0 // indirect
 	golang.org/x/oauth2 v0.10.0 // indirect
 	golang.org/x/sync v0.3.0 // indirect
-	golang.org/x/sys v0.10.0 // indirect
-	golang.org/x/text v0.11.0 // indirect
 	golang.org/x/time v0.3.0 // indirect
 	golang.org/x/tools v0.9.3 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
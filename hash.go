@@ -0,0 +1,446 @@
+package php2go
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/fnv"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/md4"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/ripemd160"
+	"golang.org/x/crypto/sha3"
+)
+
+// HashHMAC HASH_HMAC, the flag of HashInit
+const HashHMAC = 1
+
+// HashContext the incremental hashing context of HashInit
+type HashContext struct {
+	algo      *hashAlgo
+	h         hash.Hash
+	key       []byte
+	finalized bool
+}
+
+type hashAlgo struct {
+	name   string
+	crypto bool
+	new    func(options map[string]interface{}) (hash.Hash, error)
+}
+
+func hashAlgoOf(fn func() hash.Hash) func(map[string]interface{}) (hash.Hash, error) {
+	return func(map[string]interface{}) (hash.Hash, error) {
+		return fn(), nil
+	}
+}
+
+// hashAlgoList the supported algorithms in the order of hash_algos()
+var hashAlgoList = []*hashAlgo{
+	{"md4", true, hashAlgoOf(md4.New)},
+	{"md5", true, hashAlgoOf(md5.New)},
+	{"sha1", true, hashAlgoOf(sha1.New)},
+	{"sha224", true, hashAlgoOf(sha256.New224)},
+	{"sha256", true, hashAlgoOf(sha256.New)},
+	{"sha384", true, hashAlgoOf(sha512.New384)},
+	{"sha512/224", true, hashAlgoOf(sha512.New512_224)},
+	{"sha512/256", true, hashAlgoOf(sha512.New512_256)},
+	{"sha512", true, hashAlgoOf(sha512.New)},
+	{"sha3-224", true, hashAlgoOf(sha3.New224)},
+	{"sha3-256", true, hashAlgoOf(sha3.New256)},
+	{"sha3-384", true, hashAlgoOf(sha3.New384)},
+	{"sha3-512", true, hashAlgoOf(sha3.New512)},
+	{"ripemd160", true, hashAlgoOf(ripemd160.New)},
+	{"whirlpool", true, hashAlgoOf(newWhirlpool)},
+	{"adler32", false, hashAlgoOf(func() hash.Hash { return adler32.New() })},
+	{"crc32", false, hashAlgoOf(newCRC32BZip2)},
+	{"crc32b", false, hashAlgoOf(func() hash.Hash { return crc32.NewIEEE() })},
+	{"crc32c", false, hashAlgoOf(func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) })},
+	{"fnv132", false, hashAlgoOf(func() hash.Hash { return fnv.New32() })},
+	{"fnv1a32", false, hashAlgoOf(func() hash.Hash { return fnv.New32a() })},
+	{"fnv164", false, hashAlgoOf(func() hash.Hash { return fnv.New64() })},
+	{"fnv1a64", false, hashAlgoOf(func() hash.Hash { return fnv.New64a() })},
+	{"joaat", false, hashAlgoOf(newJoaat)},
+	{"murmur3a", false, func(options map[string]interface{}) (hash.Hash, error) {
+		seed, _ := hashSeed(options)
+		return newMurmur3('a', uint64(uint32(seed))), nil
+	}},
+	{"murmur3c", false, func(options map[string]interface{}) (hash.Hash, error) {
+		seed, _ := hashSeed(options)
+		return newMurmur3('c', uint64(uint32(seed))), nil
+	}},
+	{"murmur3f", false, func(options map[string]interface{}) (hash.Hash, error) {
+		seed, _ := hashSeed(options)
+		return newMurmur3('f', seed), nil
+	}},
+	{"xxh32", false, func(options map[string]interface{}) (hash.Hash, error) {
+		seed, _ := hashSeed(options)
+		return newXXH32(uint32(seed)), nil
+	}},
+	{"xxh64", false, func(options map[string]interface{}) (hash.Hash, error) {
+		seed, _ := hashSeed(options)
+		return newXXH64(seed), nil
+	}},
+	{"xxh3", false, func(options map[string]interface{}) (hash.Hash, error) {
+		return hashXXH3("xxh3", 8, options)
+	}},
+	{"xxh128", false, func(options map[string]interface{}) (hash.Hash, error) {
+		return hashXXH3("xxh128", 16, options)
+	}},
+}
+
+// hashSeed the "seed" option, only integers are taken into account like PHP does
+func hashSeed(options map[string]interface{}) (uint64, bool) {
+	switch v := options["seed"].(type) {
+	case int:
+		return uint64(v), true
+	case int32:
+		return uint64(v), true
+	case int64:
+		return uint64(v), true
+	case uint32:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	}
+	return 0, false
+}
+
+// hashXXH3 the "seed" or the "secret" option of xxh3 and xxh128, a secret is truncated to 256 bytes
+func hashXXH3(name string, size int, options map[string]interface{}) (hash.Hash, error) {
+	_, hasSeed := options["seed"]
+	secret, hasSecret := options["secret"]
+	if hasSeed && hasSecret {
+		return nil, fmt.Errorf("%s: Only one of seed or secret is to be passed for initialization", name)
+	}
+	if hasSecret {
+		s := phpStringVal(secret)
+		if len(s) < xxh3SecretSizeMin {
+			return nil, fmt.Errorf("%s: Secret length must be >= %d bytes, %d bytes given", name, xxh3SecretSizeMin, len(s))
+		}
+		return newXXH3(size, 0, []byte(s)), nil
+	}
+	seed, _ := hashSeed(options)
+	return newXXH3(size, seed, nil), nil
+}
+
+func findHashAlgo(algo string) *hashAlgo {
+	algo = strings.ToLower(algo)
+	for _, a := range hashAlgoList {
+		if a.name == algo {
+			return a
+		}
+	}
+	return nil
+}
+
+// findHashAlgoCrypto the algorithm for the functions that only accept cryptographic ones
+func findHashAlgoCrypto(fn, algo string) (*hashAlgo, error) {
+	a := findHashAlgo(algo)
+	if a == nil || !a.crypto {
+		return nil, fmt.Errorf("%s(): Argument #1 ($algo) must be a valid cryptographic hashing algorithm", fn)
+	}
+	return a, nil
+}
+
+func (a *hashAlgo) ctor() func() hash.Hash {
+	return func() hash.Hash {
+		h, _ := a.new(nil)
+		return h
+	}
+}
+
+// Hash hash()
+// options are "seed" for murmur3a, murmur3c, murmur3f, xxh32, xxh64, xxh3 and xxh128, and "secret" for xxh3 and xxh128.
+// Hash("sha256", "The quick brown fox jumped over the lazy dog.", false), Hash("xxh3", "data", false, map[string]interface{}{"seed": 42})
+func Hash(algo, data string, binary bool, options ...map[string]interface{}) (string, error) {
+	a := findHashAlgo(algo)
+	if a == nil {
+		return "", errors.New("hash(): Argument #1 ($algo) must be a valid hashing algorithm")
+	}
+	ctx, err := newHashContext(a, "", false, options)
+	if err != nil {
+		return "", err
+	}
+	ctx.h.Write([]byte(data))
+	return ctx.final(binary), nil
+}
+
+// HashFile hash_file()
+func HashFile(algo, filename string, binary bool, options ...map[string]interface{}) (string, error) {
+	a := findHashAlgo(algo)
+	if a == nil {
+		return "", errors.New("hash_file(): Argument #1 ($algo) must be a valid hashing algorithm")
+	}
+	ctx, err := newHashContext(a, "", false, options)
+	if err != nil {
+		return "", err
+	}
+	if err = ctx.writeFile(filename); err != nil {
+		return "", err
+	}
+	return ctx.final(binary), nil
+}
+
+// HashHmac hash_hmac()
+// HashHmac("sha256", "The quick brown fox jumped over the lazy dog.", "secret", false)
+func HashHmac(algo, data, key string, binary bool) (string, error) {
+	a, err := findHashAlgoCrypto("hash_hmac", algo)
+	if err != nil {
+		return "", err
+	}
+	ctx, _ := newHashContext(a, key, true, nil)
+	ctx.h.Write([]byte(data))
+	return ctx.final(binary), nil
+}
+
+// HashHmacFile hash_hmac_file()
+func HashHmacFile(algo, filename, key string, binary bool) (string, error) {
+	a, err := findHashAlgoCrypto("hash_hmac_file", algo)
+	if err != nil {
+		return "", err
+	}
+	ctx, _ := newHashContext(a, key, true, nil)
+	if err = ctx.writeFile(filename); err != nil {
+		return "", err
+	}
+	return ctx.final(binary), nil
+}
+
+// HashAlgos hash_algos()
+func HashAlgos() []string {
+	algos := make([]string, 0, len(hashAlgoList))
+	for _, a := range hashAlgoList {
+		algos = append(algos, a.name)
+	}
+	return algos
+}
+
+// HashHmacAlgos hash_hmac_algos()
+func HashHmacAlgos() []string {
+	var algos []string
+	for _, a := range hashAlgoList {
+		if a.crypto {
+			algos = append(algos, a.name)
+		}
+	}
+	return algos
+}
+
+// HashEquals hash_equals()
+// Timing attack safe string comparison
+func HashEquals(knownString, userString string) bool {
+	return subtle.ConstantTimeCompare([]byte(knownString), []byte(userString)) == 1
+}
+
+// HashPbkdf2 hash_pbkdf2()
+// length is in hex digits unless binary is true, 0 is the whole digest
+// HashPbkdf2("sha256", "password", "salt", 1000, 20, false)
+func HashPbkdf2(algo, password, salt string, iterations, length int, binary bool) (string, error) {
+	a, err := findHashAlgoCrypto("hash_pbkdf2", algo)
+	if err != nil {
+		return "", err
+	}
+	if iterations <= 0 {
+		return "", errors.New("hash_pbkdf2(): Argument #4 ($iterations) must be greater than 0")
+	}
+	if length < 0 {
+		return "", errors.New("hash_pbkdf2(): Argument #5 ($length) must be greater than or equal to 0")
+	}
+	if length == 0 {
+		length = a.ctor()().Size()
+		if !binary {
+			length *= 2
+		}
+	}
+	keyLen := length
+	if !binary {
+		keyLen = (length + 1) / 2
+	}
+	key := pbkdf2.Key([]byte(password), []byte(salt), iterations, keyLen, a.ctor())
+	if binary {
+		return string(key), nil
+	}
+	return hex.EncodeToString(key)[:length], nil
+}
+
+// HashHkdf hash_hkdf()
+// Returns length raw bytes, 0 is the size of the digest
+// HashHkdf("sha256", key, 32, "aes-256-encryption", "")
+func HashHkdf(algo, key string, length int, info, salt string) (string, error) {
+	a, err := findHashAlgoCrypto("hash_hkdf", algo)
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", errors.New("hash_hkdf(): Argument #2 ($key) cannot be empty")
+	}
+	if length < 0 {
+		return "", errors.New("hash_hkdf(): Argument #3 ($length) must be greater than or equal to 0")
+	}
+	size := a.ctor()().Size()
+	if length == 0 {
+		length = size
+	} else if length > 255*size {
+		return "", fmt.Errorf("hash_hkdf(): Argument #3 ($length) must be less than or equal to %d", 255*size)
+	}
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(a.ctor(), []byte(key), []byte(salt), []byte(info)), out); err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// HashInit hash_init()
+// flags is 0 or HashHMAC, key is the HMAC key. options are those of Hash.
+//
+//	ctx, _ := HashInit("sha256", 0, "")
+//	HashUpdate(ctx, "The quick brown fox ")
+//	HashUpdate(ctx, "jumped over the lazy dog.")
+//	HashFinal(ctx, false)
+func HashInit(algo string, flags int, key string, options ...map[string]interface{}) (*HashContext, error) {
+	a := findHashAlgo(algo)
+	if a == nil {
+		return nil, errors.New("hash_init(): Argument #1 ($algo) must be a valid hashing algorithm")
+	}
+	hmac := flags&HashHMAC != 0
+	if hmac {
+		if !a.crypto {
+			return nil, errors.New("hash_init(): Argument #1 ($algo) must be a cryptographic hashing algorithm if HMAC is requested")
+		}
+		if key == "" {
+			return nil, errors.New("hash_init(): Argument #3 ($key) cannot be empty when HMAC is requested")
+		}
+	}
+	return newHashContext(a, key, hmac, options)
+}
+
+// HashUpdate hash_update()
+func HashUpdate(ctx *HashContext, data string) error {
+	if ctx.finalized {
+		return errors.New("hash_update(): Argument #1 ($context) must be a valid, non-finalized HashContext")
+	}
+	ctx.h.Write([]byte(data))
+	return nil
+}
+
+// HashUpdateFile hash_update_file()
+func HashUpdateFile(ctx *HashContext, filename string) error {
+	if ctx.finalized {
+		return errors.New("hash_update_file(): Argument #1 ($context) must be a valid, non-finalized HashContext")
+	}
+	return ctx.writeFile(filename)
+}
+
+// HashFinal hash_final()
+// The context can't be used anymore, HashCopy it before to go on
+func HashFinal(ctx *HashContext, binary bool) (string, error) {
+	if ctx.finalized {
+		return "", errors.New("hash_final(): Argument #1 ($context) must be a valid, non-finalized HashContext")
+	}
+	ctx.finalized = true
+	return ctx.final(binary), nil
+}
+
+// HashCopy hash_copy()
+func HashCopy(ctx *HashContext) (*HashContext, error) {
+	if ctx.finalized {
+		return nil, errors.New("hash_copy(): Argument #1 ($context) must be a valid, non-finalized HashContext")
+	}
+	c := *ctx
+	c.h = cloneHash(ctx.h, ctx.algo)
+	return &c, nil
+}
+
+// newHashContext php_hash_hmac_prep_key(), the key is hashed when longer than the block size
+func newHashContext(a *hashAlgo, key string, hmac bool, options []map[string]interface{}) (*HashContext, error) {
+	var opts map[string]interface{}
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	h, err := a.new(opts)
+	if err != nil {
+		return nil, err
+	}
+	ctx := &HashContext{algo: a, h: h}
+	if hmac {
+		ctx.key = make([]byte, h.BlockSize())
+		if len(key) > h.BlockSize() {
+			k := a.ctor()()
+			k.Write([]byte(key))
+			k.Sum(ctx.key[:0])
+		} else {
+			copy(ctx.key, key)
+		}
+		ipad := make([]byte, len(ctx.key))
+		for i, c := range ctx.key {
+			ipad[i] = c ^ 0x36
+		}
+		h.Write(ipad)
+	}
+	return ctx, nil
+}
+
+func (ctx *HashContext) writeFile(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(ctx.h, f)
+	return err
+}
+
+// final the digest, the outer hash of the HMAC
+func (ctx *HashContext) final(binary bool) string {
+	sum := ctx.h.Sum(nil)
+	if ctx.key != nil {
+		outer := ctx.algo.ctor()()
+		opad := make([]byte, len(ctx.key))
+		for i, c := range ctx.key {
+			opad[i] = c ^ 0x5c
+		}
+		outer.Write(opad)
+		outer.Write(sum)
+		sum = outer.Sum(nil)
+	}
+	if binary {
+		return string(sum)
+	}
+	return hex.EncodeToString(sum)
+}
+
+// cloneHash a copy of h in its current state
+func cloneHash(h hash.Hash, a *hashAlgo) hash.Hash {
+	switch c := h.(type) {
+	case interface{ Clone() sha3.ShakeHash }:
+		return c.Clone().(hash.Hash)
+	case encoding.BinaryMarshaler:
+		state, err := c.MarshalBinary()
+		if err == nil {
+			n := a.ctor()()
+			if err = n.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err == nil {
+				return n
+			}
+		}
+	}
+	// the others keep their state in arrays
+	v := reflect.ValueOf(h).Elem()
+	n := reflect.New(v.Type())
+	n.Elem().Set(v)
+	return n.Interface().(hash.Hash)
+}
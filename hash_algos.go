@@ -0,0 +1,903 @@
+package php2go
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// The hash.Hash implementations of the algorithms of ext/hash the standard library and x/crypto don't have.
+// Their state is kept in arrays so that a copy of the struct is a copy of the state.
+
+// crc32BZip2 PHP's "crc32", the bzip2 CRC (MSB first, polynomial 0x04C11DB7) output little-endian
+type crc32BZip2 uint32
+
+var crc32BZip2Table [256]uint32
+
+func init() {
+	for i := range crc32BZip2Table {
+		c := uint32(i) << 24
+		for j := 0; j < 8; j++ {
+			if c&0x80000000 != 0 {
+				c = c<<1 ^ 0x04C11DB7
+			} else {
+				c <<= 1
+			}
+		}
+		crc32BZip2Table[i] = c
+	}
+}
+
+func newCRC32BZip2() hash.Hash {
+	c := crc32BZip2(0xFFFFFFFF)
+	return &c
+}
+
+func (c *crc32BZip2) Write(p []byte) (int, error) {
+	crc := uint32(*c)
+	for _, b := range p {
+		crc = crc<<8 ^ crc32BZip2Table[byte(crc>>24)^b]
+	}
+	*c = crc32BZip2(crc)
+	return len(p), nil
+}
+
+func (c *crc32BZip2) Sum(b []byte) []byte {
+	crc := ^uint32(*c)
+	return append(b, byte(crc), byte(crc>>8), byte(crc>>16), byte(crc>>24))
+}
+
+func (c *crc32BZip2) Reset()         { *c = 0xFFFFFFFF }
+func (c *crc32BZip2) Size() int      { return 4 }
+func (c *crc32BZip2) BlockSize() int { return 4 }
+
+// joaat Bob Jenkins' one-at-a-time hash
+type joaat uint32
+
+func newJoaat() hash.Hash {
+	return new(joaat)
+}
+
+func (j *joaat) Write(p []byte) (int, error) {
+	h := uint32(*j)
+	for _, b := range p {
+		h += uint32(b)
+		h += h << 10
+		h ^= h >> 6
+	}
+	*j = joaat(h)
+	return len(p), nil
+}
+
+func (j *joaat) Sum(b []byte) []byte {
+	h := uint32(*j)
+	h += h << 3
+	h ^= h >> 11
+	h += h << 15
+	return appendUint32(b, h)
+}
+
+func (j *joaat) Reset()         { *j = 0 }
+func (j *joaat) Size() int      { return 4 }
+func (j *joaat) BlockSize() int { return 4 }
+
+// murmur3 MurmurHash3, murmur3a is x86_32, murmur3c is x86_128 and murmur3f is x64_128.
+// The words of the 128 bit variants are output big-endian one after the other.
+type murmur3 struct {
+	variant byte
+	seed    uint64
+	h32     [4]uint32
+	h64     [2]uint64
+	carry   [16]byte
+	n       int
+	length  uint64
+}
+
+func newMurmur3(variant byte, seed uint64) hash.Hash {
+	m := &murmur3{variant: variant, seed: seed}
+	m.Reset()
+	return m
+}
+
+func (m *murmur3) Reset() {
+	m.h32 = [4]uint32{uint32(m.seed), uint32(m.seed), uint32(m.seed), uint32(m.seed)}
+	m.h64 = [2]uint64{m.seed, m.seed}
+	m.n, m.length = 0, 0
+}
+
+func (m *murmur3) Size() int {
+	if m.variant == 'a' {
+		return 4
+	}
+	return 16
+}
+
+func (m *murmur3) BlockSize() int {
+	if m.variant == 'a' {
+		return 4
+	}
+	return 16
+}
+
+func (m *murmur3) Write(p []byte) (int, error) {
+	size := m.BlockSize()
+	m.length += uint64(len(p))
+	written := len(p)
+	if m.n > 0 {
+		c := copy(m.carry[m.n:size], p)
+		m.n += c
+		p = p[c:]
+		if m.n < size {
+			return written, nil
+		}
+		m.block(m.carry[:size])
+		m.n = 0
+	}
+	for ; len(p) >= size; p = p[size:] {
+		m.block(p[:size])
+	}
+	m.n = copy(m.carry[:], p)
+	return written, nil
+}
+
+const (
+	murmur3C1x86 = 0x239b961b
+	murmur3C2x86 = 0xab0e9789
+	murmur3C3x86 = 0x38b34ae5
+	murmur3C4x86 = 0xa1e38b93
+	murmur3C1x64 = 0x87c37b91114253d5
+	murmur3C2x64 = 0x4cf5ad432745937f
+)
+
+func (m *murmur3) block(p []byte) {
+	switch m.variant {
+	case 'a':
+		h := m.h32[0] ^ murmur3K32(binary.LittleEndian.Uint32(p), 0xcc9e2d51, 15, 0x1b873593)
+		m.h32[0] = bits.RotateLeft32(h, 13)*5 + 0xe6546b64
+	case 'c':
+		h := &m.h32
+		h[0] ^= murmur3K32(binary.LittleEndian.Uint32(p), murmur3C1x86, 15, murmur3C2x86)
+		h[0] = (bits.RotateLeft32(h[0], 19)+h[1])*5 + 0x561ccd1b
+		h[1] ^= murmur3K32(binary.LittleEndian.Uint32(p[4:]), murmur3C2x86, 16, murmur3C3x86)
+		h[1] = (bits.RotateLeft32(h[1], 17)+h[2])*5 + 0x0bcaa747
+		h[2] ^= murmur3K32(binary.LittleEndian.Uint32(p[8:]), murmur3C3x86, 17, murmur3C4x86)
+		h[2] = (bits.RotateLeft32(h[2], 15)+h[3])*5 + 0x96cd1c35
+		h[3] ^= murmur3K32(binary.LittleEndian.Uint32(p[12:]), murmur3C4x86, 18, murmur3C1x86)
+		h[3] = (bits.RotateLeft32(h[3], 13)+h[0])*5 + 0x32ac3b17
+	default:
+		h := &m.h64
+		h[0] ^= murmur3K64(binary.LittleEndian.Uint64(p), murmur3C1x64, 31, murmur3C2x64)
+		h[0] = (bits.RotateLeft64(h[0], 27)+h[1])*5 + 0x52dce729
+		h[1] ^= murmur3K64(binary.LittleEndian.Uint64(p[8:]), murmur3C2x64, 33, murmur3C1x64)
+		h[1] = (bits.RotateLeft64(h[1], 31)+h[0])*5 + 0x38495ab5
+	}
+}
+
+func (m *murmur3) Sum(b []byte) []byte {
+	var tail [16]byte
+	copy(tail[:], m.carry[:m.n])
+	switch m.variant {
+	case 'a':
+		h := m.h32[0]
+		if m.n > 0 {
+			h ^= murmur3K32(binary.LittleEndian.Uint32(tail[:]), 0xcc9e2d51, 15, 0x1b873593)
+		}
+		return appendUint32(b, murmur3Fmix32(h^uint32(m.length)))
+	case 'c':
+		h := m.h32
+		c := [...]uint32{murmur3C1x86, murmur3C2x86, murmur3C3x86, murmur3C4x86, murmur3C1x86}
+		r := [...]int{15, 16, 17, 18}
+		for i := 3; i >= 0; i-- {
+			if m.n > 4*i {
+				h[i] ^= murmur3K32(binary.LittleEndian.Uint32(tail[4*i:]), c[i], r[i], c[i+1])
+			}
+		}
+		for i := range h {
+			h[i] ^= uint32(m.length)
+		}
+		h[0] += h[1] + h[2] + h[3]
+		h[1], h[2], h[3] = h[1]+h[0], h[2]+h[0], h[3]+h[0]
+		for i := range h {
+			h[i] = murmur3Fmix32(h[i])
+		}
+		h[0] += h[1] + h[2] + h[3]
+		h[1], h[2], h[3] = h[1]+h[0], h[2]+h[0], h[3]+h[0]
+		for _, v := range h {
+			b = appendUint32(b, v)
+		}
+		return b
+	}
+	h := m.h64
+	if m.n > 8 {
+		h[1] ^= murmur3K64(binary.LittleEndian.Uint64(tail[8:]), murmur3C2x64, 33, murmur3C1x64)
+	}
+	if m.n > 0 {
+		h[0] ^= murmur3K64(binary.LittleEndian.Uint64(tail[:]), murmur3C1x64, 31, murmur3C2x64)
+	}
+	h[0] ^= m.length
+	h[1] ^= m.length
+	h[0] += h[1]
+	h[1] += h[0]
+	h[0], h[1] = murmur3Fmix64(h[0]), murmur3Fmix64(h[1])
+	h[0] += h[1]
+	h[1] += h[0]
+	b = appendUint64(b, h[0])
+	return appendUint64(b, h[1])
+}
+
+func murmur3K32(k, c1 uint32, r int, c2 uint32) uint32 {
+	return bits.RotateLeft32(k*c1, r) * c2
+}
+
+func murmur3K64(k, c1 uint64, r int, c2 uint64) uint64 {
+	return bits.RotateLeft64(k*c1, r) * c2
+}
+
+func murmur3Fmix32(h uint32) uint32 {
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	return h ^ h>>16
+}
+
+func murmur3Fmix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	return h ^ h>>33
+}
+
+const (
+	xxhPrime32_1 = 0x9E3779B1
+	xxhPrime32_2 = 0x85EBCA77
+	xxhPrime32_3 = 0xC2B2AE3D
+	xxhPrime32_4 = 0x27D4EB2F
+	xxhPrime32_5 = 0x165667B1
+	xxhPrime64_1 = 0x9E3779B185EBCA87
+	xxhPrime64_2 = 0xC2B2AE3D27D4EB4F
+	xxhPrime64_3 = 0x165667B19E3779F9
+	xxhPrime64_4 = 0x85EBCA77C2B2AE63
+	xxhPrime64_5 = 0x27D4EB2F165667C5
+)
+
+// xxh32 XXH32
+type xxh32 struct {
+	seed  uint32
+	v     [4]uint32
+	buf   [16]byte
+	n     int
+	total uint64
+}
+
+func newXXH32(seed uint32) hash.Hash {
+	x := &xxh32{seed: seed}
+	x.Reset()
+	return x
+}
+
+func (x *xxh32) Reset() {
+	x.v = [4]uint32{x.seed + xxhPrime32_1 + xxhPrime32_2, x.seed + xxhPrime32_2, x.seed, x.seed - xxhPrime32_1}
+	x.n, x.total = 0, 0
+}
+
+func (x *xxh32) Size() int      { return 4 }
+func (x *xxh32) BlockSize() int { return 16 }
+
+func (x *xxh32) Write(p []byte) (int, error) {
+	written := len(p)
+	x.total += uint64(len(p))
+	if x.n > 0 {
+		c := copy(x.buf[x.n:], p)
+		x.n += c
+		p = p[c:]
+		if x.n < 16 {
+			return written, nil
+		}
+		x.stripe(x.buf[:])
+		x.n = 0
+	}
+	for ; len(p) >= 16; p = p[16:] {
+		x.stripe(p)
+	}
+	x.n = copy(x.buf[:], p)
+	return written, nil
+}
+
+func (x *xxh32) stripe(p []byte) {
+	for i := range x.v {
+		x.v[i] = xxh32Round(x.v[i], binary.LittleEndian.Uint32(p[4*i:]))
+	}
+}
+
+func xxh32Round(acc, input uint32) uint32 {
+	return bits.RotateLeft32(acc+input*xxhPrime32_2, 13) * xxhPrime32_1
+}
+
+func (x *xxh32) Sum(b []byte) []byte {
+	var h uint32
+	if x.total >= 16 {
+		h = bits.RotateLeft32(x.v[0], 1) + bits.RotateLeft32(x.v[1], 7) + bits.RotateLeft32(x.v[2], 12) + bits.RotateLeft32(x.v[3], 18)
+	} else {
+		h = x.seed + xxhPrime32_5
+	}
+	h += uint32(x.total)
+	p := x.buf[:x.n]
+	for ; len(p) >= 4; p = p[4:] {
+		h = bits.RotateLeft32(h+binary.LittleEndian.Uint32(p)*xxhPrime32_3, 17) * xxhPrime32_4
+	}
+	for _, c := range p {
+		h = bits.RotateLeft32(h+uint32(c)*xxhPrime32_5, 11) * xxhPrime32_1
+	}
+	h ^= h >> 15
+	h *= xxhPrime32_2
+	h ^= h >> 13
+	h *= xxhPrime32_3
+	h ^= h >> 16
+	return appendUint32(b, h)
+}
+
+// xxh64 XXH64
+type xxh64 struct {
+	seed  uint64
+	v     [4]uint64
+	buf   [32]byte
+	n     int
+	total uint64
+}
+
+func newXXH64(seed uint64) hash.Hash {
+	x := &xxh64{seed: seed}
+	x.Reset()
+	return x
+}
+
+func (x *xxh64) Reset() {
+	x.v = [4]uint64{x.seed + xxhPrime64_1 + xxhPrime64_2, x.seed + xxhPrime64_2, x.seed, x.seed - xxhPrime64_1}
+	x.n, x.total = 0, 0
+}
+
+func (x *xxh64) Size() int      { return 8 }
+func (x *xxh64) BlockSize() int { return 32 }
+
+func (x *xxh64) Write(p []byte) (int, error) {
+	written := len(p)
+	x.total += uint64(len(p))
+	if x.n > 0 {
+		c := copy(x.buf[x.n:], p)
+		x.n += c
+		p = p[c:]
+		if x.n < 32 {
+			return written, nil
+		}
+		x.stripe(x.buf[:])
+		x.n = 0
+	}
+	for ; len(p) >= 32; p = p[32:] {
+		x.stripe(p)
+	}
+	x.n = copy(x.buf[:], p)
+	return written, nil
+}
+
+func (x *xxh64) stripe(p []byte) {
+	for i := range x.v {
+		x.v[i] = xxh64Round(x.v[i], binary.LittleEndian.Uint64(p[8*i:]))
+	}
+}
+
+func xxh64Round(acc, input uint64) uint64 {
+	return bits.RotateLeft64(acc+input*xxhPrime64_2, 31) * xxhPrime64_1
+}
+
+func xxh64MergeRound(acc, val uint64) uint64 {
+	return (acc^xxh64Round(0, val))*xxhPrime64_1 + xxhPrime64_4
+}
+
+func xxh64Avalanche(h uint64) uint64 {
+	h ^= h >> 33
+	h *= xxhPrime64_2
+	h ^= h >> 29
+	h *= xxhPrime64_3
+	return h ^ h>>32
+}
+
+func (x *xxh64) Sum(b []byte) []byte {
+	var h uint64
+	if x.total >= 32 {
+		h = bits.RotateLeft64(x.v[0], 1) + bits.RotateLeft64(x.v[1], 7) + bits.RotateLeft64(x.v[2], 12) + bits.RotateLeft64(x.v[3], 18)
+		for _, v := range x.v {
+			h = xxh64MergeRound(h, v)
+		}
+	} else {
+		h = x.seed + xxhPrime64_5
+	}
+	h += x.total
+	p := x.buf[:x.n]
+	for ; len(p) >= 8; p = p[8:] {
+		h = bits.RotateLeft64(h^xxh64Round(0, binary.LittleEndian.Uint64(p)), 27)*xxhPrime64_1 + xxhPrime64_4
+	}
+	if len(p) >= 4 {
+		h = bits.RotateLeft64(h^uint64(binary.LittleEndian.Uint32(p))*xxhPrime64_1, 23)*xxhPrime64_2 + xxhPrime64_3
+		p = p[4:]
+	}
+	for _, c := range p {
+		h = bits.RotateLeft64(h^uint64(c)*xxhPrime64_5, 11) * xxhPrime64_1
+	}
+	return appendUint64(b, xxh64Avalanche(h))
+}
+
+const (
+	xxh3StripeLen         = 64
+	xxh3SecretConsumeRate = 8
+	xxh3SecretSizeMin     = 136
+	xxh3SecretSizeMax     = 256
+	xxh3MidsizeMax        = 240
+	xxh3PrimeMX1          = 0x165667919E3779F9
+	xxh3PrimeMX2          = 0x9FB21C651E98DF25
+)
+
+// xxh3KSecret XXH3_kSecret
+var xxh3KSecret = [192]byte{
+	0xb8, 0xfe, 0x6c, 0x39, 0x23, 0xa4, 0x4b, 0xbe, 0x7c, 0x01, 0x81, 0x2c, 0xf7, 0x21, 0xad, 0x1c,
+	0xde, 0xd4, 0x6d, 0xe9, 0x83, 0x90, 0x97, 0xdb, 0x72, 0x40, 0xa4, 0xa4, 0xb7, 0xb3, 0x67, 0x1f,
+	0xcb, 0x79, 0xe6, 0x4e, 0xcc, 0xc0, 0xe5, 0x78, 0x82, 0x5a, 0xd0, 0x7d, 0xcc, 0xff, 0x72, 0x21,
+	0xb8, 0x08, 0x46, 0x74, 0xf7, 0x43, 0x24, 0x8e, 0xe0, 0x35, 0x90, 0xe6, 0x81, 0x3a, 0x26, 0x4c,
+	0x3c, 0x28, 0x52, 0xbb, 0x91, 0xc3, 0x00, 0xcb, 0x88, 0xd0, 0x65, 0x8b, 0x1b, 0x53, 0x2e, 0xa3,
+	0x71, 0x64, 0x48, 0x97, 0xa2, 0x0d, 0xf9, 0x4e, 0x38, 0x19, 0xef, 0x46, 0xa9, 0xde, 0xac, 0xd8,
+	0xa8, 0xfa, 0x76, 0x3f, 0xe3, 0x9c, 0x34, 0x3f, 0xf9, 0xdc, 0xbb, 0xc7, 0xc7, 0x0b, 0x4f, 0x1d,
+	0x8a, 0x51, 0xe0, 0x4b, 0xcd, 0xb4, 0x59, 0x31, 0xc8, 0x9f, 0x7e, 0xc9, 0xd9, 0x78, 0x73, 0x64,
+	0xea, 0xc5, 0xac, 0x83, 0x34, 0xd3, 0xeb, 0xc3, 0xc5, 0x81, 0xa0, 0xff, 0xfa, 0x13, 0x63, 0xeb,
+	0x17, 0x0d, 0xdd, 0x51, 0xb7, 0xf0, 0xda, 0x49, 0xd3, 0x16, 0x55, 0x26, 0x29, 0xd4, 0x68, 0x9e,
+	0x2b, 0x16, 0xbe, 0x58, 0x7d, 0x47, 0xa1, 0xfc, 0x8f, 0xf8, 0xb8, 0xd1, 0x7a, 0xd0, 0x31, 0xce,
+	0x45, 0xcb, 0x3a, 0x8f, 0x95, 0x16, 0x04, 0x28, 0xaf, 0xd7, 0xfb, 0xca, 0xbb, 0x4b, 0x40, 0x7e,
+}
+
+// xxh3 the XXH3 streaming state, 64 bits output for "xxh3" and 128 bits for "xxh128"
+type xxh3 struct {
+	size              int
+	acc               [8]uint64
+	secret            [xxh3SecretSizeMax]byte
+	secretSize        int
+	buffer            [256]byte
+	bufferedSize      int
+	nbStripesSoFar    int
+	totalLen          uint64
+	seed              uint64
+	useSeed           bool
+	secretLimit       int
+	nbStripesPerBlock int
+}
+
+// newXXH3 XXH3_64bits_reset_withSeed() or XXH3_64bits_reset_withSecret() when secret isn't nil
+func newXXH3(size int, seed uint64, secret []byte) hash.Hash {
+	x := &xxh3{size: size, seed: seed, useSeed: seed != 0}
+	switch {
+	case secret != nil:
+		x.secretSize = copy(x.secret[:], secret)
+	default:
+		x.secretSize = len(xxh3KSecret)
+		for i := 0; i < len(xxh3KSecret); i += 16 {
+			binary.LittleEndian.PutUint64(x.secret[i:], binary.LittleEndian.Uint64(xxh3KSecret[i:])+seed)
+			binary.LittleEndian.PutUint64(x.secret[i+8:], binary.LittleEndian.Uint64(xxh3KSecret[i+8:])-seed)
+		}
+	}
+	x.secretLimit = x.secretSize - xxh3StripeLen
+	x.nbStripesPerBlock = x.secretLimit / xxh3SecretConsumeRate
+	x.Reset()
+	return x
+}
+
+func (x *xxh3) Reset() {
+	x.acc = [8]uint64{xxhPrime32_3, xxhPrime64_1, xxhPrime64_2, xxhPrime64_3, xxhPrime64_4, xxhPrime32_2, xxhPrime64_5, xxhPrime32_1}
+	x.bufferedSize, x.nbStripesSoFar, x.totalLen = 0, 0, 0
+}
+
+func (x *xxh3) Size() int      { return x.size }
+func (x *xxh3) BlockSize() int { return xxh3StripeLen }
+
+// Write XXH3_update()
+func (x *xxh3) Write(p []byte) (int, error) {
+	written := len(p)
+	if len(p) == 0 {
+		return 0, nil
+	}
+	secret := x.secret[:x.secretSize]
+	x.totalLen += uint64(len(p))
+	if len(p) <= len(x.buffer)-x.bufferedSize {
+		x.bufferedSize += copy(x.buffer[x.bufferedSize:], p)
+		return written, nil
+	}
+	if x.bufferedSize > 0 {
+		loadSize := copy(x.buffer[x.bufferedSize:], p)
+		p = p[loadSize:]
+		xxh3ConsumeStripes(&x.acc, &x.nbStripesSoFar, x.nbStripesPerBlock, x.buffer[:], len(x.buffer)/xxh3StripeLen, secret, x.secretLimit)
+		x.bufferedSize = 0
+	}
+	if len(p) > len(x.buffer) {
+		nbStripes := (len(p) - 1) / xxh3StripeLen
+		consumed := xxh3ConsumeStripes(&x.acc, &x.nbStripesSoFar, x.nbStripesPerBlock, p, nbStripes, secret, x.secretLimit)
+		copy(x.buffer[len(x.buffer)-xxh3StripeLen:], p[consumed-xxh3StripeLen:consumed])
+		p = p[consumed:]
+	}
+	x.bufferedSize = copy(x.buffer[:], p)
+	return written, nil
+}
+
+// Sum XXH3_64bits_digest() and XXH3_128bits_digest(), output big-endian with the high half first
+func (x *xxh3) Sum(b []byte) []byte {
+	secret := x.secret[:x.secretSize]
+	if x.totalLen > xxh3MidsizeMax {
+		acc := x.digestLong(secret)
+		low := xxh3MergeAccs(&acc, secret[11:], x.totalLen*xxhPrime64_1)
+		if x.size == 8 {
+			return appendUint64(b, low)
+		}
+		high := xxh3MergeAccs(&acc, secret[x.secretSize-64-11:], ^(x.totalLen * xxhPrime64_2))
+		b = appendUint64(b, high)
+		return appendUint64(b, low)
+	}
+	input := x.buffer[:x.totalLen]
+	if x.useSeed {
+		secret = xxh3KSecret[:]
+	}
+	if x.size == 8 {
+		return appendUint64(b, xxh3Short64(input, secret, x.seed))
+	}
+	low, high := xxh3Short128(input, secret, x.seed)
+	b = appendUint64(b, high)
+	return appendUint64(b, low)
+}
+
+// digestLong XXH3_digest_long()
+func (x *xxh3) digestLong(secret []byte) [8]uint64 {
+	acc := x.acc
+	var lastStripe []byte
+	if x.bufferedSize >= xxh3StripeLen {
+		nbStripes := (x.bufferedSize - 1) / xxh3StripeLen
+		nbStripesSoFar := x.nbStripesSoFar
+		xxh3ConsumeStripes(&acc, &nbStripesSoFar, x.nbStripesPerBlock, x.buffer[:], nbStripes, secret, x.secretLimit)
+		lastStripe = x.buffer[x.bufferedSize-xxh3StripeLen : x.bufferedSize]
+	} else {
+		catchupSize := xxh3StripeLen - x.bufferedSize
+		lastStripe = make([]byte, 0, xxh3StripeLen)
+		lastStripe = append(lastStripe, x.buffer[len(x.buffer)-catchupSize:]...)
+		lastStripe = append(lastStripe, x.buffer[:x.bufferedSize]...)
+	}
+	xxh3Accumulate512(&acc, lastStripe, secret[x.secretLimit-7:])
+	return acc
+}
+
+// xxh3ConsumeStripes XXH3_consumeStripes(), returns the number of bytes consumed
+func xxh3ConsumeStripes(acc *[8]uint64, nbStripesSoFar *int, nbStripesPerBlock int, input []byte, nbStripes int, secret []byte, secretLimit int) int {
+	consumed := 0
+	initialSecret := secret[*nbStripesSoFar*xxh3SecretConsumeRate:]
+	if nbStripes >= nbStripesPerBlock-*nbStripesSoFar {
+		nbStripesThisIter := nbStripesPerBlock - *nbStripesSoFar
+		for {
+			xxh3Accumulate(acc, input[consumed:], initialSecret, nbStripesThisIter)
+			xxh3ScrambleAcc(acc, secret[secretLimit:])
+			consumed += nbStripesThisIter * xxh3StripeLen
+			nbStripes -= nbStripesThisIter
+			nbStripesThisIter = nbStripesPerBlock
+			initialSecret = secret
+			if nbStripes < nbStripesPerBlock {
+				break
+			}
+		}
+		*nbStripesSoFar = 0
+	}
+	if nbStripes > 0 {
+		xxh3Accumulate(acc, input[consumed:], initialSecret, nbStripes)
+		consumed += nbStripes * xxh3StripeLen
+		*nbStripesSoFar += nbStripes
+	}
+	return consumed
+}
+
+func xxh3Accumulate(acc *[8]uint64, input, secret []byte, nbStripes int) {
+	for n := 0; n < nbStripes; n++ {
+		xxh3Accumulate512(acc, input[n*xxh3StripeLen:], secret[n*xxh3SecretConsumeRate:])
+	}
+}
+
+func xxh3Accumulate512(acc *[8]uint64, input, secret []byte) {
+	for i := 0; i < 8; i++ {
+		dataVal := binary.LittleEndian.Uint64(input[8*i:])
+		dataKey := dataVal ^ binary.LittleEndian.Uint64(secret[8*i:])
+		acc[i^1] += dataVal
+		acc[i] += (dataKey & 0xFFFFFFFF) * (dataKey >> 32)
+	}
+}
+
+func xxh3ScrambleAcc(acc *[8]uint64, secret []byte) {
+	for i := 0; i < 8; i++ {
+		a := acc[i]
+		a ^= a >> 47
+		a ^= binary.LittleEndian.Uint64(secret[8*i:])
+		acc[i] = a * xxhPrime32_1
+	}
+}
+
+func xxh3MergeAccs(acc *[8]uint64, secret []byte, start uint64) uint64 {
+	for i := 0; i < 4; i++ {
+		start += xxh3Mul128Fold64(acc[2*i]^binary.LittleEndian.Uint64(secret[16*i:]), acc[2*i+1]^binary.LittleEndian.Uint64(secret[16*i+8:]))
+	}
+	return xxh3Avalanche(start)
+}
+
+func xxh3Mul128Fold64(lhs, rhs uint64) uint64 {
+	hi, lo := bits.Mul64(lhs, rhs)
+	return hi ^ lo
+}
+
+func xxh3Avalanche(h uint64) uint64 {
+	h ^= h >> 37
+	h *= xxh3PrimeMX1
+	return h ^ h>>32
+}
+
+func xxh3Rrmxmx(h, length uint64) uint64 {
+	h ^= bits.RotateLeft64(h, 49) ^ bits.RotateLeft64(h, 24)
+	h *= xxh3PrimeMX2
+	h ^= (h >> 35) + length
+	h *= xxh3PrimeMX2
+	return h ^ h>>28
+}
+
+func xxh3Mix16B(input, secret []byte, seed uint64) uint64 {
+	return xxh3Mul128Fold64(binary.LittleEndian.Uint64(input)^(binary.LittleEndian.Uint64(secret)+seed),
+		binary.LittleEndian.Uint64(input[8:])^(binary.LittleEndian.Uint64(secret[8:])-seed))
+}
+
+// xxh3Short64 XXH3_64bits_internal() for inputs of at most 240 bytes
+func xxh3Short64(input, secret []byte, seed uint64) uint64 {
+	n := len(input)
+	length := uint64(n)
+	switch {
+	case n == 0:
+		return xxh64Avalanche(seed ^ binary.LittleEndian.Uint64(secret[56:]) ^ binary.LittleEndian.Uint64(secret[64:]))
+	case n <= 3:
+		combined := uint32(input[0])<<16 | uint32(input[n>>1])<<24 | uint32(input[n-1]) | uint32(n)<<8
+		bitflip := uint64(binary.LittleEndian.Uint32(secret)^binary.LittleEndian.Uint32(secret[4:])) + seed
+		return xxh64Avalanche(uint64(combined) ^ bitflip)
+	case n <= 8:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		input1 := binary.LittleEndian.Uint32(input)
+		input2 := binary.LittleEndian.Uint32(input[n-4:])
+		bitflip := (binary.LittleEndian.Uint64(secret[8:]) ^ binary.LittleEndian.Uint64(secret[16:])) - seed
+		return xxh3Rrmxmx((uint64(input2)+uint64(input1)<<32)^bitflip, length)
+	case n <= 16:
+		bitflip1 := (binary.LittleEndian.Uint64(secret[24:]) ^ binary.LittleEndian.Uint64(secret[32:])) + seed
+		bitflip2 := (binary.LittleEndian.Uint64(secret[40:]) ^ binary.LittleEndian.Uint64(secret[48:])) - seed
+		inputLo := binary.LittleEndian.Uint64(input) ^ bitflip1
+		inputHi := binary.LittleEndian.Uint64(input[n-8:]) ^ bitflip2
+		return xxh3Avalanche(length + bits.ReverseBytes64(inputLo) + inputHi + xxh3Mul128Fold64(inputLo, inputHi))
+	case n <= 128:
+		acc := length * xxhPrime64_1
+		for i := (n - 1) / 32; i >= 0; i-- {
+			acc += xxh3Mix16B(input[16*i:], secret[32*i:], seed)
+			acc += xxh3Mix16B(input[n-16*(i+1):], secret[32*i+16:], seed)
+		}
+		return xxh3Avalanche(acc)
+	}
+	acc := length * xxhPrime64_1
+	for i := 0; i < 8; i++ {
+		acc += xxh3Mix16B(input[16*i:], secret[16*i:], seed)
+	}
+	accEnd := xxh3Mix16B(input[n-16:], secret[xxh3SecretSizeMin-17:], seed)
+	acc = xxh3Avalanche(acc)
+	for i := 8; i < n/16; i++ {
+		accEnd += xxh3Mix16B(input[16*i:], secret[16*(i-8)+3:], seed)
+	}
+	return xxh3Avalanche(acc + accEnd)
+}
+
+// xxh3Short128 XXH3_128bits_internal() for inputs of at most 240 bytes
+func xxh3Short128(input, secret []byte, seed uint64) (low, high uint64) {
+	n := len(input)
+	length := uint64(n)
+	switch {
+	case n == 0:
+		bitflipl := binary.LittleEndian.Uint64(secret[64:]) ^ binary.LittleEndian.Uint64(secret[72:])
+		bitfliph := binary.LittleEndian.Uint64(secret[80:]) ^ binary.LittleEndian.Uint64(secret[88:])
+		return xxh64Avalanche(seed ^ bitflipl), xxh64Avalanche(seed ^ bitfliph)
+	case n <= 3:
+		combinedl := uint32(input[0])<<16 | uint32(input[n>>1])<<24 | uint32(input[n-1]) | uint32(n)<<8
+		combinedh := bits.RotateLeft32(bits.ReverseBytes32(combinedl), 13)
+		bitflipl := uint64(binary.LittleEndian.Uint32(secret)^binary.LittleEndian.Uint32(secret[4:])) + seed
+		bitfliph := uint64(binary.LittleEndian.Uint32(secret[8:])^binary.LittleEndian.Uint32(secret[12:])) - seed
+		return xxh64Avalanche(uint64(combinedl) ^ bitflipl), xxh64Avalanche(uint64(combinedh) ^ bitfliph)
+	case n <= 8:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		inputLo := binary.LittleEndian.Uint32(input)
+		inputHi := binary.LittleEndian.Uint32(input[n-4:])
+		bitflip := (binary.LittleEndian.Uint64(secret[16:]) ^ binary.LittleEndian.Uint64(secret[24:])) + seed
+		keyed := (uint64(inputLo) + uint64(inputHi)<<32) ^ bitflip
+		high, low = bits.Mul64(keyed, xxhPrime64_1+length<<2)
+		high += low << 1
+		low ^= high >> 3
+		low ^= low >> 35
+		low *= xxh3PrimeMX2
+		low ^= low >> 28
+		return low, xxh3Avalanche(high)
+	case n <= 16:
+		bitflipl := (binary.LittleEndian.Uint64(secret[32:]) ^ binary.LittleEndian.Uint64(secret[40:])) - seed
+		bitfliph := (binary.LittleEndian.Uint64(secret[48:]) ^ binary.LittleEndian.Uint64(secret[56:])) + seed
+		inputLo := binary.LittleEndian.Uint64(input)
+		inputHi := binary.LittleEndian.Uint64(input[n-8:])
+		mHigh, mLow := bits.Mul64(inputLo^inputHi^bitflipl, xxhPrime64_1)
+		mLow += (length - 1) << 54
+		inputHi ^= bitfliph
+		mHigh += inputHi + uint64(uint32(inputHi))*(xxhPrime32_2-1)
+		mLow ^= bits.ReverseBytes64(mHigh)
+		high, low = bits.Mul64(mLow, xxhPrime64_2)
+		high += mHigh * xxhPrime64_2
+		return xxh3Avalanche(low), xxh3Avalanche(high)
+	}
+	accLow, accHigh := length*xxhPrime64_1, uint64(0)
+	mix32B := func(input1, input2, secret []byte, seed uint64) {
+		accLow += xxh3Mix16B(input1, secret, seed)
+		accLow ^= binary.LittleEndian.Uint64(input2) + binary.LittleEndian.Uint64(input2[8:])
+		accHigh += xxh3Mix16B(input2, secret[16:], seed)
+		accHigh ^= binary.LittleEndian.Uint64(input1) + binary.LittleEndian.Uint64(input1[8:])
+	}
+	if n <= 128 {
+		for i := (n - 1) / 32; i >= 0; i-- {
+			mix32B(input[16*i:], input[n-16*(i+1):], secret[32*i:], seed)
+		}
+	} else {
+		for i := 32; i < 160; i += 32 {
+			mix32B(input[i-32:], input[i-16:], secret[i-32:], seed)
+		}
+		accLow, accHigh = xxh3Avalanche(accLow), xxh3Avalanche(accHigh)
+		for i := 160; i <= n; i += 32 {
+			mix32B(input[i-32:], input[i-16:], secret[3+i-160:], seed)
+		}
+		mix32B(input[n-16:], input[n-32:], secret[xxh3SecretSizeMin-17-16:], -seed)
+	}
+	low = xxh3Avalanche(accLow + accHigh)
+	high = -xxh3Avalanche(accLow*xxhPrime64_1 + accHigh*xxhPrime64_4 + (length-seed)*xxhPrime64_2)
+	return low, high
+}
+
+// whirlpool the Whirlpool hash, its tables are computed from the mini-boxes of the specification
+type whirlpool struct {
+	h      [8]uint64
+	buf    [64]byte
+	n      int
+	length uint64
+}
+
+var (
+	whirlpoolC  [8][256]uint64
+	whirlpoolRC [11]uint64
+)
+
+func init() {
+	e := [16]byte{0x1, 0xB, 0x9, 0xC, 0xD, 0x6, 0xF, 0x3, 0xE, 0x8, 0x7, 0x4, 0xA, 0x2, 0x5, 0x0}
+	r := [16]byte{0x7, 0xC, 0xB, 0xD, 0xE, 0x4, 0x9, 0xF, 0x6, 0x3, 0x8, 0xA, 0x2, 0x5, 0x1, 0x0}
+	var eInv [16]byte
+	for i, v := range e {
+		eInv[v] = byte(i)
+	}
+	mul := func(a, b byte) byte {
+		var p byte
+		for ; b > 0; b >>= 1 {
+			if b&1 != 0 {
+				p ^= a
+			}
+			if a&0x80 != 0 {
+				a = a<<1 ^ 0x1D
+			} else {
+				a <<= 1
+			}
+		}
+		return p
+	}
+	var s [256]byte
+	for u := range s {
+		a, b := e[u>>4], eInv[u&0xF]
+		t := r[a^b]
+		s[u] = e[a^t]<<4 | eInv[b^t]
+	}
+	for x := range s {
+		var c uint64
+		for _, f := range [8]byte{1, 1, 4, 1, 8, 5, 2, 9} {
+			c = c<<8 | uint64(mul(s[x], f))
+		}
+		for k := range whirlpoolC {
+			whirlpoolC[k][x] = bits.RotateLeft64(c, -8*k)
+		}
+	}
+	for i := 1; i < len(whirlpoolRC); i++ {
+		whirlpoolRC[i] = binary.BigEndian.Uint64(s[8*(i-1):])
+	}
+}
+
+func newWhirlpool() hash.Hash {
+	return new(whirlpool)
+}
+
+func (w *whirlpool) Reset()         { *w = whirlpool{} }
+func (w *whirlpool) Size() int      { return 64 }
+func (w *whirlpool) BlockSize() int { return 64 }
+
+func (w *whirlpool) Write(p []byte) (int, error) {
+	written := len(p)
+	w.length += uint64(len(p))
+	if w.n > 0 {
+		c := copy(w.buf[w.n:], p)
+		w.n += c
+		p = p[c:]
+		if w.n < 64 {
+			return written, nil
+		}
+		w.block(w.buf[:])
+		w.n = 0
+	}
+	for ; len(p) >= 64; p = p[64:] {
+		w.block(p)
+	}
+	w.n = copy(w.buf[:], p)
+	return written, nil
+}
+
+func (w *whirlpool) block(p []byte) {
+	var k, state, block, l [8]uint64
+	for i := range block {
+		block[i] = binary.BigEndian.Uint64(p[8*i:])
+		k[i] = w.h[i]
+		state[i] = block[i] ^ k[i]
+	}
+	round := func(in *[8]uint64, i int) uint64 {
+		var v uint64
+		for t := 0; t < 8; t++ {
+			v ^= whirlpoolC[t][byte(in[(i-t)&7]>>(56-8*t))]
+		}
+		return v
+	}
+	for r := 1; r < len(whirlpoolRC); r++ {
+		for i := range l {
+			l[i] = round(&k, i)
+		}
+		l[0] ^= whirlpoolRC[r]
+		k = l
+		for i := range l {
+			l[i] = round(&state, i) ^ k[i]
+		}
+		state = l
+	}
+	for i := range w.h {
+		w.h[i] ^= state[i] ^ block[i]
+	}
+}
+
+func (w *whirlpool) Sum(b []byte) []byte {
+	d := *w
+	var pad [96]byte
+	pad[0] = 0x80
+	n := 64 - (d.n+1+32)%64
+	if n == 64 {
+		n = 0
+	}
+	bitLength := pad[1+n : 1+n+32]
+	binary.BigEndian.PutUint64(bitLength[16:], d.length>>61)
+	binary.BigEndian.PutUint64(bitLength[24:], d.length<<3)
+	d.Write(pad[:1+n+32])
+	for _, v := range d.h {
+		b = appendUint64(b, v)
+	}
+	return b
+}
+
+// appendUint32 appends v big-endian
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+// appendUint64 appends v big-endian
+func appendUint64(b []byte, v uint64) []byte {
+	return appendUint32(appendUint32(b, uint32(v>>32)), uint32(v))
+}
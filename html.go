@@ -0,0 +1,1064 @@
+package php2go
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// EntHTMLQuoteSingle ENT_HTML_QUOTE_SINGLE
+	EntHTMLQuoteSingle = 1
+	// EntHTMLQuoteDouble ENT_HTML_QUOTE_DOUBLE
+	EntHTMLQuoteDouble = 2
+	// EntCompat ENT_COMPAT, only double quotes
+	EntCompat = EntHTMLQuoteDouble
+	// EntQuotes ENT_QUOTES, double and single quotes
+	EntQuotes = EntHTMLQuoteSingle | EntHTMLQuoteDouble
+	// EntNoQuotes ENT_NOQUOTES
+	EntNoQuotes = 0
+	// EntIgnore ENT_IGNORE, drop invalid code unit sequences
+	EntIgnore = 4
+	// EntSubstitute ENT_SUBSTITUTE, replace invalid code unit sequences with U+FFFD
+	EntSubstitute = 8
+	// EntDisallowed ENT_DISALLOWED, replace the code points invalid in the document type with U+FFFD
+	EntDisallowed = 128
+	// EntHTML401 ENT_HTML401
+	EntHTML401 = 0
+	// EntXML1 ENT_XML1
+	EntXML1 = 16
+	// EntXHTML ENT_XHTML
+	EntXHTML = 32
+	// EntHTML5 ENT_HTML5
+	EntHTML5 = EntXML1 | EntXHTML
+
+	// HTMLSpecialchars HTML_SPECIALCHARS
+	HTMLSpecialchars = 0
+	// HTMLEntities HTML_ENTITIES
+	HTMLEntities = 1
+)
+
+// htmlDoctype the document type bits of the flags
+const htmlDoctype = EntHTML5
+
+// htmlEncodeTables the entities of the code points, built from htmlEntities401 and htmlEntities5
+var htmlEncodeTables struct {
+	sync.Once
+	html401 map[rune]string
+	html5   map[rune]string
+	// html5Pairs the HTML5 entities of two code points
+	html5Pairs map[string]string
+}
+
+func htmlLoadEncodeTables() {
+	htmlEncodeTables.Do(func() {
+		htmlEncodeTables.html401 = make(map[rune]string, len(htmlEntities401))
+		for name, r := range htmlEntities401 {
+			htmlEncodeTables.html401[r] = name
+		}
+		htmlEncodeTables.html5 = map[rune]string{}
+		htmlEncodeTables.html5Pairs = map[string]string{}
+		for name, s := range htmlEntities5 {
+			if utf8.RuneCountInString(s) > 1 {
+				if old, ok := htmlEncodeTables.html5Pairs[s]; !ok || htmlPreferName(name, old) {
+					htmlEncodeTables.html5Pairs[s] = name
+				}
+				continue
+			}
+			r, _ := utf8.DecodeRuneInString(s)
+			if old, ok := htmlEncodeTables.html5[r]; !ok || htmlPreferName(name, old) {
+				htmlEncodeTables.html5[r] = name
+			}
+		}
+	})
+}
+
+// htmlPreferName whether name is a better entity than old for the same characters:
+// the HTML 4.01 one, then the lower case one, then the shortest
+func htmlPreferName(name, old string) bool {
+	if _, ok := htmlEntities401[name]; ok {
+		return true
+	}
+	if _, ok := htmlEntities401[old]; ok {
+		return false
+	}
+	if lower, oldLower := name[0] >= 'a', old[0] >= 'a'; lower != oldLower {
+		return lower
+	}
+	if len(name) != len(old) {
+		return len(name) < len(old)
+	}
+	return name < old
+}
+
+// htmlEscape php_escape_html_entities_ex(), all for htmlentities()
+func htmlEscape(str string, flags int, all, doubleEncode bool) string {
+	doctype := flags & htmlDoctype
+	if doctype == EntXML1 {
+		// XML has no other named entities
+		all = false
+	}
+	if all {
+		htmlLoadEncodeTables()
+	}
+	var b strings.Builder
+	b.Grow(len(str))
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			i += htmlInvalidLen(str[i:])
+			if flags&EntIgnore != 0 {
+				continue
+			} else if flags&EntSubstitute != 0 {
+				b.WriteString("�")
+				continue
+			}
+			return ""
+		}
+		c := str[i : i+size]
+		i += size
+
+		if r == '&' {
+			if !doubleEncode {
+				if n := htmlEntityLen(str[i:], flags); n > 0 {
+					b.WriteString(str[i-1 : i+n])
+					i += n
+					continue
+				}
+			}
+			b.WriteString("&amp;")
+			continue
+		}
+		if r == '\'' && flags&EntHTMLQuoteSingle == 0 || r == '"' && flags&EntHTMLQuoteDouble == 0 {
+			b.WriteString(c)
+			continue
+		}
+
+		var entity string
+		switch {
+		case !all:
+			entity = htmlBasicEntity(r, doctype)
+		case doctype == EntHTML5:
+			if r2, size2 := utf8.DecodeRuneInString(str[i:]); size2 > 0 {
+				if entity = htmlEncodeTables.html5Pairs[string([]rune{r, r2})]; entity != "" {
+					i += size2
+					break
+				}
+			}
+			entity = htmlEncodeTables.html5[r]
+		default:
+			if entity = htmlEncodeTables.html401[r]; r == '\'' {
+				entity = "#039"
+			}
+		}
+		if entity != "" {
+			b.WriteString("&" + entity + ";")
+		} else if flags&EntDisallowed != 0 && !htmlAllowed(r, doctype) {
+			b.WriteString("�")
+		} else {
+			b.WriteString(c)
+		}
+	}
+	return b.String()
+}
+
+// htmlInvalidLen the length of the invalid UTF-8 sequence at the start of s: its lead byte and the continuation bytes that follow
+func htmlInvalidLen(s string) int {
+	n := 1
+	if s[0] >= 0xC2 && s[0] <= 0xF4 {
+		for n < len(s) && n < 4 && s[n]&0xC0 == 0x80 {
+			n++
+		}
+	}
+	return n
+}
+
+// htmlBasicEntity the entity of the characters of htmlspecialchars()
+func htmlBasicEntity(r rune, doctype int) string {
+	switch r {
+	case '&':
+		return "amp"
+	case '"':
+		return "quot"
+	case '<':
+		return "lt"
+	case '>':
+		return "gt"
+	case '\'':
+		if doctype == EntHTML401 {
+			return "#039"
+		}
+		return "apos"
+	}
+	return ""
+}
+
+// htmlResolve the characters of the named entity in the document type, all for the html_entity_decode() ones
+func htmlResolve(name string, doctype int, all bool) (string, bool) {
+	if !all || doctype == EntXML1 {
+		if name == "apos" && doctype == EntHTML401 {
+			return "", false
+		}
+		for _, r := range "&\"<>'" {
+			if htmlBasicEntity(r, EntXML1) == name {
+				return string(r), true
+			}
+		}
+		return "", false
+	}
+	if doctype == EntHTML5 {
+		s, ok := htmlEntities5[name]
+		return s, ok
+	}
+	if r, ok := htmlEntities401[name]; ok {
+		return string(r), true
+	}
+	if name == "apos" && doctype == EntXHTML {
+		return "'", true
+	}
+	return "", false
+}
+
+// htmlNumericEntity process_numeric_entity(), the code point of the entity at the start of s after "&#", and its length with ';'
+func htmlNumericEntity(s string) (rune, int) {
+	i, base := 0, 10
+	if s != "" && (s[0] == 'x' || s[0] == 'X') {
+		i, base = 1, 16
+	}
+	start := i
+	for i < len(s) && (isDigit(s[i]) || base == 16 && strings.IndexByte("abcdefABCDEF", s[i]) >= 0) {
+		i++
+	}
+	if i == start || i >= len(s) || s[i] != ';' {
+		return 0, 0
+	}
+	code, err := strconv.ParseInt(s[start:i], base, 32)
+	if err != nil || code > utf8.MaxRune {
+		return 0, 0
+	}
+	return rune(code), i + 1
+}
+
+// htmlNamedEntity process_named_entity_html(), the name of the entity at the start of s after "&", and its length with ';'
+func htmlNamedEntity(s string) (string, int) {
+	i := 0
+	for i < len(s) && (isDigit(s[i]) || 'a' <= s[i]|0x20 && s[i]|0x20 <= 'z') {
+		i++
+	}
+	if i == 0 || i >= len(s) || s[i] != ';' {
+		return "", 0
+	}
+	return s[:i], i + 1
+}
+
+// htmlEntityLen the length of the valid entity at the start of s after "&", 0 when there is none
+func htmlEntityLen(s string, flags int) int {
+	doctype := flags & htmlDoctype
+	if s != "" && s[0] == '#' {
+		code, n := htmlNumericEntity(s[1:])
+		if n == 0 || flags&EntDisallowed != 0 && !htmlNumericAllowed(code, doctype) {
+			return 0
+		}
+		return n + 1
+	}
+	name, n := htmlNamedEntity(s)
+	if n == 0 {
+		return 0
+	}
+	if _, ok := htmlResolve(name, doctype, true); !ok {
+		return 0
+	}
+	return n
+}
+
+// htmlUnescape traverse_for_entities(), all for html_entity_decode()
+func htmlUnescape(str string, flags int, all bool) string {
+	doctype := flags & htmlDoctype
+	var b strings.Builder
+	b.Grow(len(str))
+	for i := 0; i < len(str); {
+		if str[i] != '&' || i+3 >= len(str) {
+			b.WriteByte(str[i])
+			i++
+			continue
+		}
+		var s string
+		var n int
+		if str[i+1] == '#' {
+			code, size := htmlNumericEntity(str[i+2:])
+			if size > 0 && (all || strings.ContainsRune("&\"<>'", code)) &&
+				htmlAllowed(code, doctype) && !(doctype == EntHTML5 && code == '\r') {
+				s, n = string(code), size+1
+			}
+		} else if name, size := htmlNamedEntity(str[i+1:]); size > 0 {
+			if s, _ = htmlResolve(name, doctype, all); s != "" {
+				n = size
+			}
+		}
+		if n == 0 || s == "'" && flags&EntHTMLQuoteSingle == 0 || s == `"` && flags&EntHTMLQuoteDouble == 0 {
+			b.WriteByte('&')
+			i++
+			continue
+		}
+		b.WriteString(s)
+		i += n + 1
+	}
+	return b.String()
+}
+
+// htmlAllowed unicode_cp_is_allowed(), whether the code point can appear in the document type
+func htmlAllowed(r rune, doctype int) bool {
+	switch doctype {
+	case EntHTML401:
+		return r >= 0x20 && r <= 0x7E || r == '\n' || r == '\t' || r == '\r' || r >= 0xA0 && r <= 0xD7FF ||
+			r >= 0xE000 && r <= utf8.MaxRune && r&0xFFFF < 0xFFFE && (r < 0xFDD0 || r > 0xFDEF)
+	case EntHTML5:
+		return r >= 0x20 && r <= 0x7E || r >= 0x09 && r <= 0x0D && r != 0x0B || r >= 0xA0 && r <= 0xD7FF ||
+			r >= 0xE000 && r <= utf8.MaxRune && r&0xFFFF < 0xFFFE && (r < 0xFDD0 || r > 0xFDEF)
+	}
+	return r >= 0x20 && r <= 0xD7FF || r == '\n' || r == '\t' || r == '\r' ||
+		r >= 0xE000 && r <= utf8.MaxRune && r != 0xFFFE && r != 0xFFFF
+}
+
+// htmlNumericAllowed numeric_entity_is_allowed(), less restrictive than htmlAllowed
+func htmlNumericAllowed(r rune, doctype int) bool {
+	switch doctype {
+	case EntHTML401:
+		return r <= utf8.MaxRune
+	case EntHTML5:
+		return r >= 0x20 && r <= 0x7E || r >= 0x09 && r <= 0x0C && r != 0x0B ||
+			r >= 0xA0 && r <= utf8.MaxRune && r&0xFFFF < 0xFFFE && (r < 0xFDD0 || r > 0xFDEF)
+	}
+	return htmlAllowed(r, doctype)
+}
+
+// htmlTranslationTable get_html_translation_table()
+func htmlTranslationTable(table, flags int) map[string]string {
+	doctype := flags & htmlDoctype
+	result := map[string]string{}
+	if table == HTMLEntities && doctype != EntXML1 {
+		htmlLoadEncodeTables()
+		if doctype == EntHTML5 {
+			for r, name := range htmlEncodeTables.html5 {
+				result[string(r)] = "&" + name + ";"
+			}
+			for s, name := range htmlEncodeTables.html5Pairs {
+				result[s] = "&" + name + ";"
+			}
+		} else {
+			for r, name := range htmlEncodeTables.html401 {
+				result[string(r)] = "&" + name + ";"
+			}
+			result["'"] = "&#039;"
+		}
+	} else {
+		for _, r := range "&\"<>'" {
+			result[string(r)] = "&" + htmlBasicEntity(r, doctype) + ";"
+		}
+	}
+	if flags&EntHTMLQuoteSingle == 0 {
+		delete(result, "'")
+	}
+	if flags&EntHTMLQuoteDouble == 0 {
+		delete(result, `"`)
+	}
+	return result
+}
+
+// stripTags php_strip_tags_ex(), allow the lower case allowed tags like "<a><b>"
+func stripTags(str, allow string) string {
+	var result, tag []byte
+	var inQuote, lc byte
+	state, depth, br, isXML := 0, 0, 0, false
+	at := func(i int) byte {
+		if i >= 0 && i < len(str) {
+			return str[i]
+		}
+		return 0
+	}
+	isSpace := func(c byte) bool {
+		return strings.IndexByte(" \t\n\r\v\f", c) >= 0 && c != 0
+	}
+	for p := 0; p < len(str); p++ {
+		c := str[p]
+		switch state {
+		case 0:
+			switch c {
+			case 0:
+			case '<':
+				if inQuote != 0 {
+					break
+				}
+				if isSpace(at(p + 1)) {
+					result = append(result, c)
+					break
+				}
+				lc, state = '<', 1
+				if allow != "" {
+					tag = append(tag, '<')
+				}
+			case '>':
+				if depth > 0 {
+					depth--
+					break
+				}
+				if inQuote != 0 {
+					break
+				}
+				result = append(result, c)
+			default:
+				result = append(result, c)
+			}
+		case 1:
+			regular := false
+			switch c {
+			case 0:
+			case '<':
+				if inQuote != 0 {
+					break
+				}
+				if isSpace(at(p + 1)) {
+					regular = true
+					break
+				}
+				depth++
+			case '>':
+				if depth > 0 {
+					depth--
+					break
+				}
+				if inQuote != 0 {
+					break
+				}
+				lc = '>'
+				if isXML && at(p-1) == '-' {
+					break
+				}
+				inQuote, state, isXML = 0, 0, false
+				if allow != "" {
+					tag = append(tag, '>')
+					if stripTagsFind(tag, allow) {
+						result = append(result, tag...)
+					}
+					tag = tag[:0]
+				}
+			case '"', '\'':
+				if p != 0 && (inQuote == 0 || c == inQuote) {
+					if inQuote != 0 {
+						inQuote = 0
+					} else {
+						inQuote = c
+					}
+				}
+				regular = true
+			case '!':
+				// JavaScript & Other HTML scripting languages
+				if at(p-1) == '<' {
+					state, lc = 3, c
+				} else {
+					regular = true
+				}
+			case '?':
+				if at(p-1) == '<' {
+					br, state = 0, 2
+				} else {
+					regular = true
+				}
+			default:
+				regular = true
+			}
+			if regular && allow != "" {
+				tag = append(tag, c)
+			}
+		case 2:
+			// PHP code
+			switch c {
+			case '(':
+				if lc != '"' && lc != '\'' {
+					lc = '('
+					br++
+				}
+			case ')':
+				if lc != '"' && lc != '\'' {
+					lc = ')'
+					br--
+				}
+			case '>':
+				if depth > 0 {
+					depth--
+					break
+				}
+				if inQuote != 0 {
+					break
+				}
+				if br == 0 && lc != '"' && at(p-1) == '?' {
+					inQuote, state = 0, 0
+					tag = tag[:0]
+				}
+			case '"', '\'':
+				if at(p-1) != '\\' {
+					if lc == c {
+						lc = 0
+					} else if lc != '\\' {
+						lc = c
+					}
+					if p != 0 && (inQuote == 0 || c == inQuote) {
+						if inQuote != 0 {
+							inQuote = 0
+						} else {
+							inQuote = c
+						}
+					}
+				}
+			case 'l', 'L':
+				// <?xml is not PHP code
+				if p > 4 && at(p-1)|0x20 == 'm' && at(p-2)|0x20 == 'x' && at(p-3) == '?' && at(p-4) == '<' {
+					state, isXML = 1, true
+				}
+			}
+		case 3:
+			// <!
+			switch c {
+			case '>':
+				if depth > 0 {
+					depth--
+					break
+				}
+				if inQuote != 0 {
+					break
+				}
+				inQuote, state = 0, 0
+				tag = tag[:0]
+			case '"', '\'':
+				if p != 0 && at(p-1) != '\\' && (inQuote == 0 || c == inQuote) {
+					if inQuote != 0 {
+						inQuote = 0
+					} else {
+						inQuote = c
+					}
+				}
+			case '-':
+				if at(p-1) == '-' && at(p-2) == '!' {
+					state = 4
+				}
+			case 'e', 'E':
+				// <!DOCTYPE
+				if p > 6 && strings.EqualFold(str[p-6:p], "doctyp") {
+					state = 1
+				}
+			}
+		case 4:
+			// comment
+			if c == '>' && inQuote == 0 && at(p-1) == '-' && at(p-2) == '-' {
+				inQuote, state = 0, 0
+				tag = tag[:0]
+			}
+		}
+	}
+	return string(result)
+}
+
+// stripTagsFind php_tag_find(), whether the tag normalized to "<name>" is in allow
+func stripTagsFind(tag []byte, allow string) bool {
+	norm := []byte{}
+	started := false
+loop:
+	for t := 0; t < len(tag); t++ {
+		c := tag[t]
+		if 'A' <= c && c <= 'Z' {
+			c |= 0x20
+		}
+		switch c {
+		case '<':
+			norm = append(norm, c)
+		case '>':
+			break loop
+		default:
+			if strings.IndexByte(" \t\n\r\v\f", c) < 0 {
+				started = true
+				if c != '/' || t > 0 && tag[t-1] != '<' && (t+1 >= len(tag) || tag[t+1] != '>') {
+					norm = append(norm, c)
+				}
+			} else if started {
+				break loop
+			}
+		}
+	}
+	return strings.Contains(allow, string(append(norm, '>')))
+}
+
+// htmlEntities401 the named entities of HTML 4.01
+var htmlEntities401 = map[string]rune{
+	"quot": 0x22, "amp": 0x26, "lt": 0x3C, "gt": 0x3E, "nbsp": 0xA0, "iexcl": 0xA1,
+	"cent": 0xA2, "pound": 0xA3, "curren": 0xA4, "yen": 0xA5, "brvbar": 0xA6, "sect": 0xA7,
+	"uml": 0xA8, "copy": 0xA9, "ordf": 0xAA, "laquo": 0xAB, "not": 0xAC, "shy": 0xAD,
+	"reg": 0xAE, "macr": 0xAF, "deg": 0xB0, "plusmn": 0xB1, "sup2": 0xB2, "sup3": 0xB3,
+	"acute": 0xB4, "micro": 0xB5, "para": 0xB6, "middot": 0xB7, "cedil": 0xB8, "sup1": 0xB9,
+	"ordm": 0xBA, "raquo": 0xBB, "frac14": 0xBC, "frac12": 0xBD, "frac34": 0xBE, "iquest": 0xBF,
+	"Agrave": 0xC0, "Aacute": 0xC1, "Acirc": 0xC2, "Atilde": 0xC3, "Auml": 0xC4, "Aring": 0xC5,
+	"AElig": 0xC6, "Ccedil": 0xC7, "Egrave": 0xC8, "Eacute": 0xC9, "Ecirc": 0xCA, "Euml": 0xCB,
+	"Igrave": 0xCC, "Iacute": 0xCD, "Icirc": 0xCE, "Iuml": 0xCF, "ETH": 0xD0, "Ntilde": 0xD1,
+	"Ograve": 0xD2, "Oacute": 0xD3, "Ocirc": 0xD4, "Otilde": 0xD5, "Ouml": 0xD6, "times": 0xD7,
+	"Oslash": 0xD8, "Ugrave": 0xD9, "Uacute": 0xDA, "Ucirc": 0xDB, "Uuml": 0xDC, "Yacute": 0xDD,
+	"THORN": 0xDE, "szlig": 0xDF, "agrave": 0xE0, "aacute": 0xE1, "acirc": 0xE2, "atilde": 0xE3,
+	"auml": 0xE4, "aring": 0xE5, "aelig": 0xE6, "ccedil": 0xE7, "egrave": 0xE8, "eacute": 0xE9,
+	"ecirc": 0xEA, "euml": 0xEB, "igrave": 0xEC, "iacute": 0xED, "icirc": 0xEE, "iuml": 0xEF,
+	"eth": 0xF0, "ntilde": 0xF1, "ograve": 0xF2, "oacute": 0xF3, "ocirc": 0xF4, "otilde": 0xF5,
+	"ouml": 0xF6, "divide": 0xF7, "oslash": 0xF8, "ugrave": 0xF9, "uacute": 0xFA, "ucirc": 0xFB,
+	"uuml": 0xFC, "yacute": 0xFD, "thorn": 0xFE, "yuml": 0xFF, "OElig": 0x152, "oelig": 0x153,
+	"Scaron": 0x160, "scaron": 0x161, "Yuml": 0x178, "fnof": 0x192, "circ": 0x2C6, "tilde": 0x2DC,
+	"Alpha": 0x391, "Beta": 0x392, "Gamma": 0x393, "Delta": 0x394, "Epsilon": 0x395, "Zeta": 0x396,
+	"Eta": 0x397, "Theta": 0x398, "Iota": 0x399, "Kappa": 0x39A, "Lambda": 0x39B, "Mu": 0x39C,
+	"Nu": 0x39D, "Xi": 0x39E, "Omicron": 0x39F, "Pi": 0x3A0, "Rho": 0x3A1, "Sigma": 0x3A3,
+	"Tau": 0x3A4, "Upsilon": 0x3A5, "Phi": 0x3A6, "Chi": 0x3A7, "Psi": 0x3A8, "Omega": 0x3A9,
+	"alpha": 0x3B1, "beta": 0x3B2, "gamma": 0x3B3, "delta": 0x3B4, "epsilon": 0x3B5, "zeta": 0x3B6,
+	"eta": 0x3B7, "theta": 0x3B8, "iota": 0x3B9, "kappa": 0x3BA, "lambda": 0x3BB, "mu": 0x3BC,
+	"nu": 0x3BD, "xi": 0x3BE, "omicron": 0x3BF, "pi": 0x3C0, "rho": 0x3C1, "sigmaf": 0x3C2,
+	"sigma": 0x3C3, "tau": 0x3C4, "upsilon": 0x3C5, "phi": 0x3C6, "chi": 0x3C7, "psi": 0x3C8,
+	"omega": 0x3C9, "thetasym": 0x3D1, "upsih": 0x3D2, "piv": 0x3D6, "ensp": 0x2002, "emsp": 0x2003,
+	"thinsp": 0x2009, "zwnj": 0x200C, "zwj": 0x200D, "lrm": 0x200E, "rlm": 0x200F, "ndash": 0x2013,
+	"mdash": 0x2014, "lsquo": 0x2018, "rsquo": 0x2019, "sbquo": 0x201A, "ldquo": 0x201C, "rdquo": 0x201D,
+	"bdquo": 0x201E, "dagger": 0x2020, "Dagger": 0x2021, "bull": 0x2022, "hellip": 0x2026, "permil": 0x2030,
+	"prime": 0x2032, "Prime": 0x2033, "lsaquo": 0x2039, "rsaquo": 0x203A, "oline": 0x203E, "frasl": 0x2044,
+	"euro": 0x20AC, "image": 0x2111, "weierp": 0x2118, "real": 0x211C, "trade": 0x2122, "alefsym": 0x2135,
+	"larr": 0x2190, "uarr": 0x2191, "rarr": 0x2192, "darr": 0x2193, "harr": 0x2194, "crarr": 0x21B5,
+	"lArr": 0x21D0, "uArr": 0x21D1, "rArr": 0x21D2, "dArr": 0x21D3, "hArr": 0x21D4, "forall": 0x2200,
+	"part": 0x2202, "exist": 0x2203, "empty": 0x2205, "nabla": 0x2207, "isin": 0x2208, "notin": 0x2209,
+	"ni": 0x220B, "prod": 0x220F, "sum": 0x2211, "minus": 0x2212, "lowast": 0x2217, "radic": 0x221A,
+	"prop": 0x221D, "infin": 0x221E, "ang": 0x2220, "and": 0x2227, "or": 0x2228, "cap": 0x2229,
+	"cup": 0x222A, "int": 0x222B, "there4": 0x2234, "sim": 0x223C, "cong": 0x2245, "asymp": 0x2248,
+	"ne": 0x2260, "equiv": 0x2261, "le": 0x2264, "ge": 0x2265, "sub": 0x2282, "sup": 0x2283,
+	"nsub": 0x2284, "sube": 0x2286, "supe": 0x2287, "oplus": 0x2295, "otimes": 0x2297, "perp": 0x22A5,
+	"sdot": 0x22C5, "lceil": 0x2308, "rceil": 0x2309, "lfloor": 0x230A, "rfloor": 0x230B, "lang": 0x2329,
+	"rang": 0x232A, "loz": 0x25CA, "spades": 0x2660, "clubs": 0x2663, "hearts": 0x2665, "diams": 0x2666,
+}
+
+// htmlEntities5 the named entities of HTML5
+var htmlEntities5 = map[string]string{
+	"Aacute": "\u00C1", "aacute": "\u00E1", "Abreve": "\u0102", "abreve": "\u0103", "ac": "\u223E",
+	"acd": "\u223F", "acE": "\u223E\u0333", "Acirc": "\u00C2", "acirc": "\u00E2", "acute": "\u00B4",
+	"Acy": "\u0410", "acy": "\u0430", "AElig": "\u00C6", "aelig": "\u00E6", "af": "\u2061",
+	"Afr": "\U0001D504", "afr": "\U0001D51E", "Agrave": "\u00C0", "agrave": "\u00E0", "alefsym": "\u2135",
+	"aleph": "\u2135", "Alpha": "\u0391", "alpha": "\u03B1", "Amacr": "\u0100", "amacr": "\u0101",
+	"amalg": "\u2A3F", "AMP": "&", "amp": "&", "And": "\u2A53", "and": "\u2227",
+	"andand": "\u2A55", "andd": "\u2A5C", "andslope": "\u2A58", "andv": "\u2A5A", "ang": "\u2220",
+	"ange": "\u29A4", "angle": "\u2220", "angmsd": "\u2221", "angmsdaa": "\u29A8", "angmsdab": "\u29A9",
+	"angmsdac": "\u29AA", "angmsdad": "\u29AB", "angmsdae": "\u29AC", "angmsdaf": "\u29AD", "angmsdag": "\u29AE",
+	"angmsdah": "\u29AF", "angrt": "\u221F", "angrtvb": "\u22BE", "angrtvbd": "\u299D", "angsph": "\u2222",
+	"angst": "\u00C5", "angzarr": "\u237C", "Aogon": "\u0104", "aogon": "\u0105", "Aopf": "\U0001D538",
+	"aopf": "\U0001D552", "ap": "\u2248", "apacir": "\u2A6F", "apE": "\u2A70", "ape": "\u224A",
+	"apid": "\u224B", "apos": "'", "ApplyFunction": "\u2061", "approx": "\u2248", "approxeq": "\u224A",
+	"Aring": "\u00C5", "aring": "\u00E5", "Ascr": "\U0001D49C", "ascr": "\U0001D4B6", "Assign": "\u2254",
+	"ast": "*", "asymp": "\u2248", "asympeq": "\u224D", "Atilde": "\u00C3", "atilde": "\u00E3",
+	"Auml": "\u00C4", "auml": "\u00E4", "awconint": "\u2233", "awint": "\u2A11", "backcong": "\u224C",
+	"backepsilon": "\u03F6", "backprime": "\u2035", "backsim": "\u223D", "backsimeq": "\u22CD", "Backslash": "\u2216",
+	"Barv": "\u2AE7", "barvee": "\u22BD", "Barwed": "\u2306", "barwed": "\u2305", "barwedge": "\u2305",
+	"bbrk": "\u23B5", "bbrktbrk": "\u23B6", "bcong": "\u224C", "Bcy": "\u0411", "bcy": "\u0431",
+	"bdquo": "\u201E", "becaus": "\u2235", "Because": "\u2235", "because": "\u2235", "bemptyv": "\u29B0",
+	"bepsi": "\u03F6", "bernou": "\u212C", "Bernoullis": "\u212C", "Beta": "\u0392", "beta": "\u03B2",
+	"beth": "\u2136", "between": "\u226C", "Bfr": "\U0001D505", "bfr": "\U0001D51F", "bigcap": "\u22C2",
+	"bigcirc": "\u25EF", "bigcup": "\u22C3", "bigodot": "\u2A00", "bigoplus": "\u2A01", "bigotimes": "\u2A02",
+	"bigsqcup": "\u2A06", "bigstar": "\u2605", "bigtriangledown": "\u25BD", "bigtriangleup": "\u25B3", "biguplus": "\u2A04",
+	"bigvee": "\u22C1", "bigwedge": "\u22C0", "bkarow": "\u290D", "blacklozenge": "\u29EB", "blacksquare": "\u25AA",
+	"blacktriangle": "\u25B4", "blacktriangledown": "\u25BE", "blacktriangleleft": "\u25C2", "blacktriangleright": "\u25B8", "blank": "\u2423",
+	"blk12": "\u2592", "blk14": "\u2591", "blk34": "\u2593", "block": "\u2588", "bne": "=\u20E5",
+	"bnequiv": "\u2261\u20E5", "bNot": "\u2AED", "bnot": "\u2310", "Bopf": "\U0001D539", "bopf": "\U0001D553",
+	"bot": "\u22A5", "bottom": "\u22A5", "bowtie": "\u22C8", "boxbox": "\u29C9", "boxDL": "\u2557",
+	"boxDl": "\u2556", "boxdL": "\u2555", "boxdl": "\u2510", "boxDR": "\u2554", "boxDr": "\u2553",
+	"boxdR": "\u2552", "boxdr": "\u250C", "boxH": "\u2550", "boxh": "\u2500", "boxHD": "\u2566",
+	"boxHd": "\u2564", "boxhD": "\u2565", "boxhd": "\u252C", "boxHU": "\u2569", "boxHu": "\u2567",
+	"boxhU": "\u2568", "boxhu": "\u2534", "boxminus": "\u229F", "boxplus": "\u229E", "boxtimes": "\u22A0",
+	"boxUL": "\u255D", "boxUl": "\u255C", "boxuL": "\u255B", "boxul": "\u2518", "boxUR": "\u255A",
+	"boxUr": "\u2559", "boxuR": "\u2558", "boxur": "\u2514", "boxV": "\u2551", "boxv": "\u2502",
+	"boxVH": "\u256C", "boxVh": "\u256B", "boxvH": "\u256A", "boxvh": "\u253C", "boxVL": "\u2563",
+	"boxVl": "\u2562", "boxvL": "\u2561", "boxvl": "\u2524", "boxVR": "\u2560", "boxVr": "\u255F",
+	"boxvR": "\u255E", "boxvr": "\u251C", "bprime": "\u2035", "Breve": "\u02D8", "breve": "\u02D8",
+	"brvbar": "\u00A6", "Bscr": "\u212C", "bscr": "\U0001D4B7", "bsemi": "\u204F", "bsim": "\u223D",
+	"bsime": "\u22CD", "bsol": "\\", "bsolb": "\u29C5", "bsolhsub": "\u27C8", "bull": "\u2022",
+	"bullet": "\u2022", "bump": "\u224E", "bumpE": "\u2AAE", "bumpe": "\u224F", "Bumpeq": "\u224E",
+	"bumpeq": "\u224F", "Cacute": "\u0106", "cacute": "\u0107", "Cap": "\u22D2", "cap": "\u2229",
+	"capand": "\u2A44", "capbrcup": "\u2A49", "capcap": "\u2A4B", "capcup": "\u2A47", "capdot": "\u2A40",
+	"CapitalDifferentialD": "\u2145", "caps": "\u2229\uFE00", "caret": "\u2041", "caron": "\u02C7", "Cayleys": "\u212D",
+	"ccaps": "\u2A4D", "Ccaron": "\u010C", "ccaron": "\u010D", "Ccedil": "\u00C7", "ccedil": "\u00E7",
+	"Ccirc": "\u0108", "ccirc": "\u0109", "Cconint": "\u2230", "ccups": "\u2A4C", "ccupssm": "\u2A50",
+	"Cdot": "\u010A", "cdot": "\u010B", "cedil": "\u00B8", "Cedilla": "\u00B8", "cemptyv": "\u29B2",
+	"cent": "\u00A2", "CenterDot": "\u00B7", "centerdot": "\u00B7", "Cfr": "\u212D", "cfr": "\U0001D520",
+	"CHcy": "\u0427", "chcy": "\u0447", "check": "\u2713", "checkmark": "\u2713", "Chi": "\u03A7",
+	"chi": "\u03C7", "cir": "\u25CB", "circ": "\u02C6", "circeq": "\u2257", "circlearrowleft": "\u21BA",
+	"circlearrowright": "\u21BB", "circledast": "\u229B", "circledcirc": "\u229A", "circleddash": "\u229D", "CircleDot": "\u2299",
+	"circledR": "\u00AE", "circledS": "\u24C8", "CircleMinus": "\u2296", "CirclePlus": "\u2295", "CircleTimes": "\u2297",
+	"cirE": "\u29C3", "cire": "\u2257", "cirfnint": "\u2A10", "cirmid": "\u2AEF", "cirscir": "\u29C2",
+	"ClockwiseContourIntegral": "\u2232", "CloseCurlyDoubleQuote": "\u201D", "CloseCurlyQuote": "\u2019", "clubs": "\u2663", "clubsuit": "\u2663",
+	"Colon": "\u2237", "colon": ":", "Colone": "\u2A74", "colone": "\u2254", "coloneq": "\u2254",
+	"comma": ",", "commat": "@", "comp": "\u2201", "compfn": "\u2218", "complement": "\u2201",
+	"complexes": "\u2102", "cong": "\u2245", "congdot": "\u2A6D", "Congruent": "\u2261", "Conint": "\u222F",
+	"conint": "\u222E", "ContourIntegral": "\u222E", "Copf": "\u2102", "copf": "\U0001D554", "coprod": "\u2210",
+	"Coproduct": "\u2210", "COPY": "\u00A9", "copy": "\u00A9", "copysr": "\u2117", "CounterClockwiseContourIntegral": "\u2233",
+	"crarr": "\u21B5", "Cross": "\u2A2F", "cross": "\u2717", "Cscr": "\U0001D49E", "cscr": "\U0001D4B8",
+	"csub": "\u2ACF", "csube": "\u2AD1", "csup": "\u2AD0", "csupe": "\u2AD2", "ctdot": "\u22EF",
+	"cudarrl": "\u2938", "cudarrr": "\u2935", "cuepr": "\u22DE", "cuesc": "\u22DF", "cularr": "\u21B6",
+	"cularrp": "\u293D", "Cup": "\u22D3", "cup": "\u222A", "cupbrcap": "\u2A48", "CupCap": "\u224D",
+	"cupcap": "\u2A46", "cupcup": "\u2A4A", "cupdot": "\u228D", "cupor": "\u2A45", "cups": "\u222A\uFE00",
+	"curarr": "\u21B7", "curarrm": "\u293C", "curlyeqprec": "\u22DE", "curlyeqsucc": "\u22DF", "curlyvee": "\u22CE",
+	"curlywedge": "\u22CF", "curren": "\u00A4", "curvearrowleft": "\u21B6", "curvearrowright": "\u21B7", "cuvee": "\u22CE",
+	"cuwed": "\u22CF", "cwconint": "\u2232", "cwint": "\u2231", "cylcty": "\u232D", "Dagger": "\u2021",
+	"dagger": "\u2020", "daleth": "\u2138", "Darr": "\u21A1", "dArr": "\u21D3", "darr": "\u2193",
+	"dash": "\u2010", "Dashv": "\u2AE4", "dashv": "\u22A3", "dbkarow": "\u290F", "dblac": "\u02DD",
+	"Dcaron": "\u010E", "dcaron": "\u010F", "Dcy": "\u0414", "dcy": "\u0434", "DD": "\u2145",
+	"dd": "\u2146", "ddagger": "\u2021", "ddarr": "\u21CA", "DDotrahd": "\u2911", "ddotseq": "\u2A77",
+	"deg": "\u00B0", "Del": "\u2207", "Delta": "\u0394", "delta": "\u03B4", "demptyv": "\u29B1",
+	"dfisht": "\u297F", "Dfr": "\U0001D507", "dfr": "\U0001D521", "dHar": "\u2965", "dharl": "\u21C3",
+	"dharr": "\u21C2", "DiacriticalAcute": "\u00B4", "DiacriticalDot": "\u02D9", "DiacriticalDoubleAcute": "\u02DD", "DiacriticalGrave": "`",
+	"DiacriticalTilde": "\u02DC", "diam": "\u22C4", "Diamond": "\u22C4", "diamond": "\u22C4", "diamondsuit": "\u2666",
+	"diams": "\u2666", "die": "\u00A8", "DifferentialD": "\u2146", "digamma": "\u03DD", "disin": "\u22F2",
+	"div": "\u00F7", "divide": "\u00F7", "divideontimes": "\u22C7", "divonx": "\u22C7", "DJcy": "\u0402",
+	"djcy": "\u0452", "dlcorn": "\u231E", "dlcrop": "\u230D", "dollar": "$", "Dopf": "\U0001D53B",
+	"dopf": "\U0001D555", "Dot": "\u00A8", "dot": "\u02D9", "DotDot": "\u20DC", "doteq": "\u2250",
+	"doteqdot": "\u2251", "DotEqual": "\u2250", "dotminus": "\u2238", "dotplus": "\u2214", "dotsquare": "\u22A1",
+	"doublebarwedge": "\u2306", "DoubleContourIntegral": "\u222F", "DoubleDot": "\u00A8", "DoubleDownArrow": "\u21D3", "DoubleLeftArrow": "\u21D0",
+	"DoubleLeftRightArrow": "\u21D4", "DoubleLeftTee": "\u2AE4", "DoubleLongLeftArrow": "\u27F8", "DoubleLongLeftRightArrow": "\u27FA", "DoubleLongRightArrow": "\u27F9",
+	"DoubleRightArrow": "\u21D2", "DoubleRightTee": "\u22A8", "DoubleUpArrow": "\u21D1", "DoubleUpDownArrow": "\u21D5", "DoubleVerticalBar": "\u2225",
+	"DownArrow": "\u2193", "Downarrow": "\u21D3", "downarrow": "\u2193", "DownArrowBar": "\u2913", "DownArrowUpArrow": "\u21F5",
+	"DownBreve": "\u0311", "downdownarrows": "\u21CA", "downharpoonleft": "\u21C3", "downharpoonright": "\u21C2", "DownLeftRightVector": "\u2950",
+	"DownLeftTeeVector": "\u295E", "DownLeftVector": "\u21BD", "DownLeftVectorBar": "\u2956", "DownRightTeeVector": "\u295F", "DownRightVector": "\u21C1",
+	"DownRightVectorBar": "\u2957", "DownTee": "\u22A4", "DownTeeArrow": "\u21A7", "drbkarow": "\u2910", "drcorn": "\u231F",
+	"drcrop": "\u230C", "Dscr": "\U0001D49F", "dscr": "\U0001D4B9", "DScy": "\u0405", "dscy": "\u0455",
+	"dsol": "\u29F6", "Dstrok": "\u0110", "dstrok": "\u0111", "dtdot": "\u22F1", "dtri": "\u25BF",
+	"dtrif": "\u25BE", "duarr": "\u21F5", "duhar": "\u296F", "dwangle": "\u29A6", "DZcy": "\u040F",
+	"dzcy": "\u045F", "dzigrarr": "\u27FF", "Eacute": "\u00C9", "eacute": "\u00E9", "easter": "\u2A6E",
+	"Ecaron": "\u011A", "ecaron": "\u011B", "ecir": "\u2256", "Ecirc": "\u00CA", "ecirc": "\u00EA",
+	"ecolon": "\u2255", "Ecy": "\u042D", "ecy": "\u044D", "eDDot": "\u2A77", "Edot": "\u0116",
+	"eDot": "\u2251", "edot": "\u0117", "ee": "\u2147", "efDot": "\u2252", "Efr": "\U0001D508",
+	"efr": "\U0001D522", "eg": "\u2A9A", "Egrave": "\u00C8", "egrave": "\u00E8", "egs": "\u2A96",
+	"egsdot": "\u2A98", "el": "\u2A99", "Element": "\u2208", "elinters": "\u23E7", "ell": "\u2113",
+	"els": "\u2A95", "elsdot": "\u2A97", "Emacr": "\u0112", "emacr": "\u0113", "empty": "\u2205",
+	"emptyset": "\u2205", "EmptySmallSquare": "\u25FB", "emptyv": "\u2205", "EmptyVerySmallSquare": "\u25AB", "emsp": "\u2003",
+	"emsp13": "\u2004", "emsp14": "\u2005", "ENG": "\u014A", "eng": "\u014B", "ensp": "\u2002",
+	"Eogon": "\u0118", "eogon": "\u0119", "Eopf": "\U0001D53C", "eopf": "\U0001D556", "epar": "\u22D5",
+	"eparsl": "\u29E3", "eplus": "\u2A71", "epsi": "\u03B5", "Epsilon": "\u0395", "epsilon": "\u03B5",
+	"epsiv": "\u03F5", "eqcirc": "\u2256", "eqcolon": "\u2255", "eqsim": "\u2242", "eqslantgtr": "\u2A96",
+	"eqslantless": "\u2A95", "Equal": "\u2A75", "equals": "=", "EqualTilde": "\u2242", "equest": "\u225F",
+	"Equilibrium": "\u21CC", "equiv": "\u2261", "equivDD": "\u2A78", "eqvparsl": "\u29E5", "erarr": "\u2971",
+	"erDot": "\u2253", "Escr": "\u2130", "escr": "\u212F", "esdot": "\u2250", "Esim": "\u2A73",
+	"esim": "\u2242", "Eta": "\u0397", "eta": "\u03B7", "ETH": "\u00D0", "eth": "\u00F0",
+	"Euml": "\u00CB", "euml": "\u00EB", "euro": "\u20AC", "excl": "!", "exist": "\u2203",
+	"Exists": "\u2203", "expectation": "\u2130", "ExponentialE": "\u2147", "exponentiale": "\u2147", "fallingdotseq": "\u2252",
+	"Fcy": "\u0424", "fcy": "\u0444", "female": "\u2640", "ffilig": "\uFB03", "fflig": "\uFB00",
+	"ffllig": "\uFB04", "Ffr": "\U0001D509", "ffr": "\U0001D523", "filig": "\uFB01", "FilledSmallSquare": "\u25FC",
+	"FilledVerySmallSquare": "\u25AA", "fjlig": "fj", "flat": "\u266D", "fllig": "\uFB02", "fltns": "\u25B1",
+	"fnof": "\u0192", "Fopf": "\U0001D53D", "fopf": "\U0001D557", "ForAll": "\u2200", "forall": "\u2200",
+	"fork": "\u22D4", "forkv": "\u2AD9", "Fouriertrf": "\u2131", "fpartint": "\u2A0D", "frac12": "\u00BD",
+	"frac13": "\u2153", "frac14": "\u00BC", "frac15": "\u2155", "frac16": "\u2159", "frac18": "\u215B",
+	"frac23": "\u2154", "frac25": "\u2156", "frac34": "\u00BE", "frac35": "\u2157", "frac38": "\u215C",
+	"frac45": "\u2158", "frac56": "\u215A", "frac58": "\u215D", "frac78": "\u215E", "frasl": "\u2044",
+	"frown": "\u2322", "Fscr": "\u2131", "fscr": "\U0001D4BB", "gacute": "\u01F5", "Gamma": "\u0393",
+	"gamma": "\u03B3", "Gammad": "\u03DC", "gammad": "\u03DD", "gap": "\u2A86", "Gbreve": "\u011E",
+	"gbreve": "\u011F", "Gcedil": "\u0122", "Gcirc": "\u011C", "gcirc": "\u011D", "Gcy": "\u0413",
+	"gcy": "\u0433", "Gdot": "\u0120", "gdot": "\u0121", "gE": "\u2267", "ge": "\u2265",
+	"gEl": "\u2A8C", "gel": "\u22DB", "geq": "\u2265", "geqq": "\u2267", "geqslant": "\u2A7E",
+	"ges": "\u2A7E", "gescc": "\u2AA9", "gesdot": "\u2A80", "gesdoto": "\u2A82", "gesdotol": "\u2A84",
+	"gesl": "\u22DB\uFE00", "gesles": "\u2A94", "Gfr": "\U0001D50A", "gfr": "\U0001D524", "Gg": "\u22D9",
+	"gg": "\u226B", "ggg": "\u22D9", "gimel": "\u2137", "GJcy": "\u0403", "gjcy": "\u0453",
+	"gl": "\u2277", "gla": "\u2AA5", "glE": "\u2A92", "glj": "\u2AA4", "gnap": "\u2A8A",
+	"gnapprox": "\u2A8A", "gnE": "\u2269", "gne": "\u2A88", "gneq": "\u2A88", "gneqq": "\u2269",
+	"gnsim": "\u22E7", "Gopf": "\U0001D53E", "gopf": "\U0001D558", "grave": "`", "GreaterEqual": "\u2265",
+	"GreaterEqualLess": "\u22DB", "GreaterFullEqual": "\u2267", "GreaterGreater": "\u2AA2", "GreaterLess": "\u2277", "GreaterSlantEqual": "\u2A7E",
+	"GreaterTilde": "\u2273", "Gscr": "\U0001D4A2", "gscr": "\u210A", "gsim": "\u2273", "gsime": "\u2A8E",
+	"gsiml": "\u2A90", "GT": ">", "Gt": "\u226B", "gt": ">", "gtcc": "\u2AA7",
+	"gtcir": "\u2A7A", "gtdot": "\u22D7", "gtlPar": "\u2995", "gtquest": "\u2A7C", "gtrapprox": "\u2A86",
+	"gtrarr": "\u2978", "gtrdot": "\u22D7", "gtreqless": "\u22DB", "gtreqqless": "\u2A8C", "gtrless": "\u2277",
+	"gtrsim": "\u2273", "gvertneqq": "\u2269\uFE00", "gvnE": "\u2269\uFE00", "Hacek": "\u02C7", "hairsp": "\u200A",
+	"half": "\u00BD", "hamilt": "\u210B", "HARDcy": "\u042A", "hardcy": "\u044A", "hArr": "\u21D4",
+	"harr": "\u2194", "harrcir": "\u2948", "harrw": "\u21AD", "Hat": "^", "hbar": "\u210F",
+	"Hcirc": "\u0124", "hcirc": "\u0125", "hearts": "\u2665", "heartsuit": "\u2665", "hellip": "\u2026",
+	"hercon": "\u22B9", "Hfr": "\u210C", "hfr": "\U0001D525", "HilbertSpace": "\u210B", "hksearow": "\u2925",
+	"hkswarow": "\u2926", "hoarr": "\u21FF", "homtht": "\u223B", "hookleftarrow": "\u21A9", "hookrightarrow": "\u21AA",
+	"Hopf": "\u210D", "hopf": "\U0001D559", "horbar": "\u2015", "HorizontalLine": "\u2500", "Hscr": "\u210B",
+	"hscr": "\U0001D4BD", "hslash": "\u210F", "Hstrok": "\u0126", "hstrok": "\u0127", "HumpDownHump": "\u224E",
+	"HumpEqual": "\u224F", "hybull": "\u2043", "hyphen": "\u2010", "Iacute": "\u00CD", "iacute": "\u00ED",
+	"ic": "\u2063", "Icirc": "\u00CE", "icirc": "\u00EE", "Icy": "\u0418", "icy": "\u0438",
+	"Idot": "\u0130", "IEcy": "\u0415", "iecy": "\u0435", "iexcl": "\u00A1", "iff": "\u21D4",
+	"Ifr": "\u2111", "ifr": "\U0001D526", "Igrave": "\u00CC", "igrave": "\u00EC", "ii": "\u2148",
+	"iiiint": "\u2A0C", "iiint": "\u222D", "iinfin": "\u29DC", "iiota": "\u2129", "IJlig": "\u0132",
+	"ijlig": "\u0133", "Im": "\u2111", "Imacr": "\u012A", "imacr": "\u012B", "image": "\u2111",
+	"ImaginaryI": "\u2148", "imagline": "\u2110", "imagpart": "\u2111", "imath": "\u0131", "imof": "\u22B7",
+	"imped": "\u01B5", "Implies": "\u21D2", "in": "\u2208", "incare": "\u2105", "infin": "\u221E",
+	"infintie": "\u29DD", "inodot": "\u0131", "Int": "\u222C", "int": "\u222B", "intcal": "\u22BA",
+	"integers": "\u2124", "Integral": "\u222B", "intercal": "\u22BA", "Intersection": "\u22C2", "intlarhk": "\u2A17",
+	"intprod": "\u2A3C", "InvisibleComma": "\u2063", "InvisibleTimes": "\u2062", "IOcy": "\u0401", "iocy": "\u0451",
+	"Iogon": "\u012E", "iogon": "\u012F", "Iopf": "\U0001D540", "iopf": "\U0001D55A", "Iota": "\u0399",
+	"iota": "\u03B9", "iprod": "\u2A3C", "iquest": "\u00BF", "Iscr": "\u2110", "iscr": "\U0001D4BE",
+	"isin": "\u2208", "isindot": "\u22F5", "isinE": "\u22F9", "isins": "\u22F4", "isinsv": "\u22F3",
+	"isinv": "\u2208", "it": "\u2062", "Itilde": "\u0128", "itilde": "\u0129", "Iukcy": "\u0406",
+	"iukcy": "\u0456", "Iuml": "\u00CF", "iuml": "\u00EF", "Jcirc": "\u0134", "jcirc": "\u0135",
+	"Jcy": "\u0419", "jcy": "\u0439", "Jfr": "\U0001D50D", "jfr": "\U0001D527", "jmath": "\u0237",
+	"Jopf": "\U0001D541", "jopf": "\U0001D55B", "Jscr": "\U0001D4A5", "jscr": "\U0001D4BF", "Jsercy": "\u0408",
+	"jsercy": "\u0458", "Jukcy": "\u0404", "jukcy": "\u0454", "Kappa": "\u039A", "kappa": "\u03BA",
+	"kappav": "\u03F0", "Kcedil": "\u0136", "kcedil": "\u0137", "Kcy": "\u041A", "kcy": "\u043A",
+	"Kfr": "\U0001D50E", "kfr": "\U0001D528", "kgreen": "\u0138", "KHcy": "\u0425", "khcy": "\u0445",
+	"KJcy": "\u040C", "kjcy": "\u045C", "Kopf": "\U0001D542", "kopf": "\U0001D55C", "Kscr": "\U0001D4A6",
+	"kscr": "\U0001D4C0", "lAarr": "\u21DA", "Lacute": "\u0139", "lacute": "\u013A", "laemptyv": "\u29B4",
+	"lagran": "\u2112", "Lambda": "\u039B", "lambda": "\u03BB", "Lang": "\u27EA", "lang": "\u27E8",
+	"langd": "\u2991", "langle": "\u27E8", "lap": "\u2A85", "Laplacetrf": "\u2112", "laquo": "\u00AB",
+	"Larr": "\u219E", "lArr": "\u21D0", "larr": "\u2190", "larrb": "\u21E4", "larrbfs": "\u291F",
+	"larrfs": "\u291D", "larrhk": "\u21A9", "larrlp": "\u21AB", "larrpl": "\u2939", "larrsim": "\u2973",
+	"larrtl": "\u21A2", "lat": "\u2AAB", "lAtail": "\u291B", "latail": "\u2919", "late": "\u2AAD",
+	"lates": "\u2AAD\uFE00", "lBarr": "\u290E", "lbarr": "\u290C", "lbbrk": "\u2772", "lbrace": "{",
+	"lbrack": "[", "lbrke": "\u298B", "lbrksld": "\u298F", "lbrkslu": "\u298D", "Lcaron": "\u013D",
+	"lcaron": "\u013E", "Lcedil": "\u013B", "lcedil": "\u013C", "lceil": "\u2308", "lcub": "{",
+	"Lcy": "\u041B", "lcy": "\u043B", "ldca": "\u2936", "ldquo": "\u201C", "ldquor": "\u201E",
+	"ldrdhar": "\u2967", "ldrushar": "\u294B", "ldsh": "\u21B2", "lE": "\u2266", "le": "\u2264",
+	"LeftAngleBracket": "\u27E8", "LeftArrow": "\u2190", "Leftarrow": "\u21D0", "leftarrow": "\u2190", "LeftArrowBar": "\u21E4",
+	"LeftArrowRightArrow": "\u21C6", "leftarrowtail": "\u21A2", "LeftCeiling": "\u2308", "LeftDoubleBracket": "\u27E6", "LeftDownTeeVector": "\u2961",
+	"LeftDownVector": "\u21C3", "LeftDownVectorBar": "\u2959", "LeftFloor": "\u230A", "leftharpoondown": "\u21BD", "leftharpoonup": "\u21BC",
+	"leftleftarrows": "\u21C7", "LeftRightArrow": "\u2194", "Leftrightarrow": "\u21D4", "leftrightarrow": "\u2194", "leftrightarrows": "\u21C6",
+	"leftrightharpoons": "\u21CB", "leftrightsquigarrow": "\u21AD", "LeftRightVector": "\u294E", "LeftTee": "\u22A3", "LeftTeeArrow": "\u21A4",
+	"LeftTeeVector": "\u295A", "leftthreetimes": "\u22CB", "LeftTriangle": "\u22B2", "LeftTriangleBar": "\u29CF", "LeftTriangleEqual": "\u22B4",
+	"LeftUpDownVector": "\u2951", "LeftUpTeeVector": "\u2960", "LeftUpVector": "\u21BF", "LeftUpVectorBar": "\u2958", "LeftVector": "\u21BC",
+	"LeftVectorBar": "\u2952", "lEg": "\u2A8B", "leg": "\u22DA", "leq": "\u2264", "leqq": "\u2266",
+	"leqslant": "\u2A7D", "les": "\u2A7D", "lescc": "\u2AA8", "lesdot": "\u2A7F", "lesdoto": "\u2A81",
+	"lesdotor": "\u2A83", "lesg": "\u22DA\uFE00", "lesges": "\u2A93", "lessapprox": "\u2A85", "lessdot": "\u22D6",
+	"lesseqgtr": "\u22DA", "lesseqqgtr": "\u2A8B", "LessEqualGreater": "\u22DA", "LessFullEqual": "\u2266", "LessGreater": "\u2276",
+	"lessgtr": "\u2276", "LessLess": "\u2AA1", "lesssim": "\u2272", "LessSlantEqual": "\u2A7D", "LessTilde": "\u2272",
+	"lfisht": "\u297C", "lfloor": "\u230A", "Lfr": "\U0001D50F", "lfr": "\U0001D529", "lg": "\u2276",
+	"lgE": "\u2A91", "lHar": "\u2962", "lhard": "\u21BD", "lharu": "\u21BC", "lharul": "\u296A",
+	"lhblk": "\u2584", "LJcy": "\u0409", "ljcy": "\u0459", "Ll": "\u22D8", "ll": "\u226A",
+	"llarr": "\u21C7", "llcorner": "\u231E", "Lleftarrow": "\u21DA", "llhard": "\u296B", "lltri": "\u25FA",
+	"Lmidot": "\u013F", "lmidot": "\u0140", "lmoust": "\u23B0", "lmoustache": "\u23B0", "lnap": "\u2A89",
+	"lnapprox": "\u2A89", "lnE": "\u2268", "lne": "\u2A87", "lneq": "\u2A87", "lneqq": "\u2268",
+	"lnsim": "\u22E6", "loang": "\u27EC", "loarr": "\u21FD", "lobrk": "\u27E6", "LongLeftArrow": "\u27F5",
+	"Longleftarrow": "\u27F8", "longleftarrow": "\u27F5", "LongLeftRightArrow": "\u27F7", "Longleftrightarrow": "\u27FA", "longleftrightarrow": "\u27F7",
+	"longmapsto": "\u27FC", "LongRightArrow": "\u27F6", "Longrightarrow": "\u27F9", "longrightarrow": "\u27F6", "looparrowleft": "\u21AB",
+	"looparrowright": "\u21AC", "lopar": "\u2985", "Lopf": "\U0001D543", "lopf": "\U0001D55D", "loplus": "\u2A2D",
+	"lotimes": "\u2A34", "lowast": "\u2217", "lowbar": "_", "LowerLeftArrow": "\u2199", "LowerRightArrow": "\u2198",
+	"loz": "\u25CA", "lozenge": "\u25CA", "lozf": "\u29EB", "lpar": "(", "lparlt": "\u2993",
+	"lrarr": "\u21C6", "lrcorner": "\u231F", "lrhar": "\u21CB", "lrhard": "\u296D", "lrm": "\u200E",
+	"lrtri": "\u22BF", "lsaquo": "\u2039", "Lscr": "\u2112", "lscr": "\U0001D4C1", "Lsh": "\u21B0",
+	"lsh": "\u21B0", "lsim": "\u2272", "lsime": "\u2A8D", "lsimg": "\u2A8F", "lsqb": "[",
+	"lsquo": "\u2018", "lsquor": "\u201A", "Lstrok": "\u0141", "lstrok": "\u0142", "LT": "<",
+	"Lt": "\u226A", "lt": "<", "ltcc": "\u2AA6", "ltcir": "\u2A79", "ltdot": "\u22D6",
+	"lthree": "\u22CB", "ltimes": "\u22C9", "ltlarr": "\u2976", "ltquest": "\u2A7B", "ltri": "\u25C3",
+	"ltrie": "\u22B4", "ltrif": "\u25C2", "ltrPar": "\u2996", "lurdshar": "\u294A", "luruhar": "\u2966",
+	"lvertneqq": "\u2268\uFE00", "lvnE": "\u2268\uFE00", "macr": "\u00AF", "male": "\u2642", "malt": "\u2720",
+	"maltese": "\u2720", "Map": "\u2905", "map": "\u21A6", "mapsto": "\u21A6", "mapstodown": "\u21A7",
+	"mapstoleft": "\u21A4", "mapstoup": "\u21A5", "marker": "\u25AE", "mcomma": "\u2A29", "Mcy": "\u041C",
+	"mcy": "\u043C", "mdash": "\u2014", "mDDot": "\u223A", "measuredangle": "\u2221", "MediumSpace": "\u205F",
+	"Mellintrf": "\u2133", "Mfr": "\U0001D510", "mfr": "\U0001D52A", "mho": "\u2127", "micro": "\u00B5",
+	"mid": "\u2223", "midast": "*", "midcir": "\u2AF0", "middot": "\u00B7", "minus": "\u2212",
+	"minusb": "\u229F", "minusd": "\u2238", "minusdu": "\u2A2A", "MinusPlus": "\u2213", "mlcp": "\u2ADB",
+	"mldr": "\u2026", "mnplus": "\u2213", "models": "\u22A7", "Mopf": "\U0001D544", "mopf": "\U0001D55E",
+	"mp": "\u2213", "Mscr": "\u2133", "mscr": "\U0001D4C2", "mstpos": "\u223E", "Mu": "\u039C",
+	"mu": "\u03BC", "multimap": "\u22B8", "mumap": "\u22B8", "nabla": "\u2207", "Nacute": "\u0143",
+	"nacute": "\u0144", "nang": "\u2220\u20D2", "nap": "\u2249", "napE": "\u2A70\u0338", "napid": "\u224B\u0338",
+	"napos": "\u0149", "napprox": "\u2249", "natur": "\u266E", "natural": "\u266E", "naturals": "\u2115",
+	"nbsp": "\u00A0", "nbump": "\u224E\u0338", "nbumpe": "\u224F\u0338", "ncap": "\u2A43", "Ncaron": "\u0147",
+	"ncaron": "\u0148", "Ncedil": "\u0145", "ncedil": "\u0146", "ncong": "\u2247", "ncongdot": "\u2A6D\u0338",
+	"ncup": "\u2A42", "Ncy": "\u041D", "ncy": "\u043D", "ndash": "\u2013", "ne": "\u2260",
+	"nearhk": "\u2924", "neArr": "\u21D7", "nearr": "\u2197", "nearrow": "\u2197", "nedot": "\u2250\u0338",
+	"NegativeMediumSpace": "\u200B", "NegativeThickSpace": "\u200B", "NegativeThinSpace": "\u200B", "NegativeVeryThinSpace": "\u200B", "nequiv": "\u2262",
+	"nesear": "\u2928", "nesim": "\u2242\u0338", "NestedGreaterGreater": "\u226B", "NestedLessLess": "\u226A", "NewLine": "\u000A",
+	"nexist": "\u2204", "nexists": "\u2204", "Nfr": "\U0001D511", "nfr": "\U0001D52B", "ngE": "\u2267\u0338",
+	"nge": "\u2271", "ngeq": "\u2271", "ngeqq": "\u2267\u0338", "ngeqslant": "\u2A7E\u0338", "nges": "\u2A7E\u0338",
+	"nGg": "\u22D9\u0338", "ngsim": "\u2275", "nGt": "\u226B\u20D2", "ngt": "\u226F", "ngtr": "\u226F",
+	"nGtv": "\u226B\u0338", "nhArr": "\u21CE", "nharr": "\u21AE", "nhpar": "\u2AF2", "ni": "\u220B",
+	"nis": "\u22FC", "nisd": "\u22FA", "niv": "\u220B", "NJcy": "\u040A", "njcy": "\u045A",
+	"nlArr": "\u21CD", "nlarr": "\u219A", "nldr": "\u2025", "nlE": "\u2266\u0338", "nle": "\u2270",
+	"nLeftarrow": "\u21CD", "nleftarrow": "\u219A", "nLeftrightarrow": "\u21CE", "nleftrightarrow": "\u21AE", "nleq": "\u2270",
+	"nleqq": "\u2266\u0338", "nleqslant": "\u2A7D\u0338", "nles": "\u2A7D\u0338", "nless": "\u226E", "nLl": "\u22D8\u0338",
+	"nlsim": "\u2274", "nLt": "\u226A\u20D2", "nlt": "\u226E", "nltri": "\u22EA", "nltrie": "\u22EC",
+	"nLtv": "\u226A\u0338", "nmid": "\u2224", "NoBreak": "\u2060", "NonBreakingSpace": "\u00A0", "Nopf": "\u2115",
+	"nopf": "\U0001D55F", "Not": "\u2AEC", "not": "\u00AC", "NotCongruent": "\u2262", "NotCupCap": "\u226D",
+	"NotDoubleVerticalBar": "\u2226", "NotElement": "\u2209", "NotEqual": "\u2260", "NotEqualTilde": "\u2242\u0338", "NotExists": "\u2204",
+	"NotGreater": "\u226F", "NotGreaterEqual": "\u2271", "NotGreaterFullEqual": "\u2267\u0338", "NotGreaterGreater": "\u226B\u0338", "NotGreaterLess": "\u2279",
+	"NotGreaterSlantEqual": "\u2A7E\u0338", "NotGreaterTilde": "\u2275", "NotHumpDownHump": "\u224E\u0338", "NotHumpEqual": "\u224F\u0338", "notin": "\u2209",
+	"notindot": "\u22F5\u0338", "notinE": "\u22F9\u0338", "notinva": "\u2209", "notinvb": "\u22F7", "notinvc": "\u22F6",
+	"NotLeftTriangle": "\u22EA", "NotLeftTriangleBar": "\u29CF\u0338", "NotLeftTriangleEqual": "\u22EC", "NotLess": "\u226E", "NotLessEqual": "\u2270",
+	"NotLessGreater": "\u2278", "NotLessLess": "\u226A\u0338", "NotLessSlantEqual": "\u2A7D\u0338", "NotLessTilde": "\u2274", "NotNestedGreaterGreater": "\u2AA2\u0338",
+	"NotNestedLessLess": "\u2AA1\u0338", "notni": "\u220C", "notniva": "\u220C", "notnivb": "\u22FE", "notnivc": "\u22FD",
+	"NotPrecedes": "\u2280", "NotPrecedesEqual": "\u2AAF\u0338", "NotPrecedesSlantEqual": "\u22E0", "NotReverseElement": "\u220C", "NotRightTriangle": "\u22EB",
+	"NotRightTriangleBar": "\u29D0\u0338", "NotRightTriangleEqual": "\u22ED", "NotSquareSubset": "\u228F\u0338", "NotSquareSubsetEqual": "\u22E2", "NotSquareSuperset": "\u2290\u0338",
+	"NotSquareSupersetEqual": "\u22E3", "NotSubset": "\u2282\u20D2", "NotSubsetEqual": "\u2288", "NotSucceeds": "\u2281", "NotSucceedsEqual": "\u2AB0\u0338",
+	"NotSucceedsSlantEqual": "\u22E1", "NotSucceedsTilde": "\u227F\u0338", "NotSuperset": "\u2283\u20D2", "NotSupersetEqual": "\u2289", "NotTilde": "\u2241",
+	"NotTildeEqual": "\u2244", "NotTildeFullEqual": "\u2247", "NotTildeTilde": "\u2249", "NotVerticalBar": "\u2224", "npar": "\u2226",
+	"nparallel": "\u2226", "nparsl": "\u2AFD\u20E5", "npart": "\u2202\u0338", "npolint": "\u2A14", "npr": "\u2280",
+	"nprcue": "\u22E0", "npre": "\u2AAF\u0338", "nprec": "\u2280", "npreceq": "\u2AAF\u0338", "nrArr": "\u21CF",
+	"nrarr": "\u219B", "nrarrc": "\u2933\u0338", "nrarrw": "\u219D\u0338", "nRightarrow": "\u21CF", "nrightarrow": "\u219B",
+	"nrtri": "\u22EB", "nrtrie": "\u22ED", "nsc": "\u2281", "nsccue": "\u22E1", "nsce": "\u2AB0\u0338",
+	"Nscr": "\U0001D4A9", "nscr": "\U0001D4C3", "nshortmid": "\u2224", "nshortparallel": "\u2226", "nsim": "\u2241",
+	"nsime": "\u2244", "nsimeq": "\u2244", "nsmid": "\u2224", "nspar": "\u2226", "nsqsube": "\u22E2",
+	"nsqsupe": "\u22E3", "nsub": "\u2284", "nsubE": "\u2AC5\u0338", "nsube": "\u2288", "nsubset": "\u2282\u20D2",
+	"nsubseteq": "\u2288", "nsubseteqq": "\u2AC5\u0338", "nsucc": "\u2281", "nsucceq": "\u2AB0\u0338", "nsup": "\u2285",
+	"nsupE": "\u2AC6\u0338", "nsupe": "\u2289", "nsupset": "\u2283\u20D2", "nsupseteq": "\u2289", "nsupseteqq": "\u2AC6\u0338",
+	"ntgl": "\u2279", "Ntilde": "\u00D1", "ntilde": "\u00F1", "ntlg": "\u2278", "ntriangleleft": "\u22EA",
+	"ntrianglelefteq": "\u22EC", "ntriangleright": "\u22EB", "ntrianglerighteq": "\u22ED", "Nu": "\u039D", "nu": "\u03BD",
+	"num": "#", "numero": "\u2116", "numsp": "\u2007", "nvap": "\u224D\u20D2", "nVDash": "\u22AF",
+	"nVdash": "\u22AE", "nvDash": "\u22AD", "nvdash": "\u22AC", "nvge": "\u2265\u20D2", "nvgt": ">\u20D2",
+	"nvHarr": "\u2904", "nvinfin": "\u29DE", "nvlArr": "\u2902", "nvle": "\u2264\u20D2", "nvlt": "<\u20D2",
+	"nvltrie": "\u22B4\u20D2", "nvrArr": "\u2903", "nvrtrie": "\u22B5\u20D2", "nvsim": "\u223C\u20D2", "nwarhk": "\u2923",
+	"nwArr": "\u21D6", "nwarr": "\u2196", "nwarrow": "\u2196", "nwnear": "\u2927", "Oacute": "\u00D3",
+	"oacute": "\u00F3", "oast": "\u229B", "ocir": "\u229A", "Ocirc": "\u00D4", "ocirc": "\u00F4",
+	"Ocy": "\u041E", "ocy": "\u043E", "odash": "\u229D", "Odblac": "\u0150", "odblac": "\u0151",
+	"odiv": "\u2A38", "odot": "\u2299", "odsold": "\u29BC", "OElig": "\u0152", "oelig": "\u0153",
+	"ofcir": "\u29BF", "Ofr": "\U0001D512", "ofr": "\U0001D52C", "ogon": "\u02DB", "Ograve": "\u00D2",
+	"ograve": "\u00F2", "ogt": "\u29C1", "ohbar": "\u29B5", "ohm": "\u03A9", "oint": "\u222E",
+	"olarr": "\u21BA", "olcir": "\u29BE", "olcross": "\u29BB", "oline": "\u203E", "olt": "\u29C0",
+	"Omacr": "\u014C", "omacr": "\u014D", "Omega": "\u03A9", "omega": "\u03C9", "Omicron": "\u039F",
+	"omicron": "\u03BF", "omid": "\u29B6", "ominus": "\u2296", "Oopf": "\U0001D546", "oopf": "\U0001D560",
+	"opar": "\u29B7", "OpenCurlyDoubleQuote": "\u201C", "OpenCurlyQuote": "\u2018", "operp": "\u29B9", "oplus": "\u2295",
+	"Or": "\u2A54", "or": "\u2228", "orarr": "\u21BB", "ord": "\u2A5D", "order": "\u2134",
+	"orderof": "\u2134", "ordf": "\u00AA", "ordm": "\u00BA", "origof": "\u22B6", "oror": "\u2A56",
+	"orslope": "\u2A57", "orv": "\u2A5B", "oS": "\u24C8", "Oscr": "\U0001D4AA", "oscr": "\u2134",
+	"Oslash": "\u00D8", "oslash": "\u00F8", "osol": "\u2298", "Otilde": "\u00D5", "otilde": "\u00F5",
+	"Otimes": "\u2A37", "otimes": "\u2297", "otimesas": "\u2A36", "Ouml": "\u00D6", "ouml": "\u00F6",
+	"ovbar": "\u233D", "OverBar": "\u203E", "OverBrace": "\u23DE", "OverBracket": "\u23B4", "OverParenthesis": "\u23DC",
+	"par": "\u2225", "para": "\u00B6", "parallel": "\u2225", "parsim": "\u2AF3", "parsl": "\u2AFD",
+	"part": "\u2202", "PartialD": "\u2202", "Pcy": "\u041F", "pcy": "\u043F", "percnt": "%",
+	"period": ".", "permil": "\u2030", "perp": "\u22A5", "pertenk": "\u2031", "Pfr": "\U0001D513",
+	"pfr": "\U0001D52D", "Phi": "\u03A6", "phi": "\u03C6", "phiv": "\u03D5", "phmmat": "\u2133",
+	"phone": "\u260E", "Pi": "\u03A0", "pi": "\u03C0", "pitchfork": "\u22D4", "piv": "\u03D6",
+	"planck": "\u210F", "planckh": "\u210E", "plankv": "\u210F", "plus": "+", "plusacir": "\u2A23",
+	"plusb": "\u229E", "pluscir": "\u2A22", "plusdo": "\u2214", "plusdu": "\u2A25", "pluse": "\u2A72",
+	"PlusMinus": "\u00B1", "plusmn": "\u00B1", "plussim": "\u2A26", "plustwo": "\u2A27", "pm": "\u00B1",
+	"Poincareplane": "\u210C", "pointint": "\u2A15", "Popf": "\u2119", "popf": "\U0001D561", "pound": "\u00A3",
+	"Pr": "\u2ABB", "pr": "\u227A", "prap": "\u2AB7", "prcue": "\u227C", "prE": "\u2AB3",
+	"pre": "\u2AAF", "prec": "\u227A", "precapprox": "\u2AB7", "preccurlyeq": "\u227C", "Precedes": "\u227A",
+	"PrecedesEqual": "\u2AAF", "PrecedesSlantEqual": "\u227C", "PrecedesTilde": "\u227E", "preceq": "\u2AAF", "precnapprox": "\u2AB9",
+	"precneqq": "\u2AB5", "precnsim": "\u22E8", "precsim": "\u227E", "Prime": "\u2033", "prime": "\u2032",
+	"primes": "\u2119", "prnap": "\u2AB9", "prnE": "\u2AB5", "prnsim": "\u22E8", "prod": "\u220F",
+	"Product": "\u220F", "profalar": "\u232E", "profline": "\u2312", "profsurf": "\u2313", "prop": "\u221D",
+	"Proportion": "\u2237", "Proportional": "\u221D", "propto": "\u221D", "prsim": "\u227E", "prurel": "\u22B0",
+	"Pscr": "\U0001D4AB", "pscr": "\U0001D4C5", "Psi": "\u03A8", "psi": "\u03C8", "puncsp": "\u2008",
+	"Qfr": "\U0001D514", "qfr": "\U0001D52E", "qint": "\u2A0C", "Qopf": "\u211A", "qopf": "\U0001D562",
+	"qprime": "\u2057", "Qscr": "\U0001D4AC", "qscr": "\U0001D4C6", "quaternions": "\u210D", "quatint": "\u2A16",
+	"quest": "?", "questeq": "\u225F", "QUOT": "\"", "quot": "\"", "rAarr": "\u21DB",
+	"race": "\u223D\u0331", "Racute": "\u0154", "racute": "\u0155", "radic": "\u221A", "raemptyv": "\u29B3",
+	"Rang": "\u27EB", "rang": "\u27E9", "rangd": "\u2992", "range": "\u29A5", "rangle": "\u27E9",
+	"raquo": "\u00BB", "Rarr": "\u21A0", "rArr": "\u21D2", "rarr": "\u2192", "rarrap": "\u2975",
+	"rarrb": "\u21E5", "rarrbfs": "\u2920", "rarrc": "\u2933", "rarrfs": "\u291E", "rarrhk": "\u21AA",
+	"rarrlp": "\u21AC", "rarrpl": "\u2945", "rarrsim": "\u2974", "Rarrtl": "\u2916", "rarrtl": "\u21A3",
+	"rarrw": "\u219D", "rAtail": "\u291C", "ratail": "\u291A", "ratio": "\u2236", "rationals": "\u211A",
+	"RBarr": "\u2910", "rBarr": "\u290F", "rbarr": "\u290D", "rbbrk": "\u2773", "rbrace": "}",
+	"rbrack": "]", "rbrke": "\u298C", "rbrksld": "\u298E", "rbrkslu": "\u2990", "Rcaron": "\u0158",
+	"rcaron": "\u0159", "Rcedil": "\u0156", "rcedil": "\u0157", "rceil": "\u2309", "rcub": "}",
+	"Rcy": "\u0420", "rcy": "\u0440", "rdca": "\u2937", "rdldhar": "\u2969", "rdquo": "\u201D",
+	"rdquor": "\u201D", "rdsh": "\u21B3", "Re": "\u211C", "real": "\u211C", "realine": "\u211B",
+	"realpart": "\u211C", "reals": "\u211D", "rect": "\u25AD", "REG": "\u00AE", "reg": "\u00AE",
+	"ReverseElement": "\u220B", "ReverseEquilibrium": "\u21CB", "ReverseUpEquilibrium": "\u296F", "rfisht": "\u297D", "rfloor": "\u230B",
+	"Rfr": "\u211C", "rfr": "\U0001D52F", "rHar": "\u2964", "rhard": "\u21C1", "rharu": "\u21C0",
+	"rharul": "\u296C", "Rho": "\u03A1", "rho": "\u03C1", "rhov": "\u03F1", "RightAngleBracket": "\u27E9",
+	"RightArrow": "\u2192", "Rightarrow": "\u21D2", "rightarrow": "\u2192", "RightArrowBar": "\u21E5", "RightArrowLeftArrow": "\u21C4",
+	"rightarrowtail": "\u21A3", "RightCeiling": "\u2309", "RightDoubleBracket": "\u27E7", "RightDownTeeVector": "\u295D", "RightDownVector": "\u21C2",
+	"RightDownVectorBar": "\u2955", "RightFloor": "\u230B", "rightharpoondown": "\u21C1", "rightharpoonup": "\u21C0", "rightleftarrows": "\u21C4",
+	"rightleftharpoons": "\u21CC", "rightrightarrows": "\u21C9", "rightsquigarrow": "\u219D", "RightTee": "\u22A2", "RightTeeArrow": "\u21A6",
+	"RightTeeVector": "\u295B", "rightthreetimes": "\u22CC", "RightTriangle": "\u22B3", "RightTriangleBar": "\u29D0", "RightTriangleEqual": "\u22B5",
+	"RightUpDownVector": "\u294F", "RightUpTeeVector": "\u295C", "RightUpVector": "\u21BE", "RightUpVectorBar": "\u2954", "RightVector": "\u21C0",
+	"RightVectorBar": "\u2953", "ring": "\u02DA", "risingdotseq": "\u2253", "rlarr": "\u21C4", "rlhar": "\u21CC",
+	"rlm": "\u200F", "rmoust": "\u23B1", "rmoustache": "\u23B1", "rnmid": "\u2AEE", "roang": "\u27ED",
+	"roarr": "\u21FE", "robrk": "\u27E7", "ropar": "\u2986", "Ropf": "\u211D", "ropf": "\U0001D563",
+	"roplus": "\u2A2E", "rotimes": "\u2A35", "RoundImplies": "\u2970", "rpar": ")", "rpargt": "\u2994",
+	"rppolint": "\u2A12", "rrarr": "\u21C9", "Rrightarrow": "\u21DB", "rsaquo": "\u203A", "Rscr": "\u211B",
+	"rscr": "\U0001D4C7", "Rsh": "\u21B1", "rsh": "\u21B1", "rsqb": "]", "rsquo": "\u2019",
+	"rsquor": "\u2019", "rthree": "\u22CC", "rtimes": "\u22CA", "rtri": "\u25B9", "rtrie": "\u22B5",
+	"rtrif": "\u25B8", "rtriltri": "\u29CE", "RuleDelayed": "\u29F4", "ruluhar": "\u2968", "rx": "\u211E",
+	"Sacute": "\u015A", "sacute": "\u015B", "sbquo": "\u201A", "Sc": "\u2ABC", "sc": "\u227B",
+	"scap": "\u2AB8", "Scaron": "\u0160", "scaron": "\u0161", "sccue": "\u227D", "scE": "\u2AB4",
+	"sce": "\u2AB0", "Scedil": "\u015E", "scedil": "\u015F", "Scirc": "\u015C", "scirc": "\u015D",
+	"scnap": "\u2ABA", "scnE": "\u2AB6", "scnsim": "\u22E9", "scpolint": "\u2A13", "scsim": "\u227F",
+	"Scy": "\u0421", "scy": "\u0441", "sdot": "\u22C5", "sdotb": "\u22A1", "sdote": "\u2A66",
+	"searhk": "\u2925", "seArr": "\u21D8", "searr": "\u2198", "searrow": "\u2198", "sect": "\u00A7",
+	"semi": ";", "seswar": "\u2929", "setminus": "\u2216", "setmn": "\u2216", "sext": "\u2736",
+	"Sfr": "\U0001D516", "sfr": "\U0001D530", "sfrown": "\u2322", "sharp": "\u266F", "SHCHcy": "\u0429",
+	"shchcy": "\u0449", "SHcy": "\u0428", "shcy": "\u0448", "ShortDownArrow": "\u2193", "ShortLeftArrow": "\u2190",
+	"shortmid": "\u2223", "shortparallel": "\u2225", "ShortRightArrow": "\u2192", "ShortUpArrow": "\u2191", "shy": "\u00AD",
+	"Sigma": "\u03A3", "sigma": "\u03C3", "sigmaf": "\u03C2", "sigmav": "\u03C2", "sim": "\u223C",
+	"simdot": "\u2A6A", "sime": "\u2243", "simeq": "\u2243", "simg": "\u2A9E", "simgE": "\u2AA0",
+	"siml": "\u2A9D", "simlE": "\u2A9F", "simne": "\u2246", "simplus": "\u2A24", "simrarr": "\u2972",
+	"slarr": "\u2190", "SmallCircle": "\u2218", "smallsetminus": "\u2216", "smashp": "\u2A33", "smeparsl": "\u29E4",
+	"smid": "\u2223", "smile": "\u2323", "smt": "\u2AAA", "smte": "\u2AAC", "smtes": "\u2AAC\uFE00",
+	"SOFTcy": "\u042C", "softcy": "\u044C", "sol": "/", "solb": "\u29C4", "solbar": "\u233F",
+	"Sopf": "\U0001D54A", "sopf": "\U0001D564", "spades": "\u2660", "spadesuit": "\u2660", "spar": "\u2225",
+	"sqcap": "\u2293", "sqcaps": "\u2293\uFE00", "sqcup": "\u2294", "sqcups": "\u2294\uFE00", "Sqrt": "\u221A",
+	"sqsub": "\u228F", "sqsube": "\u2291", "sqsubset": "\u228F", "sqsubseteq": "\u2291", "sqsup": "\u2290",
+	"sqsupe": "\u2292", "sqsupset": "\u2290", "sqsupseteq": "\u2292", "squ": "\u25A1", "Square": "\u25A1",
+	"square": "\u25A1", "SquareIntersection": "\u2293", "SquareSubset": "\u228F", "SquareSubsetEqual": "\u2291", "SquareSuperset": "\u2290",
+	"SquareSupersetEqual": "\u2292", "SquareUnion": "\u2294", "squarf": "\u25AA", "squf": "\u25AA", "srarr": "\u2192",
+	"Sscr": "\U0001D4AE", "sscr": "\U0001D4C8", "ssetmn": "\u2216", "ssmile": "\u2323", "sstarf": "\u22C6",
+	"Star": "\u22C6", "star": "\u2606", "starf": "\u2605", "straightepsilon": "\u03F5", "straightphi": "\u03D5",
+	"strns": "\u00AF", "Sub": "\u22D0", "sub": "\u2282", "subdot": "\u2ABD", "subE": "\u2AC5",
+	"sube": "\u2286", "subedot": "\u2AC3", "submult": "\u2AC1", "subnE": "\u2ACB", "subne": "\u228A",
+	"subplus": "\u2ABF", "subrarr": "\u2979", "Subset": "\u22D0", "subset": "\u2282", "subseteq": "\u2286",
+	"subseteqq": "\u2AC5", "SubsetEqual": "\u2286", "subsetneq": "\u228A", "subsetneqq": "\u2ACB", "subsim": "\u2AC7",
+	"subsub": "\u2AD5", "subsup": "\u2AD3", "succ": "\u227B", "succapprox": "\u2AB8", "succcurlyeq": "\u227D",
+	"Succeeds": "\u227B", "SucceedsEqual": "\u2AB0", "SucceedsSlantEqual": "\u227D", "SucceedsTilde": "\u227F", "succeq": "\u2AB0",
+	"succnapprox": "\u2ABA", "succneqq": "\u2AB6", "succnsim": "\u22E9", "succsim": "\u227F", "SuchThat": "\u220B",
+	"Sum": "\u2211", "sum": "\u2211", "sung": "\u266A", "Sup": "\u22D1", "sup": "\u2283",
+	"sup1": "\u00B9", "sup2": "\u00B2", "sup3": "\u00B3", "supdot": "\u2ABE", "supdsub": "\u2AD8",
+	"supE": "\u2AC6", "supe": "\u2287", "supedot": "\u2AC4", "Superset": "\u2283", "SupersetEqual": "\u2287",
+	"suphsol": "\u27C9", "suphsub": "\u2AD7", "suplarr": "\u297B", "supmult": "\u2AC2", "supnE": "\u2ACC",
+	"supne": "\u228B", "supplus": "\u2AC0", "Supset": "\u22D1", "supset": "\u2283", "supseteq": "\u2287",
+	"supseteqq": "\u2AC6", "supsetneq": "\u228B", "supsetneqq": "\u2ACC", "supsim": "\u2AC8", "supsub": "\u2AD4",
+	"supsup": "\u2AD6", "swarhk": "\u2926", "swArr": "\u21D9", "swarr": "\u2199", "swarrow": "\u2199",
+	"swnwar": "\u292A", "szlig": "\u00DF", "Tab": "\u0009", "target": "\u2316", "Tau": "\u03A4",
+	"tau": "\u03C4", "tbrk": "\u23B4", "Tcaron": "\u0164", "tcaron": "\u0165", "Tcedil": "\u0162",
+	"tcedil": "\u0163", "Tcy": "\u0422", "tcy": "\u0442", "tdot": "\u20DB", "telrec": "\u2315",
+	"Tfr": "\U0001D517", "tfr": "\U0001D531", "there4": "\u2234", "Therefore": "\u2234", "therefore": "\u2234",
+	"Theta": "\u0398", "theta": "\u03B8", "thetasym": "\u03D1", "thetav": "\u03D1", "thickapprox": "\u2248",
+	"thicksim": "\u223C", "ThickSpace": "\u205F\u200A", "thinsp": "\u2009", "ThinSpace": "\u2009", "thkap": "\u2248",
+	"thksim": "\u223C", "THORN": "\u00DE", "thorn": "\u00FE", "Tilde": "\u223C", "tilde": "\u02DC",
+	"TildeEqual": "\u2243", "TildeFullEqual": "\u2245", "TildeTilde": "\u2248", "times": "\u00D7", "timesb": "\u22A0",
+	"timesbar": "\u2A31", "timesd": "\u2A30", "tint": "\u222D", "toea": "\u2928", "top": "\u22A4",
+	"topbot": "\u2336", "topcir": "\u2AF1", "Topf": "\U0001D54B", "topf": "\U0001D565", "topfork": "\u2ADA",
+	"tosa": "\u2929", "tprime": "\u2034", "TRADE": "\u2122", "trade": "\u2122", "triangle": "\u25B5",
+	"triangledown": "\u25BF", "triangleleft": "\u25C3", "trianglelefteq": "\u22B4", "triangleq": "\u225C", "triangleright": "\u25B9",
+	"trianglerighteq": "\u22B5", "tridot": "\u25EC", "trie": "\u225C", "triminus": "\u2A3A", "TripleDot": "\u20DB",
+	"triplus": "\u2A39", "trisb": "\u29CD", "tritime": "\u2A3B", "trpezium": "\u23E2", "Tscr": "\U0001D4AF",
+	"tscr": "\U0001D4C9", "TScy": "\u0426", "tscy": "\u0446", "TSHcy": "\u040B", "tshcy": "\u045B",
+	"Tstrok": "\u0166", "tstrok": "\u0167", "twixt": "\u226C", "twoheadleftarrow": "\u219E", "twoheadrightarrow": "\u21A0",
+	"Uacute": "\u00DA", "uacute": "\u00FA", "Uarr": "\u219F", "uArr": "\u21D1", "uarr": "\u2191",
+	"Uarrocir": "\u2949", "Ubrcy": "\u040E", "ubrcy": "\u045E", "Ubreve": "\u016C", "ubreve": "\u016D",
+	"Ucirc": "\u00DB", "ucirc": "\u00FB", "Ucy": "\u0423", "ucy": "\u0443", "udarr": "\u21C5",
+	"Udblac": "\u0170", "udblac": "\u0171", "udhar": "\u296E", "ufisht": "\u297E", "Ufr": "\U0001D518",
+	"ufr": "\U0001D532", "Ugrave": "\u00D9", "ugrave": "\u00F9", "uHar": "\u2963", "uharl": "\u21BF",
+	"uharr": "\u21BE", "uhblk": "\u2580", "ulcorn": "\u231C", "ulcorner": "\u231C", "ulcrop": "\u230F",
+	"ultri": "\u25F8", "Umacr": "\u016A", "umacr": "\u016B", "uml": "\u00A8", "UnderBar": "_",
+	"UnderBrace": "\u23DF", "UnderBracket": "\u23B5", "UnderParenthesis": "\u23DD", "Union": "\u22C3", "UnionPlus": "\u228E",
+	"Uogon": "\u0172", "uogon": "\u0173", "Uopf": "\U0001D54C", "uopf": "\U0001D566", "UpArrow": "\u2191",
+	"Uparrow": "\u21D1", "uparrow": "\u2191", "UpArrowBar": "\u2912", "UpArrowDownArrow": "\u21C5", "UpDownArrow": "\u2195",
+	"Updownarrow": "\u21D5", "updownarrow": "\u2195", "UpEquilibrium": "\u296E", "upharpoonleft": "\u21BF", "upharpoonright": "\u21BE",
+	"uplus": "\u228E", "UpperLeftArrow": "\u2196", "UpperRightArrow": "\u2197", "Upsi": "\u03D2", "upsi": "\u03C5",
+	"upsih": "\u03D2", "Upsilon": "\u03A5", "upsilon": "\u03C5", "UpTee": "\u22A5", "UpTeeArrow": "\u21A5",
+	"upuparrows": "\u21C8", "urcorn": "\u231D", "urcorner": "\u231D", "urcrop": "\u230E", "Uring": "\u016E",
+	"uring": "\u016F", "urtri": "\u25F9", "Uscr": "\U0001D4B0", "uscr": "\U0001D4CA", "utdot": "\u22F0",
+	"Utilde": "\u0168", "utilde": "\u0169", "utri": "\u25B5", "utrif": "\u25B4", "uuarr": "\u21C8",
+	"Uuml": "\u00DC", "uuml": "\u00FC", "uwangle": "\u29A7", "vangrt": "\u299C", "varepsilon": "\u03F5",
+	"varkappa": "\u03F0", "varnothing": "\u2205", "varphi": "\u03D5", "varpi": "\u03D6", "varpropto": "\u221D",
+	"vArr": "\u21D5", "varr": "\u2195", "varrho": "\u03F1", "varsigma": "\u03C2", "varsubsetneq": "\u228A\uFE00",
+	"varsubsetneqq": "\u2ACB\uFE00", "varsupsetneq": "\u228B\uFE00", "varsupsetneqq": "\u2ACC\uFE00", "vartheta": "\u03D1", "vartriangleleft": "\u22B2",
+	"vartriangleright": "\u22B3", "Vbar": "\u2AEB", "vBar": "\u2AE8", "vBarv": "\u2AE9", "Vcy": "\u0412",
+	"vcy": "\u0432", "VDash": "\u22AB", "Vdash": "\u22A9", "vDash": "\u22A8", "vdash": "\u22A2",
+	"Vdashl": "\u2AE6", "Vee": "\u22C1", "vee": "\u2228", "veebar": "\u22BB", "veeeq": "\u225A",
+	"vellip": "\u22EE", "Verbar": "\u2016", "verbar": "|", "Vert": "\u2016", "vert": "|",
+	"VerticalBar": "\u2223", "VerticalLine": "|", "VerticalSeparator": "\u2758", "VerticalTilde": "\u2240", "VeryThinSpace": "\u200A",
+	"Vfr": "\U0001D519", "vfr": "\U0001D533", "vltri": "\u22B2", "vnsub": "\u2282\u20D2", "vnsup": "\u2283\u20D2",
+	"Vopf": "\U0001D54D", "vopf": "\U0001D567", "vprop": "\u221D", "vrtri": "\u22B3", "Vscr": "\U0001D4B1",
+	"vscr": "\U0001D4CB", "vsubnE": "\u2ACB\uFE00", "vsubne": "\u228A\uFE00", "vsupnE": "\u2ACC\uFE00", "vsupne": "\u228B\uFE00",
+	"Vvdash": "\u22AA", "vzigzag": "\u299A", "Wcirc": "\u0174", "wcirc": "\u0175", "wedbar": "\u2A5F",
+	"Wedge": "\u22C0", "wedge": "\u2227", "wedgeq": "\u2259", "weierp": "\u2118", "Wfr": "\U0001D51A",
+	"wfr": "\U0001D534", "Wopf": "\U0001D54E", "wopf": "\U0001D568", "wp": "\u2118", "wr": "\u2240",
+	"wreath": "\u2240", "Wscr": "\U0001D4B2", "wscr": "\U0001D4CC", "xcap": "\u22C2", "xcirc": "\u25EF",
+	"xcup": "\u22C3", "xdtri": "\u25BD", "Xfr": "\U0001D51B", "xfr": "\U0001D535", "xhArr": "\u27FA",
+	"xharr": "\u27F7", "Xi": "\u039E", "xi": "\u03BE", "xlArr": "\u27F8", "xlarr": "\u27F5",
+	"xmap": "\u27FC", "xnis": "\u22FB", "xodot": "\u2A00", "Xopf": "\U0001D54F", "xopf": "\U0001D569",
+	"xoplus": "\u2A01", "xotime": "\u2A02", "xrArr": "\u27F9", "xrarr": "\u27F6", "Xscr": "\U0001D4B3",
+	"xscr": "\U0001D4CD", "xsqcup": "\u2A06", "xuplus": "\u2A04", "xutri": "\u25B3", "xvee": "\u22C1",
+	"xwedge": "\u22C0", "Yacute": "\u00DD", "yacute": "\u00FD", "YAcy": "\u042F", "yacy": "\u044F",
+	"Ycirc": "\u0176", "ycirc": "\u0177", "Ycy": "\u042B", "ycy": "\u044B", "yen": "\u00A5",
+	"Yfr": "\U0001D51C", "yfr": "\U0001D536", "YIcy": "\u0407", "yicy": "\u0457", "Yopf": "\U0001D550",
+	"yopf": "\U0001D56A", "Yscr": "\U0001D4B4", "yscr": "\U0001D4CE", "YUcy": "\u042E", "yucy": "\u044E",
+	"Yuml": "\u0178", "yuml": "\u00FF", "Zacute": "\u0179", "zacute": "\u017A", "Zcaron": "\u017D",
+	"zcaron": "\u017E", "Zcy": "\u0417", "zcy": "\u0437", "Zdot": "\u017B", "zdot": "\u017C",
+	"zeetrf": "\u2128", "ZeroWidthSpace": "\u200B", "Zeta": "\u0396", "zeta": "\u03B6", "Zfr": "\u2128",
+	"zfr": "\U0001D537", "ZHcy": "\u0416", "zhcy": "\u0436", "zigrarr": "\u21DD", "Zopf": "\u2124",
+	"zopf": "\U0001D56B", "Zscr": "\U0001D4B5", "zscr": "\U0001D4CF", "zwj": "\u200D", "zwnj": "\u200C",
+}
@@ -2,7 +2,9 @@ package php2go
 
 import (
 	"encoding/json"
+	"math"
 	"strconv"
+	"strings"
 )
 
 //GetInterfaceToString interface 转 string
@@ -149,3 +151,260 @@ func GetInterfaceToFloat(value interface{}) float64 {
 	}
 	return it
 }
+
+// phpStringVal the PHP string conversion of value, floats use the precision of 14 digits like echo
+func phpStringVal(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "1"
+		}
+		return ""
+	case float64:
+		return phpFloatString(v)
+	case float32:
+		return phpFloatString(float64(v))
+	}
+	return GetInterfaceToString(value)
+}
+
+// phpFloatString the PHP string conversion of a float: 0.3, 1.0E+25, -INF, NAN
+func phpFloatString(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "INF"
+	case math.IsInf(f, -1):
+		return "-INF"
+	case math.IsNaN(f):
+		return "NAN"
+	}
+	return phpGcvt(f, 14, 'E')
+}
+
+// phpGcvt zend_gcvt(), the shortest of the fixed and exponential forms with at most precision significant digits.
+// precision -1 uses the shortest representation that reads back to f.
+func phpGcvt(f float64, precision int, exp byte) string {
+	var digits string
+	if precision < 0 {
+		digits, precision = strconv.FormatFloat(math.Abs(f), 'e', -1, 64), 17
+	} else {
+		digits = strconv.FormatFloat(math.Abs(f), 'e', precision-1, 64)
+	}
+	i := strings.IndexByte(digits, 'e')
+	decpt, _ := strconv.Atoi(digits[i+1:])
+	decpt++
+	digits = strings.TrimRight(strings.Replace(digits[:i], ".", "", 1), "0")
+	if digits == "" {
+		digits, decpt = "0", 1
+	}
+
+	var buf []byte
+	if math.Signbit(f) {
+		buf = append(buf, '-')
+	}
+	switch {
+	case decpt < -3 || decpt > precision:
+		// exponential format, 1.0e+25
+		buf = append(buf, digits[0], '.')
+		if len(digits) == 1 {
+			buf = append(buf, '0')
+		} else {
+			buf = append(buf, digits[1:]...)
+		}
+		buf = append(buf, exp)
+		decpt--
+		if decpt < 0 {
+			buf = append(buf, '-')
+			decpt = -decpt
+		} else {
+			buf = append(buf, '+')
+		}
+		buf = strconv.AppendInt(buf, int64(decpt), 10)
+	case decpt <= 0:
+		// 0.000123
+		buf = append(buf, '0', '.')
+		buf = append(buf, strings.Repeat("0", -decpt)...)
+		buf = append(buf, digits...)
+	default:
+		for i := 0; i < decpt; i++ {
+			if i < len(digits) {
+				buf = append(buf, digits[i])
+			} else {
+				buf = append(buf, '0')
+			}
+		}
+		if len(digits) > decpt {
+			buf = append(buf, '.')
+			buf = append(buf, digits[decpt:]...)
+		}
+	}
+	return string(buf)
+}
+
+// phpIntVal the PHP integer conversion of value, numeric strings are read up to the first invalid character
+func phpIntVal(value interface{}) int64 {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if v {
+			return 1
+		}
+		return 0
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case uint:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return int64(v)
+	case float32:
+		return phpFloatToInt(float64(v))
+	case float64:
+		return phpFloatToInt(v)
+	case string:
+		i, f, isFloat := phpNumericPrefix(v)
+		if !isFloat {
+			return i
+		}
+		// zend_dval_to_lval_cap()
+		switch {
+		case math.IsNaN(f):
+			return 0
+		case f >= math.MaxInt64:
+			return math.MaxInt64
+		case f <= math.MinInt64:
+			return math.MinInt64
+		}
+		return int64(f)
+	case []byte:
+		return phpIntVal(string(v))
+	}
+	return int64(GetInterfaceToInt(value))
+}
+
+// phpFloatToInt zend_dval_to_lval(), 0 when f is out of the range of int64
+func phpFloatToInt(f float64) int64 {
+	if math.IsNaN(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+		return 0
+	}
+	return int64(f)
+}
+
+// phpFloatVal the PHP float conversion of value
+func phpFloatVal(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case string:
+		i, f, isFloat := phpNumericPrefix(v)
+		if !isFloat {
+			return float64(i)
+		}
+		return f
+	case []byte:
+		return phpFloatVal(string(v))
+	}
+	return float64(phpIntVal(value))
+}
+
+// phpNumericPrefix the number at the start of s after leading whitespace, like is_numeric_string() with errors allowed.
+// Integers that overflow int64 are returned as floats.
+func phpNumericPrefix(s string) (i int64, f float64, isFloat bool) {
+	s = strings.TrimLeft(s, " \t\n\r\v\f")
+	n := 0
+	if n < len(s) && (s[n] == '+' || s[n] == '-') {
+		n++
+	}
+	start := n
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	intDigits := n - start
+	if n < len(s) && s[n] == '.' {
+		frac := n + 1
+		for frac < len(s) && s[frac] >= '0' && s[frac] <= '9' {
+			frac++
+		}
+		if intDigits > 0 || frac > n+1 {
+			isFloat = true
+			n = frac
+		}
+	}
+	if !isFloat && intDigits == 0 {
+		return 0, 0, false
+	}
+	if n < len(s) && (s[n] == 'e' || s[n] == 'E') {
+		exp := n + 1
+		if exp < len(s) && (s[exp] == '+' || s[exp] == '-') {
+			exp++
+		}
+		if exp < len(s) && s[exp] >= '0' && s[exp] <= '9' {
+			for exp < len(s) && s[exp] >= '0' && s[exp] <= '9' {
+				exp++
+			}
+			isFloat = true
+			n = exp
+		}
+	}
+	if !isFloat {
+		if i, err := strconv.ParseInt(s[:n], 10, 64); err == nil {
+			return i, 0, false
+		}
+		isFloat = true
+	}
+	f, _ = strconv.ParseFloat(s[:n], 64)
+	return 0, f, true
+}
+
+// phpScalar whether value is a PHP scalar, or null, phpStringVal converts it without JSON encoding
+func phpScalar(value interface{}) bool {
+	switch value.(type) {
+	case nil, bool, string, []byte, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
+// phpArray the elements of a PHP array argument: []interface{}, []string, []int, []int64 or []float64
+func phpArray(value interface{}) ([]interface{}, bool) {
+	var array []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		for _, e := range v {
+			array = append(array, e)
+		}
+	case []int:
+		for _, e := range v {
+			array = append(array, e)
+		}
+	case []int64:
+		for _, e := range v {
+			array = append(array, e)
+		}
+	case []float64:
+		for _, e := range v {
+			array = append(array, e)
+		}
+	default:
+		return nil, false
+	}
+	return array, true
+}
@@ -0,0 +1,426 @@
+package php2go
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lunarInfo 1900-2100, bits 0-3: the leap month or 0, bits 4-15: months 12 to 1, 30 days when set,
+// bit 16: the leap month has 30 days
+var lunarInfo = []int{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5d0, 0x14573, 0x052d0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b5a0, 0x195a6, // 1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040-2049
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, // 2050-2059
+	0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, // 2060-2069
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070-2079
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, // 2080-2089
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, // 2090-2099
+	0x0d520, // 2100
+}
+
+const (
+	lunarMinYear = 1900
+	lunarMaxYear = 2100
+	// lunarEpoch 1900-01-31, the first day of lunar year 1900
+	lunarEpoch = 2415051
+)
+
+var (
+	lunarStems      = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	lunarBranches   = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	lunarAnimals    = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+	lunarMonthNames = []string{"", "正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+	lunarNumbers    = []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九", "十"}
+	lunarSolarTerms = []string{"小寒", "大寒", "立春", "雨水", "惊蛰", "春分", "清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+		"小暑", "大暑", "立秋", "处暑", "白露", "秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至"}
+	lunarFestivals = map[[2]int]string{
+		{1, 1}: "春节", {1, 15}: "元宵节", {2, 2}: "龙抬头", {5, 5}: "端午节", {7, 7}: "七夕节", {7, 15}: "中元节",
+		{8, 15}: "中秋节", {9, 9}: "重阳节", {10, 1}: "寒衣节", {10, 15}: "下元节", {12, 8}: "腊八节", {12, 23}: "小年",
+	}
+	// lunarBeijing the Chinese calendar is reckoned in Beijing time
+	lunarBeijing = time.FixedZone("CST", 8*3600)
+)
+
+// LunarDate a date of the Chinese lunar calendar
+// GanZhiYear and Zodiac follow the lunar year, GanZhiMonth changes on the solar terms 立春, 惊蛰 ...
+// Term is the solar term on that day and Festival the traditional festival, or "".
+type LunarDate struct {
+	Year, Month, Day int
+	IsLeapMonth      bool
+	GanZhiYear       string
+	GanZhiMonth      string
+	GanZhiDay        string
+	Zodiac           string
+	Term             string
+	Festival         string
+}
+
+// SolarToLunar gregorian date to lunar date, 1900-01-31 to 2100-12-31
+// SolarToLunar(2, 10, 2024) = 2024 正月初一 甲辰 龙 春节
+func SolarToLunar(month, day, year int) (*LunarDate, error) {
+	if !Checkdate(month, day, year) {
+		return nil, errors.New("invalid date")
+	}
+	jd := calGregorianToSdn(year, month, day)
+	offset := jd - lunarEpoch
+	if offset < 0 || year > lunarMaxYear {
+		return nil, errors.New("date out of range (1900-01-31 to 2100-12-31)")
+	}
+	ld := &LunarDate{Year: lunarMinYear}
+	for ; offset >= LunarDaysInYear(ld.Year); ld.Year++ {
+		offset -= LunarDaysInYear(ld.Year)
+	}
+	leap := LunarLeapMonth(ld.Year)
+	for ld.Month = 1; ; {
+		days := LunarDaysInMonth(ld.Month, ld.Year, ld.IsLeapMonth)
+		if offset < days {
+			break
+		}
+		offset -= days
+		if ld.Month == leap && !ld.IsLeapMonth {
+			ld.IsLeapMonth = true
+		} else {
+			ld.Month++
+			ld.IsLeapMonth = false
+		}
+	}
+	ld.Day = offset + 1
+
+	ld.GanZhiYear = lunarGanZhi(ld.Year - 4)
+	ld.Zodiac = lunarAnimals[(ld.Year-4)%12]
+	terms := lunarTermDays(year)
+	// the month changes on the first term of the gregorian month: 小寒, 立春, 惊蛰 ...
+	n := (year-1900)*12 + month + 11
+	if day >= terms[2*(month-1)] {
+		n++
+	}
+	ld.GanZhiMonth = lunarGanZhi(n)
+	ld.GanZhiDay = lunarGanZhi(jd + 49)
+	if day == terms[2*(month-1)] {
+		ld.Term = lunarSolarTerms[2*(month-1)]
+	} else if day == terms[2*(month-1)+1] {
+		ld.Term = lunarSolarTerms[2*(month-1)+1]
+	}
+
+	if !ld.IsLeapMonth {
+		ld.Festival = lunarFestivals[[2]int{ld.Month, ld.Day}]
+	}
+	if ld.Month == 12 && ld.IsLeapMonth == (leap == 12) && ld.Day == LunarDaysInMonth(12, ld.Year, ld.IsLeapMonth) {
+		ld.Festival = "除夕"
+	}
+	if ld.Term == "清明" {
+		ld.Festival = "清明节"
+	}
+	return ld, nil
+}
+
+// LunarToSolar lunar date to midnight of the gregorian date in the default timezone
+// LunarToSolar(8, 15, 2024, false) = 2024-09-17
+func LunarToSolar(month, day, year int, isLeapMonth bool) (time.Time, error) {
+	if year < lunarMinYear || year > lunarMaxYear {
+		return time.Time{}, errors.New("year out of range (1900-2100)")
+	}
+	if isLeapMonth && LunarLeapMonth(year) != month {
+		return time.Time{}, errors.New("month " + strconv.Itoa(month) + " of " + strconv.Itoa(year) + " is not a leap month")
+	}
+	if month < 1 || month > 12 || day < 1 || day > LunarDaysInMonth(month, year, isLeapMonth) {
+		return time.Time{}, errors.New("invalid date")
+	}
+	jd := lunarEpoch + day - 1
+	for y := lunarMinYear; y < year; y++ {
+		jd += LunarDaysInYear(y)
+	}
+	leap := LunarLeapMonth(year)
+	for m := 1; m < month; m++ {
+		jd += LunarDaysInMonth(m, year, false)
+		if m == leap {
+			jd += LunarDaysInMonth(m, year, true)
+		}
+	}
+	if isLeapMonth {
+		jd += LunarDaysInMonth(month, year, false)
+	}
+	y, m, d := calSdnToGregorian(jd)
+	return time.Date(y, time.Month(m), d, 0, 0, 0, 0, dateLocation(nil)), nil
+}
+
+// LunarLeapMonth the leap month of the lunar year, 0 when there is none
+func LunarLeapMonth(year int) int {
+	if year < lunarMinYear || year > lunarMaxYear {
+		return 0
+	}
+	return lunarInfo[year-lunarMinYear] & 0xf
+}
+
+// LunarDaysInMonth 29 or 30, 0 when the month does not exist
+func LunarDaysInMonth(month, year int, isLeapMonth bool) int {
+	if year < lunarMinYear || year > lunarMaxYear || month < 1 || month > 12 {
+		return 0
+	}
+	info := lunarInfo[year-lunarMinYear]
+	bit := 0x10000 >> uint(month)
+	if isLeapMonth {
+		if info&0xf != month {
+			return 0
+		}
+		bit = 0x10000
+	}
+	if info&bit != 0 {
+		return 30
+	}
+	return 29
+}
+
+// LunarDaysInYear 353 to 385
+func LunarDaysInYear(year int) int {
+	days := 0
+	for m := 1; m <= 12; m++ {
+		days += LunarDaysInMonth(m, year, false)
+	}
+	if leap := LunarLeapMonth(year); leap > 0 {
+		days += LunarDaysInMonth(leap, year, true)
+	}
+	return days
+}
+
+// LunarFestivalDate the gregorian date of a traditional festival of the lunar year, in the default timezone
+// LunarFestivalDate("中秋节", 2024), LunarFestivalDate("除夕", 2023) = 2024-02-09
+func LunarFestivalDate(name string, year int) (time.Time, error) {
+	switch name {
+	case "除夕":
+		month, isLeap := 12, LunarLeapMonth(year) == 12
+		return LunarToSolar(month, LunarDaysInMonth(month, year, isLeap), year, isLeap)
+	case "清明节":
+		terms, err := SolarTerms(year)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t := terms[6].Time
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, dateLocation(nil)), nil
+	}
+	for md, festival := range lunarFestivals {
+		if festival == name {
+			return LunarToSolar(md[0], md[1], year, false)
+		}
+	}
+	return time.Time{}, errors.New("unknown festival " + name)
+}
+
+// String 二〇二四年闰四月初一
+func (ld *LunarDate) String() string {
+	var b strings.Builder
+	for _, c := range strconv.Itoa(ld.Year) {
+		b.WriteString(lunarNumbers[c-'0'])
+	}
+	b.WriteString("年")
+	b.WriteString(ld.MonthName())
+	b.WriteString(ld.DayName())
+	return b.String()
+}
+
+// MonthName 正月, 闰四月, 冬月, 腊月
+func (ld *LunarDate) MonthName() string {
+	if ld.IsLeapMonth {
+		return "闰" + lunarMonthNames[ld.Month] + "月"
+	}
+	return lunarMonthNames[ld.Month] + "月"
+}
+
+// DayName 初一, 十五, 廿三, 三十
+func (ld *LunarDate) DayName() string {
+	switch {
+	case ld.Day <= 10:
+		return "初" + lunarNumbers[ld.Day]
+	case ld.Day < 20:
+		return "十" + lunarNumbers[ld.Day-10]
+	case ld.Day == 20:
+		return "二十"
+	case ld.Day < 30:
+		return "廿" + lunarNumbers[ld.Day-20]
+	}
+	return "三十"
+}
+
+// lunarGanZhi the n-th pair of the sexagenary cycle, 0 is 甲子
+func lunarGanZhi(n int) string {
+	n %= 60
+	if n < 0 {
+		n += 60
+	}
+	return lunarStems[n%10] + lunarBranches[n%12]
+}
+
+// SolarTerm a solar term and when it begins, in Beijing time
+type SolarTerm struct {
+	Name string
+	Time time.Time
+}
+
+// SolarTerms the 24 solar terms of a gregorian year, 小寒 to 冬至
+func SolarTerms(year int) ([]SolarTerm, error) {
+	if year < lunarMinYear || year > lunarMaxYear {
+		return nil, errors.New("year out of range (1900-2100)")
+	}
+	terms := make([]SolarTerm, 24)
+	for i := range terms {
+		terms[i] = SolarTerm{Name: lunarSolarTerms[i], Time: lunarTermTime(year, i)}
+	}
+	return terms, nil
+}
+
+// lunarTermDays the day of month of every solar term of the year, in Beijing time
+func lunarTermDays(year int) []int {
+	days := make([]int, 24)
+	for i := range days {
+		days[i] = lunarTermTime(year, i).Day()
+	}
+	return days
+}
+
+// lunarTermTime the moment the apparent longitude of the sun reaches the i-th term of the year (小寒 = 285°)
+func lunarTermTime(year, i int) time.Time {
+	target := math.Mod(285+15*float64(i), 360) * math.Pi / 180
+	jde := 2451545 + 365.2422*(float64(year-2000)+float64(i)/24) + 5
+	for k := 0; k < 20; k++ {
+		diff := math.Remainder(target-lunarSunLongitude(jde), 2*math.Pi)
+		jde += 58.13 * math.Sin(diff)
+		if math.Abs(diff) < 1e-9 {
+			break
+		}
+	}
+	jd := jde - lunarDeltaT(float64(year)+float64(i)/24)/86400
+	unix := (jd - 2440587.5) * 86400
+	return time.Unix(int64(math.Round(unix)), 0).In(lunarBeijing)
+}
+
+// lunarSunLongitude the apparent geocentric longitude of the sun in radians, Meeus chapter 25
+func lunarSunLongitude(jde float64) float64 {
+	tau := (jde - 2451545) / 365250
+	l := 0.0
+	for n, series := range lunarVSOP87L {
+		sum := 0.0
+		for _, term := range series {
+			sum += term[0] * math.Cos(term[1]+term[2]*tau)
+		}
+		l += sum * math.Pow(tau, float64(n))
+	}
+	l /= 1e8
+	t := tau * 10
+	// the mean anomaly of the sun for the radius vector, nutation in longitude
+	m := (357.52911 + 35999.05029*t) * math.Pi / 180
+	r := 1.000140 - 0.016708*math.Cos(m) - 0.000139*math.Cos(2*m)
+	omega := (125.04452 - 1934.136261*t) * math.Pi / 180
+	ls := (280.4665 + 36000.7698*t) * math.Pi / 180
+	lm := (218.3165 + 481267.8813*t) * math.Pi / 180
+	nutation := -17.20*math.Sin(omega) - 1.32*math.Sin(2*ls) - 0.23*math.Sin(2*lm) + 0.21*math.Sin(2*omega)
+	// geocentric = heliocentric + 180°, FK5 correction, nutation and aberration, in arcseconds
+	arcsec := math.Pi / 180 / 3600
+	return math.Mod(l+math.Pi+(-0.09033+nutation-20.4898/r)*arcsec, 2*math.Pi)
+}
+
+// lunarDeltaT TT - UT in seconds, Espenak and Meeus
+func lunarDeltaT(year float64) float64 {
+	switch {
+	case year < 1900:
+		t := year - 1860
+		return 7.62 + 0.5737*t - 0.251754*t*t + 0.01680668*t*t*t - 0.0004473624*t*t*t*t + t*t*t*t*t/233174
+	case year < 1920:
+		t := year - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case year < 1941:
+		t := year - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case year < 1961:
+		t := year - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case year < 1986:
+		t := year - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case year < 2005:
+		t := year - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case year < 2050:
+		t := year - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	}
+	u := (year - 1820) / 100
+	return -20 + 32*u*u - 0.5628*(2150-year)
+}
+
+// lunarVSOP87L the heliocentric longitude of the earth, VSOP87 truncated as in Meeus appendix III
+var lunarVSOP87L = [][][3]float64{
+	{
+		{175347046, 0, 0}, {3341656, 4.6692568, 6283.0758500}, {34894, 4.62610, 12566.15170},
+		{3497, 2.7441, 5753.3849}, {3418, 2.8289, 3.5231}, {3136, 3.6277, 77713.7715},
+		{2676, 4.4181, 7860.4194}, {2343, 6.1352, 3930.2097}, {1324, 0.7425, 11506.7698},
+		{1273, 2.0371, 529.6910}, {1199, 1.1096, 1577.3435}, {990, 5.233, 5884.927},
+		{902, 2.045, 26.298}, {857, 3.508, 398.149}, {780, 1.179, 5223.694},
+		{753, 2.533, 5507.553}, {505, 4.583, 18849.228}, {492, 4.205, 775.523},
+		{357, 2.920, 0.067}, {317, 5.849, 11790.629}, {284, 1.899, 796.298},
+		{271, 0.315, 10977.079}, {243, 0.345, 5486.778}, {206, 4.806, 2544.314},
+		{205, 1.869, 5573.143}, {202, 2.458, 6069.777}, {156, 0.833, 213.299},
+		{132, 3.411, 2942.463}, {126, 1.083, 20.775}, {115, 0.645, 0.980},
+		{103, 0.636, 4694.003}, {102, 0.976, 15720.839}, {102, 4.267, 7.114},
+		{99, 6.21, 2146.17}, {98, 0.68, 155.42}, {86, 5.98, 161000.69},
+		{85, 1.30, 6275.96}, {85, 3.67, 71430.70}, {80, 1.81, 17260.15},
+		{79, 3.04, 12036.46}, {75, 1.76, 5088.63}, {74, 3.50, 3154.69},
+		{74, 4.68, 801.82}, {70, 0.83, 9437.76}, {62, 3.98, 8827.39},
+		{61, 1.82, 7084.90}, {57, 2.78, 6286.60}, {56, 4.39, 14143.50},
+		{56, 3.47, 6279.55}, {52, 0.19, 12139.55}, {52, 1.33, 1748.02},
+		{51, 0.28, 5856.48}, {49, 0.49, 1194.45}, {41, 5.37, 8429.24},
+		{41, 2.40, 19651.05}, {39, 6.17, 10447.39}, {37, 6.04, 10213.29},
+		{37, 2.57, 1059.38}, {36, 1.71, 2352.87}, {36, 1.78, 6812.77},
+		{33, 0.59, 17789.85}, {30, 0.44, 83996.85}, {30, 2.74, 1349.87},
+		{25, 3.16, 4690.48},
+	},
+	{
+		{628331966747, 0, 0}, {206059, 2.678235, 6283.075850}, {4303, 2.6351, 12566.1517},
+		{425, 1.590, 3.523}, {119, 5.796, 26.298}, {109, 2.966, 1577.344},
+		{93, 2.59, 18849.23}, {72, 1.14, 529.69}, {68, 1.87, 398.15},
+		{67, 4.41, 5507.55}, {59, 2.89, 5223.69}, {56, 2.17, 155.42},
+		{45, 0.40, 796.30}, {36, 0.47, 775.52}, {29, 2.65, 7.11},
+		{21, 5.34, 0.98}, {19, 1.85, 5486.78}, {19, 4.97, 213.30},
+		{17, 2.99, 6275.96}, {16, 0.03, 2544.31}, {16, 1.43, 2146.17},
+		{15, 1.21, 10977.08}, {12, 2.83, 1748.02}, {12, 3.26, 5088.63},
+		{12, 5.27, 1194.45}, {12, 2.08, 4694.00}, {11, 0.77, 553.57},
+		{10, 1.30, 6286.60}, {10, 4.24, 1349.87}, {9, 2.70, 242.73},
+		{9, 5.64, 951.72}, {8, 5.30, 2352.87}, {6, 2.65, 9437.76},
+		{6, 4.67, 4690.48},
+	},
+	{
+		{52919, 0, 0}, {8720, 1.0721, 6283.0758}, {309, 0.867, 12566.152},
+		{27, 0.05, 3.52}, {16, 5.19, 26.30}, {16, 3.68, 155.42},
+		{10, 0.76, 18849.23}, {9, 2.06, 77713.77}, {7, 0.83, 775.52},
+		{5, 4.66, 1577.34}, {4, 1.03, 7.11}, {4, 3.44, 5573.14},
+		{3, 5.14, 796.30}, {3, 6.05, 5507.55}, {3, 1.19, 242.73},
+		{3, 6.12, 529.69}, {3, 0.31, 398.15}, {3, 2.28, 553.57},
+		{2, 4.38, 5223.69}, {2, 3.75, 0.98},
+	},
+	{
+		{289, 5.844, 6283.076}, {35, 0, 0}, {17, 5.49, 12566.15},
+		{3, 5.20, 155.42}, {1, 4.72, 3.52}, {1, 5.30, 18849.23},
+		{1, 5.97, 242.73},
+	},
+	{
+		{114, 3.142, 0}, {8, 4.13, 6283.08}, {1, 3.84, 12566.15},
+	},
+	{
+		{1, 3.14, 0},
+	},
+}
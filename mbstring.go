@@ -0,0 +1,481 @@
+package php2go
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MbCaseUpper MB_CASE_UPPER
+	MbCaseUpper = iota
+	// MbCaseLower MB_CASE_LOWER
+	MbCaseLower
+	// MbCaseTitle MB_CASE_TITLE
+	MbCaseTitle
+	// MbCaseFold MB_CASE_FOLD
+	MbCaseFold
+	// MbCaseUpperSimple MB_CASE_UPPER_SIMPLE
+	MbCaseUpperSimple
+	// MbCaseLowerSimple MB_CASE_LOWER_SIMPLE
+	MbCaseLowerSimple
+	// MbCaseTitleSimple MB_CASE_TITLE_SIMPLE
+	MbCaseTitleSimple
+	// MbCaseFoldSimple MB_CASE_FOLD_SIMPLE
+	MbCaseFoldSimple
+)
+
+const (
+	// StrPadLeft STR_PAD_LEFT
+	StrPadLeft = iota
+	// StrPadRight STR_PAD_RIGHT
+	StrPadRight
+	// StrPadBoth STR_PAD_BOTH
+	StrPadBoth
+)
+
+// MbInternalEncoding mb_internal_encoding()
+// The encoding of the strings of the Mb functions, always UTF-8 like Go strings: it cannot be changed,
+// convert the strings of other encodings with MbConvertEncoding first.
+func MbInternalEncoding() string {
+	return "UTF-8"
+}
+
+// MbSubstr mb_substr()
+// start and length count characters, a negative start counts from the end, a negative length omits characters from the end.
+// Without length, until the end of str.
+func MbSubstr(str string, start int, length ...int) string {
+	offsets := mbOffsets(str)
+	n := len(offsets) - 1
+	if start < 0 {
+		if start += n; start < 0 {
+			start = 0
+		}
+	}
+	if start > n {
+		return ""
+	}
+	end := n
+	if len(length) > 0 {
+		if l := length[0]; l < 0 {
+			end = n + l
+		} else if start+l < n {
+			end = start + l
+		}
+	}
+	if end < start {
+		return ""
+	}
+	return str[offsets[start]:offsets[end]]
+}
+
+// MbStrpos mb_strpos()
+// The position in characters, -1 when needle is not found
+func MbStrpos(haystack, needle string, offset int) int {
+	return mbStrpos(haystack, needle, offset)
+}
+
+// MbStripos mb_stripos()
+func MbStripos(haystack, needle string, offset int) int {
+	return mbStrpos(mbConvertCase(haystack, MbCaseFoldSimple), mbConvertCase(needle, MbCaseFoldSimple), offset)
+}
+
+// MbStrrpos mb_strrpos()
+// The position in characters of the last occurrence, -1 when needle is not found
+func MbStrrpos(haystack, needle string, offset int) int {
+	return mbStrrpos(haystack, needle, offset)
+}
+
+// MbStrripos mb_strripos()
+func MbStrripos(haystack, needle string, offset int) int {
+	return mbStrrpos(mbConvertCase(haystack, MbCaseFoldSimple), mbConvertCase(needle, MbCaseFoldSimple), offset)
+}
+
+func mbStrpos(haystack, needle string, offset int) int {
+	offsets := mbOffsets(haystack)
+	n := len(offsets) - 1
+	if offset < 0 {
+		offset += n
+	}
+	if offset < 0 || offset > n {
+		return -1
+	}
+	pos := strings.Index(haystack[offsets[offset]:], needle)
+	if pos == -1 {
+		return -1
+	}
+	return offset + utf8.RuneCountInString(haystack[offsets[offset]:offsets[offset]+pos])
+}
+
+func mbStrrpos(haystack, needle string, offset int) int {
+	offsets := mbOffsets(haystack)
+	n := len(offsets) - 1
+	start, end := 0, len(haystack)
+	if offset >= 0 {
+		if offset > n {
+			return -1
+		}
+		start = offsets[offset]
+	} else {
+		if -offset > n {
+			return -1
+		}
+		// the match has to start at or before n+offset
+		if last := n + offset + utf8.RuneCountInString(needle); last < n {
+			end = offsets[last]
+		}
+	}
+	pos := strings.LastIndex(haystack[start:end], needle)
+	if pos == -1 {
+		return -1
+	}
+	return utf8.RuneCountInString(haystack[:start+pos])
+}
+
+// mbOffsets the byte offsets of the characters of str, followed by len(str)
+func mbOffsets(str string) []int {
+	offsets := make([]int, 0, len(str)+1)
+	for i := 0; i < len(str); {
+		offsets = append(offsets, i)
+		_, size := utf8.DecodeRuneInString(str[i:])
+		i += size
+	}
+	return append(offsets, len(str))
+}
+
+// MbStrtoupper mb_strtoupper()
+// Full case mapping: MbStrtoupper("straße") = "STRASSE"
+func MbStrtoupper(str string) string {
+	return mbConvertCase(str, MbCaseUpper)
+}
+
+// MbStrtolower mb_strtolower()
+func MbStrtolower(str string) string {
+	return mbConvertCase(str, MbCaseLower)
+}
+
+// MbConvertCase mb_convert_case()
+// mode is one of the MbCase* constants, MbConvertCase("hello world", MbCaseTitle) = "Hello World"
+func MbConvertCase(str string, mode int) (string, error) {
+	if mode < MbCaseUpper || mode > MbCaseFoldSimple {
+		return "", errors.New("mb_convert_case(): Argument #2 ($mode) must be one of the MB_CASE_* constants")
+	}
+	return mbConvertCase(str, mode), nil
+}
+
+// mbConvertCase php_unicode_convert_case(), invalid bytes become '?'
+func mbConvertCase(str string, mode int) string {
+	var b strings.Builder
+	b.Grow(len(str))
+	inWord := false
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteByte('?')
+			inWord = false
+			i++
+			continue
+		}
+		switch mode {
+		case MbCaseUpper:
+			b.WriteString(mbUpper(r))
+		case MbCaseUpperSimple:
+			b.WriteRune(unicode.ToUpper(r))
+		case MbCaseLower:
+			b.WriteString(mbLower(str, i, r))
+		case MbCaseLowerSimple:
+			b.WriteRune(unicode.ToLower(r))
+		case MbCaseFold:
+			for _, u := range mbUpper(r) {
+				b.WriteString(mbFold(u, true))
+			}
+		case MbCaseFoldSimple:
+			b.WriteString(mbFold(r, false))
+		default:
+			// title case the first cased letter of each word and lower case the others
+			switch {
+			case mbIsCased(r):
+				switch {
+				case inWord && mode == MbCaseTitle:
+					b.WriteString(mbLower(str, i, r))
+				case inWord:
+					b.WriteRune(unicode.ToLower(r))
+				case mode == MbCaseTitle && mbTitleFull[r] != "":
+					b.WriteString(mbTitleFull[r])
+				default:
+					b.WriteRune(unicode.ToTitle(r))
+				}
+				inWord = true
+			case mbIsCaseIgnorable(r):
+				b.WriteRune(r)
+			default:
+				b.WriteRune(r)
+				inWord = false
+			}
+		}
+		i += size
+	}
+	return b.String()
+}
+
+func mbUpper(r rune) string {
+	if s, ok := mbUpperFull[r]; ok {
+		return s
+	}
+	return string(unicode.ToUpper(r))
+}
+
+// mbLower the full lower case of r at str[i:], with the final sigma rule
+func mbLower(str string, i int, r rune) string {
+	switch r {
+	case 0x130:
+		return "i̇"
+	case 'Σ':
+		if mbIsFinalSigma(str, i) {
+			return "ς"
+		}
+	}
+	return string(unicode.ToLower(r))
+}
+
+// mbIsFinalSigma whether the Σ at str[i:] ends a word: it follows a cased letter and no cased letter follows it,
+// ignoring the case-ignorable characters in between.
+func mbIsFinalSigma(str string, i int) bool {
+	before := false
+	for j := i; j > 0; {
+		r, size := utf8.DecodeLastRuneInString(str[:j])
+		if !mbIsCaseIgnorable(r) {
+			before = mbIsCased(r)
+			break
+		}
+		j -= size
+	}
+	if !before {
+		return false
+	}
+	for j := i + len("Σ"); j < len(str); {
+		r, size := utf8.DecodeRuneInString(str[j:])
+		if !mbIsCaseIgnorable(r) {
+			return !mbIsCased(r)
+		}
+		j += size
+	}
+	return true
+}
+
+// mbFold the case folding of r, full or simple
+func mbFold(r rune, full bool) string {
+	switch {
+	case r == 0x130 && full:
+		return "i̇"
+	case r == 0x1E9E && full:
+		return "ss"
+	case r == 0x130 || r == 0x131:
+		return string(r)
+	}
+	u := unicode.ToUpper(r)
+	if 0x13A0 <= u && u <= 0x13F5 {
+		// Cherokee folds to upper case
+		return string(u)
+	}
+	return string(unicode.ToLower(u))
+}
+
+// mbIsCased the Unicode Cased property
+func mbIsCased(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsLower(r) || unicode.IsTitle(r) ||
+		unicode.In(r, unicode.Other_Lowercase, unicode.Other_Uppercase)
+}
+
+// mbIsCaseIgnorable the Unicode Case_Ignorable property
+func mbIsCaseIgnorable(r rune) bool {
+	switch r {
+	case '\'', '.', ':', 0xB7, 0x387, 0x55F, 0x5F4, 0x2018, 0x2019, 0x2024, 0x2027, 0xFE13, 0xFE52, 0xFE55, 0xFF07, 0xFF0E, 0xFF1A:
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Lm, unicode.Sk)
+}
+
+// MbStrSplit mb_str_split()
+// Splits str into chunks of length characters
+func MbStrSplit(str string, length int) ([]string, error) {
+	if length < 1 {
+		return nil, errors.New("mb_str_split(): Argument #2 ($length) must be greater than 0")
+	}
+	offsets := mbOffsets(str)
+	chunks := make([]string, 0, (len(offsets)+length-2)/length)
+	for i := 0; i < len(offsets)-1; i += length {
+		end := i + length
+		if end > len(offsets)-1 {
+			end = len(offsets) - 1
+		}
+		chunks = append(chunks, str[offsets[i]:offsets[end]])
+	}
+	return chunks, nil
+}
+
+// MbSubstrCount mb_substr_count()
+func MbSubstrCount(haystack, needle string) (int, error) {
+	if needle == "" {
+		return 0, errors.New("mb_substr_count(): Argument #2 ($needle) must not be empty")
+	}
+	return strings.Count(haystack, needle), nil
+}
+
+// MbStrwidth mb_strwidth()
+// East Asian wide and fullwidth characters count 2, the others 1: MbStrwidth("中文abc") = 7
+func MbStrwidth(str string) int {
+	width := 0
+	for _, r := range str {
+		width += mbRuneWidth(r)
+	}
+	return width
+}
+
+func mbRuneWidth(r rune) int {
+	lo, hi := 0, len(mbWideTable)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < mbWideTable[mid][0]:
+			hi = mid
+		case r > mbWideTable[mid][1]:
+			lo = mid + 1
+		default:
+			return 2
+		}
+	}
+	return 1
+}
+
+// MbStrimwidth mb_strimwidth()
+// Truncates str from the character start to width, ending with trimMarker when it was truncated.
+// MbStrimwidth("Hello World", 0, 10, "...") = "Hello W..."
+func MbStrimwidth(str string, start, width int, trimMarker string) (string, error) {
+	offsets := mbOffsets(str)
+	n := len(offsets) - 1
+	if start < 0 {
+		start += n
+	}
+	if start < 0 || start > n {
+		return "", errors.New("mb_strimwidth(): Argument #2 ($start) is out of range")
+	}
+	if width < 0 {
+		width += MbStrwidth(str) - start
+	}
+	if width < 0 {
+		return "", errors.New("mb_strimwidth(): Argument #3 ($width) is out of range")
+	}
+	str = str[offsets[start]:]
+	if MbStrwidth(str) <= width {
+		return str, nil
+	}
+	width -= MbStrwidth(trimMarker)
+	end := 0
+	for i, r := range str {
+		if width -= mbRuneWidth(r); width < 0 {
+			end = i
+			break
+		}
+	}
+	return str[:end] + trimMarker, nil
+}
+
+// MbStrPad mb_str_pad()
+// length counts characters, padType is StrPadRight, StrPadLeft or StrPadBoth
+func MbStrPad(str string, length int, padStr string, padType int) (string, error) {
+	if padStr == "" {
+		return "", errors.New("mb_str_pad(): Argument #3 ($pad_string) must be a non-empty string")
+	}
+	if padType < StrPadLeft || padType > StrPadBoth {
+		return "", errors.New("mb_str_pad(): Argument #4 ($pad_type) must be STR_PAD_LEFT, STR_PAD_RIGHT, or STR_PAD_BOTH")
+	}
+	num := length - utf8.RuneCountInString(str)
+	if num <= 0 {
+		return str, nil
+	}
+	left, right := 0, num
+	switch padType {
+	case StrPadLeft:
+		left, right = num, 0
+	case StrPadBoth:
+		left, right = num/2, num-num/2
+	}
+	pad := func(n int) string {
+		s := strings.Repeat(padStr, n/utf8.RuneCountInString(padStr)+1)
+		return s[:mbOffsets(s)[n]]
+	}
+	return pad(left) + str + pad(right), nil
+}
+
+// mbUpperFull the unconditional upper case mappings of SpecialCasing.txt longer than one character
+var mbUpperFull = map[rune]string{
+	0x00DF: "SS", 0x0149: "\u02BCN", 0x01F0: "J\u030C", 0x0390: "\u0399\u0308\u0301",
+	0x03B0: "\u03A5\u0308\u0301", 0x0587: "\u0535\u0552", 0x1E96: "H\u0331", 0x1E97: "T\u0308",
+	0x1E98: "W\u030A", 0x1E99: "Y\u030A", 0x1E9A: "A\u02BE", 0x1F50: "\u03A5\u0313",
+	0x1F52: "\u03A5\u0313\u0300", 0x1F54: "\u03A5\u0313\u0301", 0x1F56: "\u03A5\u0313\u0342", 0x1F80: "\u1F08\u0399",
+	0x1F81: "\u1F09\u0399", 0x1F82: "\u1F0A\u0399", 0x1F83: "\u1F0B\u0399", 0x1F84: "\u1F0C\u0399",
+	0x1F85: "\u1F0D\u0399", 0x1F86: "\u1F0E\u0399", 0x1F87: "\u1F0F\u0399", 0x1F88: "\u1F08\u0399",
+	0x1F89: "\u1F09\u0399", 0x1F8A: "\u1F0A\u0399", 0x1F8B: "\u1F0B\u0399", 0x1F8C: "\u1F0C\u0399",
+	0x1F8D: "\u1F0D\u0399", 0x1F8E: "\u1F0E\u0399", 0x1F8F: "\u1F0F\u0399", 0x1F90: "\u1F28\u0399",
+	0x1F91: "\u1F29\u0399", 0x1F92: "\u1F2A\u0399", 0x1F93: "\u1F2B\u0399", 0x1F94: "\u1F2C\u0399",
+	0x1F95: "\u1F2D\u0399", 0x1F96: "\u1F2E\u0399", 0x1F97: "\u1F2F\u0399", 0x1F98: "\u1F28\u0399",
+	0x1F99: "\u1F29\u0399", 0x1F9A: "\u1F2A\u0399", 0x1F9B: "\u1F2B\u0399", 0x1F9C: "\u1F2C\u0399",
+	0x1F9D: "\u1F2D\u0399", 0x1F9E: "\u1F2E\u0399", 0x1F9F: "\u1F2F\u0399", 0x1FA0: "\u1F68\u0399",
+	0x1FA1: "\u1F69\u0399", 0x1FA2: "\u1F6A\u0399", 0x1FA3: "\u1F6B\u0399", 0x1FA4: "\u1F6C\u0399",
+	0x1FA5: "\u1F6D\u0399", 0x1FA6: "\u1F6E\u0399", 0x1FA7: "\u1F6F\u0399", 0x1FA8: "\u1F68\u0399",
+	0x1FA9: "\u1F69\u0399", 0x1FAA: "\u1F6A\u0399", 0x1FAB: "\u1F6B\u0399", 0x1FAC: "\u1F6C\u0399",
+	0x1FAD: "\u1F6D\u0399", 0x1FAE: "\u1F6E\u0399", 0x1FAF: "\u1F6F\u0399", 0x1FB2: "\u1FBA\u0399",
+	0x1FB3: "\u0391\u0399", 0x1FB4: "\u0386\u0399", 0x1FB6: "\u0391\u0342", 0x1FB7: "\u0391\u0342\u0399",
+	0x1FBC: "\u0391\u0399", 0x1FC2: "\u1FCA\u0399", 0x1FC3: "\u0397\u0399", 0x1FC4: "\u0389\u0399",
+	0x1FC6: "\u0397\u0342", 0x1FC7: "\u0397\u0342\u0399", 0x1FCC: "\u0397\u0399", 0x1FD2: "\u0399\u0308\u0300",
+	0x1FD3: "\u0399\u0308\u0301", 0x1FD6: "\u0399\u0342", 0x1FD7: "\u0399\u0308\u0342", 0x1FE2: "\u03A5\u0308\u0300",
+	0x1FE3: "\u03A5\u0308\u0301", 0x1FE4: "\u03A1\u0313", 0x1FE6: "\u03A5\u0342", 0x1FE7: "\u03A5\u0308\u0342",
+	0x1FF2: "\u1FFA\u0399", 0x1FF3: "\u03A9\u0399", 0x1FF4: "\u038F\u0399", 0x1FF6: "\u03A9\u0342",
+	0x1FF7: "\u03A9\u0342\u0399", 0x1FFC: "\u03A9\u0399", 0xFB00: "FF", 0xFB01: "FI",
+	0xFB02: "FL", 0xFB03: "FFI", 0xFB04: "FFL", 0xFB05: "ST",
+	0xFB06: "ST", 0xFB13: "\u0544\u0546", 0xFB14: "\u0544\u0535", 0xFB15: "\u0544\u053B",
+	0xFB16: "\u054E\u0546", 0xFB17: "\u0544\u053D",
+}
+
+// mbTitleFull the unconditional title case mappings of SpecialCasing.txt longer than one character
+var mbTitleFull = map[rune]string{
+	0x00DF: "Ss", 0x0149: "\u02BCN", 0x01F0: "J\u030C", 0x0390: "\u0399\u0308\u0301",
+	0x03B0: "\u03A5\u0308\u0301", 0x0587: "\u0535\u0582", 0x1E96: "H\u0331", 0x1E97: "T\u0308",
+	0x1E98: "W\u030A", 0x1E99: "Y\u030A", 0x1E9A: "A\u02BE", 0x1F50: "\u03A5\u0313",
+	0x1F52: "\u03A5\u0313\u0300", 0x1F54: "\u03A5\u0313\u0301", 0x1F56: "\u03A5\u0313\u0342", 0x1FB2: "\u1FBA\u0345",
+	0x1FB4: "\u0386\u0345", 0x1FB6: "\u0391\u0342", 0x1FB7: "\u0391\u0342\u0345", 0x1FC2: "\u1FCA\u0345",
+	0x1FC4: "\u0389\u0345", 0x1FC6: "\u0397\u0342", 0x1FC7: "\u0397\u0342\u0345", 0x1FD2: "\u0399\u0308\u0300",
+	0x1FD3: "\u0399\u0308\u0301", 0x1FD6: "\u0399\u0342", 0x1FD7: "\u0399\u0308\u0342", 0x1FE2: "\u03A5\u0308\u0300",
+	0x1FE3: "\u03A5\u0308\u0301", 0x1FE4: "\u03A1\u0313", 0x1FE6: "\u03A5\u0342", 0x1FE7: "\u03A5\u0308\u0342",
+	0x1FF2: "\u1FFA\u0345", 0x1FF4: "\u038F\u0345", 0x1FF6: "\u03A9\u0342", 0x1FF7: "\u03A9\u0342\u0345",
+	0xFB00: "Ff", 0xFB01: "Fi", 0xFB02: "Fl", 0xFB03: "Ffi",
+	0xFB04: "Ffl", 0xFB05: "St", 0xFB06: "St", 0xFB13: "\u0544\u0576",
+	0xFB14: "\u0544\u0565", 0xFB15: "\u0544\u056B", 0xFB16: "\u054E\u0576", 0xFB17: "\u0544\u056D",
+}
+
+// mbWideTable the East_Asian_Width W and F ranges of EastAsianWidth.txt, mb_strwidth() counts them as 2
+var mbWideTable = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0}, {0x23F3, 0x23F3},
+	{0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA},
+	{0x26F2, 0x26F3}, {0x26F5, 0x26F5}, {0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x2E99},
+	{0x2E9B, 0x2EF3}, {0x2F00, 0x2FD5}, {0x2FF0, 0x2FFB}, {0x3000, 0x303E}, {0x3041, 0x3096}, {0x3099, 0x30FF},
+	{0x3105, 0x312F}, {0x3131, 0x318E}, {0x3190, 0x31E3}, {0x31F0, 0x321E}, {0x3220, 0x3247}, {0x3250, 0x4DBF},
+	{0x4E00, 0xA48C}, {0xA490, 0xA4C6}, {0xA960, 0xA97C}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19},
+	{0xFE30, 0xFE52}, {0xFE54, 0xFE66}, {0xFE68, 0xFE6B}, {0xFF01, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x16FF0, 0x16FF1}, {0x17000, 0x187F7}, {0x18800, 0x18CD5}, {0x18D00, 0x18D08}, {0x1AFF0, 0x1AFF3}, {0x1AFF5, 0x1AFFB},
+	{0x1AFFD, 0x1AFFE}, {0x1B000, 0x1B122}, {0x1B132, 0x1B132}, {0x1B150, 0x1B152}, {0x1B155, 0x1B155}, {0x1B164, 0x1B167},
+	{0x1B170, 0x1B2FB}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F202},
+	{0x1F210, 0x1F23B}, {0x1F240, 0x1F248}, {0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F320}, {0x1F32D, 0x1F335},
+	{0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA}, {0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4},
+	{0x1F3F8, 0x1F43E}, {0x1F440, 0x1F440}, {0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E}, {0x1F550, 0x1F567},
+	{0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4}, {0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC},
+	{0x1F6D0, 0x1F6D2}, {0x1F6D5, 0x1F6D7}, {0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC}, {0x1F7E0, 0x1F7EB},
+	{0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF}, {0x1FA70, 0x1FA7C}, {0x1FA80, 0x1FA88},
+	{0x1FA90, 0x1FABD}, {0x1FABF, 0x1FAC5}, {0x1FACE, 0x1FADB}, {0x1FAE0, 0x1FAE8}, {0x1FAF0, 0x1FAF8}, {0x20000, 0x2FFFD},
+	{0x30000, 0x3FFFD},
+}
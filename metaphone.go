@@ -0,0 +1,814 @@
+package php2go
+
+import "strings"
+
+// metaphoneCodes the letter classes of metaphone.c
+// 1: vowel AEIOU; 2: passed through unchanged FJLMNR; 4: diphthong when followed by H CGPST;
+// 8: makes C and G soft EIY; 16: prevents GH from becoming F BDH
+var metaphoneCodes = [26]byte{
+	1, 16, 4, 16, 9, 2, 4, 16, 9, 2, 0, 2, 2, 2, 1, 4, 0, 2, 4, 4, 1, 0, 0, 0, 8, 0,
+	// a  b   c  d   e  f  g  h   i  j  k  l  m  n  o  p  q  r  s  t  u  v  w  x  y  z
+}
+
+func metaphoneIsAlpha(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z'
+}
+
+func metaphoneUpper(c byte) byte {
+	if 'a' <= c && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
+
+func metaphoneEncode(c byte) byte {
+	if !metaphoneIsAlpha(c) {
+		return 0
+	}
+	return metaphoneCodes[metaphoneUpper(c)-'A']
+}
+
+// metaphone metaphone() with the traditional rules, like PHP
+func metaphone(word string, maxPhonemes int) string {
+	// the C code stops at the first NUL byte
+	if i := strings.IndexByte(word, 0); i >= 0 {
+		word = word[:i]
+	}
+	at := func(i int) byte {
+		if i >= 0 && i < len(word) {
+			return metaphoneUpper(word[i])
+		}
+		return 0
+	}
+	isVowel := func(c byte) bool { return metaphoneEncode(c)&1 != 0 }
+	affectH := func(c byte) bool { return metaphoneEncode(c)&4 != 0 }
+	makeSoft := func(c byte) bool { return metaphoneEncode(c)&8 != 0 }
+	noGHToF := func(c byte) bool { return metaphoneEncode(c)&16 != 0 }
+
+	phoned := make([]byte, 0, len(word))
+	w := 0
+
+	// skip leading non-alpha
+	for ; !metaphoneIsAlpha(at(w)); w++ {
+		if at(w) == 0 {
+			return ""
+		}
+	}
+
+	// handle the prefixes
+	switch at(w) {
+	case 'A':
+		// AE becomes E
+		if at(w+1) == 'E' {
+			phoned = append(phoned, 'E')
+			w += 2
+		} else {
+			// remember, preserve vowels at the beginning
+			phoned = append(phoned, 'A')
+			w++
+		}
+	case 'G', 'K', 'P':
+		// [GKP]N becomes N
+		if at(w+1) == 'N' {
+			phoned = append(phoned, 'N')
+			w += 2
+		}
+	case 'W':
+		// WR becomes R, WH becomes W, W if followed by a vowel
+		if at(w+1) == 'R' {
+			phoned = append(phoned, 'R')
+			w += 2
+		} else if at(w+1) == 'H' || isVowel(at(w+1)) {
+			phoned = append(phoned, 'W')
+			w += 2
+		}
+	case 'X':
+		// X becomes S
+		phoned = append(phoned, 'S')
+		w++
+	case 'E', 'I', 'O', 'U':
+		// vowels are kept
+		phoned = append(phoned, at(w))
+		w++
+	}
+
+	for ; at(w) != 0 && (maxPhonemes == 0 || len(phoned) < maxPhonemes); w++ {
+		// how many letters to skip because an earlier encoding handled multiple letters
+		skip := 0
+		curr, next := at(w), at(w+1)
+		prev := at(w - 1)
+		afterNext := byte(0)
+		if next != 0 {
+			afterNext = at(w + 2)
+		}
+
+		// ignore non-alphas, drop duplicates except CC
+		if !metaphoneIsAlpha(curr) || curr == prev && curr != 'C' {
+			continue
+		}
+
+		switch curr {
+		case 'B':
+			// B unless in MB
+			if prev != 'M' {
+				phoned = append(phoned, 'B')
+			}
+		case 'C':
+			// X if -CIA- or -CH, S if -CI-, -CE- or -CY-, dropped if -SCI-, -SCE-, -SCY-, else K
+			if makeSoft(next) {
+				if afterNext == 'A' && next == 'I' {
+					phoned = append(phoned, 'X')
+				} else if prev != 'S' {
+					phoned = append(phoned, 'S')
+				}
+			} else if next == 'H' {
+				phoned = append(phoned, 'X')
+				skip++
+			} else {
+				phoned = append(phoned, 'K')
+			}
+		case 'D':
+			// J if in -DGE-, -DGI- or -DGY-, else T
+			if next == 'G' && makeSoft(afterNext) {
+				phoned = append(phoned, 'J')
+				skip++
+			} else {
+				phoned = append(phoned, 'T')
+			}
+		case 'G':
+			// F if in -GH and not B--GH, D--GH, -H--GH, -H---GH, else dropped if -GNED, -GN,
+			// else J if in -GE-, -GI, -GY and not GG, else K
+			if next == 'H' {
+				if !(noGHToF(at(w-3)) || at(w-4) == 'H') {
+					phoned = append(phoned, 'F')
+					skip++
+				}
+			} else if next == 'N' {
+				if !metaphoneIsAlpha(afterNext) || afterNext == 'E' && metaphoneLookahead(word, w, 3) == 'D' {
+					// dropped
+				} else {
+					phoned = append(phoned, 'K')
+				}
+			} else if makeSoft(next) && prev != 'G' {
+				phoned = append(phoned, 'J')
+			} else {
+				phoned = append(phoned, 'K')
+			}
+		case 'H':
+			// H if before a vowel and not after C, G, P, S, T
+			if isVowel(next) && !affectH(prev) {
+				phoned = append(phoned, 'H')
+			}
+		case 'K':
+			// dropped if after C, else K
+			if prev != 'C' {
+				phoned = append(phoned, 'K')
+			}
+		case 'P':
+			// F if before H, else P
+			if next == 'H' {
+				phoned = append(phoned, 'F')
+			} else {
+				phoned = append(phoned, 'P')
+			}
+		case 'Q':
+			phoned = append(phoned, 'K')
+		case 'S':
+			// X in -SH-, -SIO- or -SIA-, else S
+			if next == 'I' && (afterNext == 'O' || afterNext == 'A') {
+				phoned = append(phoned, 'X')
+			} else if next == 'H' {
+				phoned = append(phoned, 'X')
+				skip++
+			} else {
+				phoned = append(phoned, 'S')
+			}
+		case 'T':
+			// X in -TIA- or -TIO-, else 0 (th) before H, else T, silent in -TCH-
+			if next == 'I' && (afterNext == 'O' || afterNext == 'A') {
+				phoned = append(phoned, 'X')
+			} else if next == 'H' {
+				phoned = append(phoned, '0')
+				skip++
+			} else if !(next == 'C' && afterNext == 'H') {
+				phoned = append(phoned, 'T')
+			}
+		case 'V':
+			phoned = append(phoned, 'F')
+		case 'W', 'Y':
+			// W or Y before a vowel, else dropped
+			if isVowel(next) {
+				phoned = append(phoned, curr)
+			}
+		case 'X':
+			phoned = append(phoned, 'K', 'S')
+		case 'Z':
+			phoned = append(phoned, 'S')
+		case 'F', 'J', 'L', 'M', 'N', 'R':
+			phoned = append(phoned, curr)
+		}
+		w += skip
+	}
+	return string(phoned)
+}
+
+// metaphoneLookahead Lookahead(), the letter howFar after word[w], or NUL when the word ends before
+func metaphoneLookahead(word string, w, howFar int) byte {
+	if w+howFar < len(word) {
+		return metaphoneUpper(word[w+howFar])
+	}
+	return 0
+}
+
+// doubleMetaphone Lawrence Philips' Double Metaphone, ported from the reference C implementation
+type doubleMetaphone struct {
+	str                []rune
+	length, last       int
+	slavoGermanic      bool
+	primary, secondary []rune
+}
+
+func newDoubleMetaphone(str string) *doubleMetaphone {
+	upper := strings.ToUpper(str)
+	dm := &doubleMetaphone{str: []rune(upper)}
+	dm.length = len(dm.str)
+	dm.last = dm.length - 1
+	dm.slavoGermanic = strings.Contains(upper, "W") || strings.Contains(upper, "K") ||
+		strings.Contains(upper, "CZ") || strings.Contains(upper, "WITZ")
+	// pad so we can index beyond the end
+	dm.str = append(dm.str, []rune("     ")...)
+	return dm
+}
+
+func (dm *doubleMetaphone) add(primary, secondary string) {
+	dm.primary = append(dm.primary, []rune(primary)...)
+	dm.secondary = append(dm.secondary, []rune(secondary)...)
+}
+
+func (dm *doubleMetaphone) at(pos int) rune {
+	if pos < 0 || pos >= len(dm.str) {
+		return 0
+	}
+	return dm.str[pos]
+}
+
+// stringAt reports whether one of options, all of length runes, is at start
+func (dm *doubleMetaphone) stringAt(start, length int, options ...string) bool {
+	if start < 0 || start >= len(dm.str) || start+length > len(dm.str) {
+		return false
+	}
+	s := string(dm.str[start : start+length])
+	for _, option := range options {
+		if s == option {
+			return true
+		}
+	}
+	return false
+}
+
+func (dm *doubleMetaphone) isVowel(pos int) bool {
+	switch dm.at(pos) {
+	case 'A', 'E', 'I', 'O', 'U', 'Y':
+		return true
+	}
+	return false
+}
+
+func (dm *doubleMetaphone) encode() (string, string) {
+	current := 0
+	if dm.length < 1 {
+		return "", ""
+	}
+
+	// skip these when at start of word
+	if dm.stringAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		current++
+	}
+	// initial 'X' is pronounced 'Z' e.g. 'Xavier'
+	if dm.at(0) == 'X' {
+		dm.add("S", "S")
+		current++
+	}
+
+	for (len(dm.primary) < 4 || len(dm.secondary) < 4) && current < dm.length {
+		switch dm.at(current) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			// all initial vowels map to 'A'
+			if current == 0 {
+				dm.add("A", "A")
+			}
+			current++
+		case 'B':
+			// "-mb", e.g. "dumb", already skipped over
+			dm.add("P", "P")
+			if dm.at(current+1) == 'B' {
+				current += 2
+			} else {
+				current++
+			}
+		case 'Ç':
+			dm.add("S", "S")
+			current++
+		case 'C':
+			current = dm.encodeC(current)
+		case 'D':
+			if dm.stringAt(current, 2, "DG") {
+				if dm.stringAt(current+2, 1, "I", "E", "Y") {
+					// e.g. 'edge'
+					dm.add("J", "J")
+					current += 3
+				} else {
+					// e.g. 'edgar'
+					dm.add("TK", "TK")
+					current += 2
+				}
+				break
+			}
+			dm.add("T", "T")
+			if dm.stringAt(current, 2, "DT", "DD") {
+				current += 2
+			} else {
+				current++
+			}
+		case 'F':
+			if dm.at(current+1) == 'F' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("F", "F")
+		case 'G':
+			current = dm.encodeG(current)
+		case 'H':
+			// only keep if first & before vowel or between 2 vowels, also takes care of 'HH'
+			if (current == 0 || dm.isVowel(current-1)) && dm.isVowel(current+1) {
+				dm.add("H", "H")
+				current += 2
+			} else {
+				current++
+			}
+		case 'J':
+			current = dm.encodeJ(current)
+		case 'K':
+			if dm.at(current+1) == 'K' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("K", "K")
+		case 'L':
+			if dm.at(current+1) == 'L' {
+				// spanish e.g. 'cabrillo', 'gallegos'
+				if current == dm.length-3 && dm.stringAt(current-1, 4, "ILLO", "ILLA", "ALLE") ||
+					(dm.stringAt(dm.last-1, 2, "AS", "OS") || dm.stringAt(dm.last, 1, "A", "O")) &&
+						dm.stringAt(current-1, 4, "ALLE") {
+					dm.add("L", "")
+					current += 2
+					break
+				}
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("L", "L")
+		case 'M':
+			// 'dumb', 'thumb'
+			if dm.stringAt(current-1, 3, "UMB") && (current+1 == dm.last || dm.stringAt(current+2, 2, "ER")) ||
+				dm.at(current+1) == 'M' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("M", "M")
+		case 'N':
+			if dm.at(current+1) == 'N' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("N", "N")
+		case 'Ñ':
+			current++
+			dm.add("N", "N")
+		case 'P':
+			if dm.at(current+1) == 'H' {
+				dm.add("F", "F")
+				current += 2
+				break
+			}
+			// also account for "campbell", "raspberry"
+			if dm.stringAt(current+1, 1, "P", "B") {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("P", "P")
+		case 'Q':
+			if dm.at(current+1) == 'Q' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("K", "K")
+		case 'R':
+			// french e.g. 'rogier', but exclude 'hochmeier'
+			if current == dm.last && !dm.slavoGermanic && dm.stringAt(current-2, 2, "IE") &&
+				!dm.stringAt(current-4, 2, "ME", "MA") {
+				dm.add("", "R")
+			} else {
+				dm.add("R", "R")
+			}
+			if dm.at(current+1) == 'R' {
+				current += 2
+			} else {
+				current++
+			}
+		case 'S':
+			current = dm.encodeS(current)
+		case 'T':
+			current = dm.encodeT(current)
+		case 'V':
+			if dm.at(current+1) == 'V' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("F", "F")
+		case 'W':
+			current = dm.encodeW(current)
+		case 'X':
+			// french e.g. breaux
+			if !(current == dm.last &&
+				(dm.stringAt(current-3, 3, "IAU", "EAU") || dm.stringAt(current-2, 2, "AU", "OU"))) {
+				dm.add("KS", "KS")
+			}
+			if dm.stringAt(current+1, 1, "C", "X") {
+				current += 2
+			} else {
+				current++
+			}
+		case 'Z':
+			// chinese pinyin e.g. 'zhao'
+			if dm.at(current+1) == 'H' {
+				dm.add("J", "J")
+				current += 2
+				break
+			} else if dm.stringAt(current+1, 2, "ZO", "ZI", "ZA") ||
+				dm.slavoGermanic && current > 0 && dm.at(current-1) != 'T' {
+				dm.add("S", "TS")
+			} else {
+				dm.add("S", "S")
+			}
+			if dm.at(current+1) == 'Z' {
+				current += 2
+			} else {
+				current++
+			}
+		default:
+			current++
+		}
+	}
+
+	if len(dm.primary) > 4 {
+		dm.primary = dm.primary[:4]
+	}
+	if len(dm.secondary) > 4 {
+		dm.secondary = dm.secondary[:4]
+	}
+	return string(dm.primary), string(dm.secondary)
+}
+
+func (dm *doubleMetaphone) encodeC(current int) int {
+	// various germanic
+	if current > 1 && !dm.isVowel(current-2) && dm.stringAt(current-1, 3, "ACH") &&
+		dm.at(current+2) != 'I' && (dm.at(current+2) != 'E' || dm.stringAt(current-2, 6, "BACHER", "MACHER")) {
+		dm.add("K", "K")
+		return current + 2
+	}
+	// special case 'caesar'
+	if current == 0 && dm.stringAt(current, 6, "CAESAR") {
+		dm.add("S", "S")
+		return current + 2
+	}
+	// italian 'chianti'
+	if dm.stringAt(current, 4, "CHIA") {
+		dm.add("K", "K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CH") {
+		// find 'michael'
+		if current > 0 && dm.stringAt(current, 4, "CHAE") {
+			dm.add("K", "X")
+			return current + 2
+		}
+		// greek roots e.g. 'chemistry', 'chorus'
+		if current == 0 &&
+			(dm.stringAt(current+1, 5, "HARAC", "HARIS") || dm.stringAt(current+1, 3, "HOR", "HYM", "HIA", "HEM")) &&
+			!dm.stringAt(0, 5, "CHORE") {
+			dm.add("K", "K")
+			return current + 2
+		}
+		// germanic, greek, or otherwise 'ch' for 'kh' sound
+		if dm.stringAt(0, 4, "VAN ", "VON ") || dm.stringAt(0, 3, "SCH") ||
+			// 'architect but not 'arch', 'orchestra', 'orchid'
+			dm.stringAt(current-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+			dm.stringAt(current+2, 1, "T", "S") ||
+			(dm.stringAt(current-1, 1, "A", "O", "U", "E") || current == 0) &&
+				// e.g., 'wachtler', 'wechsler', but not 'tichner'
+				dm.stringAt(current+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ") {
+			dm.add("K", "K")
+		} else if current > 0 {
+			if dm.stringAt(0, 2, "MC") {
+				// e.g., "McHugh"
+				dm.add("K", "K")
+			} else {
+				dm.add("X", "K")
+			}
+		} else {
+			dm.add("X", "X")
+		}
+		return current + 2
+	}
+	// e.g, 'czerny'
+	if dm.stringAt(current, 2, "CZ") && !dm.stringAt(current-2, 4, "WICZ") {
+		dm.add("S", "X")
+		return current + 2
+	}
+	// e.g., 'focaccia'
+	if dm.stringAt(current+1, 3, "CIA") {
+		dm.add("X", "X")
+		return current + 3
+	}
+	// double 'C', but not if e.g. 'McClellan'
+	if dm.stringAt(current, 2, "CC") && !(current == 1 && dm.at(0) == 'M') {
+		// 'bellocchio' but not 'bacchus'
+		if dm.stringAt(current+2, 1, "I", "E", "H") && !dm.stringAt(current+2, 2, "HU") {
+			// 'accident', 'accede' 'succeed'
+			if current == 1 && dm.at(current-1) == 'A' || dm.stringAt(current-1, 5, "UCCEE", "UCCES") {
+				dm.add("KS", "KS")
+			} else {
+				// 'bacci', 'bertucci', other italian
+				dm.add("X", "X")
+			}
+			return current + 3
+		}
+		// Pierce's rule
+		dm.add("K", "K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CK", "CG", "CQ") {
+		dm.add("K", "K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CI", "CE", "CY") {
+		// italian vs. english
+		if dm.stringAt(current, 3, "CIO", "CIE", "CIA") {
+			dm.add("S", "X")
+		} else {
+			dm.add("S", "S")
+		}
+		return current + 2
+	}
+	dm.add("K", "K")
+	// name sent in 'mac caffrey', 'mac gregor'
+	if dm.stringAt(current+1, 2, " C", " Q", " G") {
+		return current + 3
+	}
+	if dm.stringAt(current+1, 1, "C", "K", "Q") && !dm.stringAt(current+1, 2, "CE", "CI") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeG(current int) int {
+	if dm.at(current+1) == 'H' {
+		if current > 0 && !dm.isVowel(current-1) {
+			dm.add("K", "K")
+			return current + 2
+		}
+		// 'ghislane', 'ghiradelli'
+		if current == 0 {
+			if dm.at(current+2) == 'I' {
+				dm.add("J", "J")
+			} else {
+				dm.add("K", "K")
+			}
+			return current + 2
+		}
+		// Parker's rule (with some further refinements) - e.g., 'hugh'
+		if current > 1 && dm.stringAt(current-2, 1, "B", "H", "D") ||
+			// e.g., 'bough'
+			current > 2 && dm.stringAt(current-3, 1, "B", "H", "D") ||
+			// e.g., 'broughton'
+			current > 3 && dm.stringAt(current-4, 1, "B", "H") {
+			return current + 2
+		}
+		// e.g., 'laugh', 'McLaughlin', 'cough', 'gough', 'rough', 'tough'
+		if current > 2 && dm.at(current-1) == 'U' && dm.stringAt(current-3, 1, "C", "G", "L", "R", "T") {
+			dm.add("F", "F")
+		} else if current > 0 && dm.at(current-1) != 'I' {
+			dm.add("K", "K")
+		}
+		return current + 2
+	}
+	if dm.at(current+1) == 'N' {
+		if current == 1 && dm.isVowel(0) && !dm.slavoGermanic {
+			dm.add("KN", "N")
+		} else if !dm.stringAt(current+2, 2, "EY") && dm.at(current+1) != 'Y' && !dm.slavoGermanic {
+			// not e.g. 'cagney'
+			dm.add("N", "KN")
+		} else {
+			dm.add("KN", "KN")
+		}
+		return current + 2
+	}
+	// 'tagliaro'
+	if dm.stringAt(current+1, 2, "LI") && !dm.slavoGermanic {
+		dm.add("KL", "L")
+		return current + 2
+	}
+	// -ges-, -gep-, -gel-, -gie- at beginning
+	if current == 0 && (dm.at(current+1) == 'Y' ||
+		dm.stringAt(current+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
+		dm.add("K", "J")
+		return current + 2
+	}
+	// -ger-, -gy-
+	if (dm.stringAt(current+1, 2, "ER") || dm.at(current+1) == 'Y') &&
+		!dm.stringAt(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!dm.stringAt(current-1, 1, "E", "I") && !dm.stringAt(current-1, 3, "RGY", "OGY") {
+		dm.add("K", "J")
+		return current + 2
+	}
+	// italian e.g, 'biaggi'
+	if dm.stringAt(current+1, 1, "E", "I", "Y") || dm.stringAt(current-1, 4, "AGGI", "OGGI") {
+		if dm.stringAt(0, 4, "VAN ", "VON ") || dm.stringAt(0, 3, "SCH") || dm.stringAt(current+1, 2, "ET") {
+			// obvious germanic
+			dm.add("K", "K")
+		} else if dm.stringAt(current+1, 4, "IER ") {
+			// always soft if french ending
+			dm.add("J", "J")
+		} else {
+			dm.add("J", "K")
+		}
+		return current + 2
+	}
+	dm.add("K", "K")
+	if dm.at(current+1) == 'G' {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeJ(current int) int {
+	// obvious spanish, 'jose', 'san jacinto'
+	if dm.stringAt(current, 4, "JOSE") || dm.stringAt(0, 4, "SAN ") {
+		if current == 0 && dm.at(current+4) == ' ' || dm.stringAt(0, 4, "SAN ") {
+			dm.add("H", "H")
+		} else {
+			dm.add("J", "H")
+		}
+		return current + 1
+	}
+	if current == 0 && !dm.stringAt(current, 4, "JOSE") {
+		// Yankelovich/Jankelowicz
+		dm.add("J", "A")
+	} else if dm.isVowel(current-1) && !dm.slavoGermanic && (dm.at(current+1) == 'A' || dm.at(current+1) == 'O') {
+		// spanish pron. of e.g. 'bajador'
+		dm.add("J", "H")
+	} else if current == dm.last {
+		dm.add("J", "")
+	} else if !dm.stringAt(current+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") &&
+		!dm.stringAt(current-1, 1, "S", "K", "L") {
+		dm.add("J", "J")
+	}
+	// it could happen!
+	if dm.at(current+1) == 'J' {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeS(current int) int {
+	// special cases 'island', 'isle', 'carlisle', 'carlysle'
+	if dm.stringAt(current-1, 3, "ISL", "YSL") {
+		return current + 1
+	}
+	// special case 'sugar-'
+	if current == 0 && dm.stringAt(current, 5, "SUGAR") {
+		dm.add("X", "S")
+		return current + 1
+	}
+	if dm.stringAt(current, 2, "SH") {
+		// germanic
+		if dm.stringAt(current+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			dm.add("S", "S")
+		} else {
+			dm.add("X", "X")
+		}
+		return current + 2
+	}
+	// italian & armenian
+	if dm.stringAt(current, 3, "SIO", "SIA") || dm.stringAt(current, 4, "SIAN") {
+		if !dm.slavoGermanic {
+			dm.add("S", "X")
+		} else {
+			dm.add("S", "S")
+		}
+		return current + 3
+	}
+	// german & anglicisations, e.g. 'smith' match 'schmidt', 'snider' match 'schneider',
+	// also, -sz- in slavic language although in hungarian it is pronounced 's'
+	if current == 0 && dm.stringAt(current+1, 1, "M", "N", "L", "W") || dm.stringAt(current+1, 1, "Z") {
+		dm.add("S", "X")
+		if dm.stringAt(current+1, 1, "Z") {
+			return current + 2
+		}
+		return current + 1
+	}
+	if dm.stringAt(current, 2, "SC") {
+		// Schlesinger's rule
+		if dm.at(current+2) == 'H' {
+			// dutch origin, e.g. 'school', 'schooner'
+			if dm.stringAt(current+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+				// 'schermerhorn', 'schenker'
+				if dm.stringAt(current+3, 2, "ER", "EN") {
+					dm.add("X", "SK")
+				} else {
+					dm.add("SK", "SK")
+				}
+			} else if current == 0 && !dm.isVowel(3) && dm.at(3) != 'W' {
+				dm.add("X", "S")
+			} else {
+				dm.add("X", "X")
+			}
+			return current + 3
+		}
+		if dm.stringAt(current+2, 1, "I", "E", "Y") {
+			dm.add("S", "S")
+		} else {
+			dm.add("SK", "SK")
+		}
+		return current + 3
+	}
+	// french e.g. 'resnais', 'artois'
+	if current == dm.last && dm.stringAt(current-2, 2, "AI", "OI") {
+		dm.add("", "S")
+	} else {
+		dm.add("S", "S")
+	}
+	if dm.stringAt(current+1, 1, "S", "Z") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeT(current int) int {
+	if dm.stringAt(current, 4, "TION") || dm.stringAt(current, 3, "TIA", "TCH") {
+		dm.add("X", "X")
+		return current + 3
+	}
+	if dm.stringAt(current, 2, "TH") || dm.stringAt(current, 3, "TTH") {
+		// special case 'thomas', 'thames' or germanic
+		if dm.stringAt(current+2, 2, "OM", "AM") || dm.stringAt(0, 4, "VAN ", "VON ") || dm.stringAt(0, 3, "SCH") {
+			dm.add("T", "T")
+		} else {
+			dm.add("0", "T")
+		}
+		return current + 2
+	}
+	dm.add("T", "T")
+	if dm.stringAt(current+1, 1, "T", "D") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeW(current int) int {
+	// can also be in middle of word
+	if dm.stringAt(current, 2, "WR") {
+		dm.add("R", "R")
+		return current + 2
+	}
+	if current == 0 && (dm.isVowel(current+1) || dm.stringAt(current, 2, "WH")) {
+		if dm.isVowel(current + 1) {
+			// Wasserman should match Vasserman
+			dm.add("A", "F")
+		} else {
+			// need Uomo to match Womo
+			dm.add("A", "A")
+		}
+	}
+	// Arnow should match Arnoff
+	if current == dm.last && dm.isVowel(current-1) ||
+		dm.stringAt(current-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || dm.stringAt(0, 3, "SCH") {
+		dm.add("", "F")
+		return current + 1
+	}
+	// polish e.g. 'filipowicz'
+	if dm.stringAt(current, 4, "WICZ", "WITZ") {
+		dm.add("TS", "FX")
+		return current + 4
+	}
+	return current + 1
+}
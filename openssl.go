@@ -0,0 +1,299 @@
+package php2go
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// OpensslRawData OPENSSL_RAW_DATA, the data isn't base64 encoded
+	OpensslRawData = 1
+	// OpensslZeroPadding OPENSSL_ZERO_PADDING, no PKCS#7 padding, the data must be a multiple of the block size
+	OpensslZeroPadding = 2
+	// OpensslDontZeroPadKey OPENSSL_DONT_ZERO_PAD_KEY, a short key is an error rather than padded with NUL bytes
+	OpensslDontZeroPadKey = 4
+)
+
+type opensslCipher struct {
+	keyLen   int
+	ivLen    int
+	mode     string
+	newBlock func(key []byte) (cipher.Block, error)
+}
+
+// opensslCiphers the supported cipher methods by their OpenSSL name
+var opensslCiphers = map[string]opensslCipher{
+	"des-ede3":     {24, 0, "ecb", des.NewTripleDESCipher},
+	"des-ede3-cbc": {24, 8, "cbc", des.NewTripleDESCipher},
+	"des-ede3-cfb": {24, 8, "cfb", des.NewTripleDESCipher},
+	"des-ede3-ofb": {24, 8, "ofb", des.NewTripleDESCipher},
+}
+
+// opensslCipherAliases the aliases listed by openssl_get_cipher_methods(true)
+var opensslCipherAliases = map[string]string{
+	"aes128": "aes-128-cbc",
+	"aes192": "aes-192-cbc",
+	"aes256": "aes-256-cbc",
+	"des3":   "des-ede3-cbc",
+}
+
+func init() {
+	for _, bits := range []int{128, 192, 256} {
+		for _, mode := range []string{"ecb", "cbc", "cfb", "cfb8", "ofb", "ctr", "gcm"} {
+			ivLen := aes.BlockSize
+			switch mode {
+			case "ecb":
+				ivLen = 0
+			case "gcm":
+				ivLen = 12
+			}
+			opensslCiphers[fmt.Sprintf("aes-%d-%s", bits, mode)] = opensslCipher{bits / 8, ivLen, mode, aes.NewCipher}
+		}
+	}
+}
+
+func findOpensslCipher(cipherAlgo string) (opensslCipher, bool) {
+	name := strings.ToLower(cipherAlgo)
+	if alias, ok := opensslCipherAliases[name]; ok {
+		name = alias
+	}
+	c, ok := opensslCiphers[name]
+	return c, ok
+}
+
+// OpensslEncrypt openssl_encrypt()
+// The key is truncated or padded with NUL bytes to the key length of the cipher, the iv to its iv length.
+// For gcm, tag receives the authentication tag of tagLength bytes, 16 by default, and aad is the additional authenticated data.
+// OpensslEncrypt(data, "aes-256-cbc", key, OpensslRawData, iv, nil, "")
+func OpensslEncrypt(data, cipherAlgo, passphrase string, options int, iv string, tag *string, aad string, tagLength ...int) (string, error) {
+	c, block, ivb, err := opensslCipherInit("openssl_encrypt", cipherAlgo, passphrase, options, iv)
+	if err != nil {
+		return "", err
+	}
+	src := []byte(data)
+	var out []byte
+	switch c.mode {
+	case "ecb", "cbc":
+		bs := block.BlockSize()
+		if options&OpensslZeroPadding == 0 {
+			n := bs - len(src)%bs
+			src = append(src, bytes.Repeat([]byte{byte(n)}, n)...)
+		} else if len(src)%bs != 0 {
+			return "", errors.New("openssl_encrypt(): data not multiple of block length")
+		}
+		out = make([]byte, len(src))
+		if c.mode == "cbc" {
+			cipher.NewCBCEncrypter(block, ivb).CryptBlocks(out, src)
+		} else {
+			for i := 0; i < len(src); i += bs {
+				block.Encrypt(out[i:i+bs], src[i:i+bs])
+			}
+		}
+	case "gcm":
+		size := 16
+		if len(tagLength) > 0 {
+			size = tagLength[0]
+		}
+		if tag == nil {
+			return "", errors.New("openssl_encrypt(): A tag should be provided when using AEAD mode")
+		}
+		if size <= 0 || size > 16 {
+			return "", errors.New("openssl_encrypt(): Retrieving verification tag failed")
+		}
+		aead, err := cipher.NewGCMWithNonceSize(block, len(ivb))
+		if err != nil {
+			return "", err
+		}
+		sealed := aead.Seal(nil, ivb, src, []byte(aad))
+		out = sealed[:len(src)]
+		*tag = string(sealed[len(src) : len(src)+size])
+	default:
+		out = make([]byte, len(src))
+		opensslStream(c.mode, block, ivb, false).XORKeyStream(out, src)
+	}
+	if tag != nil && c.mode != "gcm" {
+		*tag = ""
+	}
+	if options&OpensslRawData == 0 {
+		return Base64Encode(string(out)), nil
+	}
+	return string(out), nil
+}
+
+// OpensslDecrypt openssl_decrypt()
+// data is base64 decoded unless options has OpensslRawData, tag and aad are those of gcm
+// OpensslDecrypt(encrypted, "aes-256-cbc", key, OpensslRawData, iv, "", "")
+func OpensslDecrypt(data, cipherAlgo, passphrase string, options int, iv, tag, aad string) (string, error) {
+	c, block, ivb, err := opensslCipherInit("openssl_decrypt", cipherAlgo, passphrase, options, iv)
+	if err != nil {
+		return "", err
+	}
+	if options&OpensslRawData == 0 {
+		if data, err = Base64Decode(data); err != nil {
+			return "", errors.New("openssl_decrypt(): Failed to base64 decode the input")
+		}
+	}
+	src := []byte(data)
+	out := make([]byte, len(src))
+	switch c.mode {
+	case "ecb", "cbc":
+		bs := block.BlockSize()
+		if len(src)%bs != 0 || len(src) == 0 && options&OpensslZeroPadding == 0 {
+			return "", errors.New("openssl_decrypt(): wrong final block length")
+		}
+		if c.mode == "cbc" {
+			cipher.NewCBCDecrypter(block, ivb).CryptBlocks(out, src)
+		} else {
+			for i := 0; i < len(src); i += bs {
+				block.Decrypt(out[i:i+bs], src[i:i+bs])
+			}
+		}
+		if options&OpensslZeroPadding == 0 {
+			n := int(out[len(out)-1])
+			if n == 0 || n > bs {
+				return "", errors.New("openssl_decrypt(): bad decrypt")
+			}
+			for _, b := range out[len(out)-n:] {
+				if int(b) != n {
+					return "", errors.New("openssl_decrypt(): bad decrypt")
+				}
+			}
+			out = out[:len(out)-n]
+		}
+	case "gcm":
+		if tag == "" || len(tag) > 16 {
+			return "", errors.New("openssl_decrypt(): Setting tag for AEAD cipher decryption failed")
+		}
+		aead, err := cipher.NewGCMWithNonceSize(block, len(ivb))
+		if err != nil {
+			return "", err
+		}
+		// GCM encrypts with CTR, sealing the ciphertext gives the plaintext back,
+		// sealing the plaintext gives the full tag which a truncated tag is the prefix of
+		out = aead.Seal(nil, ivb, src, nil)[:len(src)]
+		sealed := aead.Seal(nil, ivb, out, []byte(aad))
+		if subtle.ConstantTimeCompare(sealed[len(src):len(src)+len(tag)], []byte(tag)) != 1 {
+			return "", errors.New("openssl_decrypt(): message authentication failed")
+		}
+	default:
+		opensslStream(c.mode, block, ivb, true).XORKeyStream(out, src)
+	}
+	return string(out), nil
+}
+
+// opensslCipherInit php_openssl_cipher_init() and php_openssl_validate_iv()
+func opensslCipherInit(fn, cipherAlgo, passphrase string, options int, iv string) (opensslCipher, cipher.Block, []byte, error) {
+	c, ok := findOpensslCipher(cipherAlgo)
+	if !ok {
+		return c, nil, nil, errors.New(fn + "(): Unknown cipher algorithm")
+	}
+	key := make([]byte, c.keyLen)
+	if len(passphrase) < c.keyLen && options&OpensslDontZeroPadKey != 0 {
+		return c, nil, nil, errors.New(fn + "(): Key length cannot be set for the cipher algorithm")
+	}
+	copy(key, passphrase)
+	block, err := c.newBlock(key)
+	if err != nil {
+		return c, nil, nil, err
+	}
+	ivb := []byte(iv)
+	if c.mode == "gcm" {
+		if len(ivb) == 0 {
+			return c, nil, nil, errors.New(fn + "(): Setting of IV length for AEAD mode failed")
+		}
+	} else if len(ivb) != c.ivLen {
+		ivb = make([]byte, c.ivLen)
+		copy(ivb, iv)
+	}
+	return c, block, ivb, nil
+}
+
+func opensslStream(mode string, block cipher.Block, iv []byte, decrypt bool) cipher.Stream {
+	switch mode {
+	case "cfb":
+		if decrypt {
+			return cipher.NewCFBDecrypter(block, iv)
+		}
+		return cipher.NewCFBEncrypter(block, iv)
+	case "cfb8":
+		reg := make([]byte, len(iv))
+		copy(reg, iv)
+		return &cfb8{block: block, reg: reg, out: make([]byte, len(iv)), decrypt: decrypt}
+	case "ofb":
+		return cipher.NewOFB(block, iv)
+	}
+	return cipher.NewCTR(block, iv)
+}
+
+// cfb8 the CFB mode with 8 bit feedback
+type cfb8 struct {
+	block    cipher.Block
+	reg, out []byte
+	decrypt  bool
+}
+
+func (c *cfb8) XORKeyStream(dst, src []byte) {
+	for i, in := range src {
+		c.block.Encrypt(c.out, c.reg)
+		o := in ^ c.out[0]
+		copy(c.reg, c.reg[1:])
+		if c.decrypt {
+			c.reg[len(c.reg)-1] = in
+		} else {
+			c.reg[len(c.reg)-1] = o
+		}
+		dst[i] = o
+	}
+}
+
+// OpensslCipherIvLength openssl_cipher_iv_length()
+func OpensslCipherIvLength(cipherAlgo string) (int, error) {
+	if cipherAlgo == "" {
+		return 0, errors.New("openssl_cipher_iv_length(): Argument #1 ($cipher_algo) cannot be empty")
+	}
+	c, ok := findOpensslCipher(cipherAlgo)
+	if !ok {
+		return 0, errors.New("openssl_cipher_iv_length(): Unknown cipher algorithm")
+	}
+	return c.ivLen, nil
+}
+
+// OpensslGetCipherMethods openssl_get_cipher_methods()
+// The supported cipher methods sorted, with the aliases like aes256 when aliases is true
+func OpensslGetCipherMethods(aliases bool) []string {
+	methods := make([]string, 0, len(opensslCiphers)+len(opensslCipherAliases))
+	for name := range opensslCiphers {
+		methods = append(methods, name)
+	}
+	if aliases {
+		for name := range opensslCipherAliases {
+			methods = append(methods, name)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// OpensslRandomPseudoBytes openssl_random_pseudo_bytes()
+// strongResult, when not nil, is set to true as crypto/rand is cryptographically strong
+func OpensslRandomPseudoBytes(length int, strongResult *bool) (string, error) {
+	if length <= 0 {
+		return "", errors.New("openssl_random_pseudo_bytes(): Argument #1 ($length) must be greater than 0")
+	}
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	if strongResult != nil {
+		*strongResult = true
+	}
+	return string(b), nil
+}
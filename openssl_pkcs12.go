@@ -0,0 +1,540 @@
+package php2go
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"hash"
+	"math/bits"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	oidData                      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidEncryptedData             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+	oidKeyBag                    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 1}
+	oidPKCS8ShroudedKeyBag       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidCertBag                   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidX509Certificate           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidPBEWithSHAAnd3KeyDESCBC   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidPBEWithSHAAnd128BitRC2CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 5}
+	oidPBEWithSHAAnd40BitRC2CBC  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 6}
+	oidPBES2                     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2                    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidSHA1                      = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256                    = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384                    = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512                    = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+)
+
+// pbeHMACs the PBKDF2 pseudorandom functions by the last arc of hmacWithSHA1 1.2.840.113549.2.7 and its siblings
+var pbeHMACs = map[int]func() hash.Hash{
+	7:  sha1.New,
+	8:  sha256.New224,
+	9:  sha256.New,
+	10: sha512.New384,
+	11: sha512.New,
+}
+
+// pbeCipher a CBC cipher of PBES2
+type pbeCipher struct {
+	oid      asn1.ObjectIdentifier
+	keyLen   int
+	newBlock func(key []byte) (cipher.Block, error)
+}
+
+// pbeCiphers the PBES2 ciphers by the OPENSSL_CIPHER_* constants
+var pbeCiphers = map[int]pbeCipher{
+	OpensslCipherDES:       {asn1.ObjectIdentifier{1, 3, 14, 3, 2, 7}, 8, des.NewCipher},
+	OpensslCipher3DES:      {asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}, 24, des.NewTripleDESCipher},
+	OpensslCipherAES128CBC: {asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}, 16, aes.NewCipher},
+	OpensslCipherAES192CBC: {asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}, 24, aes.NewCipher},
+	OpensslCipherAES256CBC: {asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}, 32, aes.NewCipher},
+}
+
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt       []byte
+	Iterations int
+	KeyLength  int                      `asn1:"optional"`
+	PRF        pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+type pbeParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+// pbes2Encrypt the EncryptedPrivateKeyInfo of a PKCS#8 key, like OpenSSL with PBKDF2 and hmacWithSHA256
+func pbes2Encrypt(der, password []byte, cipherID int) ([]byte, error) {
+	c, ok := pbeCiphers[cipherID]
+	if !ok {
+		return nil, errors.New("Unknown cipher algorithm for private key")
+	}
+	const iterations = 2048
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	block, err := c.newBlock(pbkdf2.Key(password, salt, iterations, c.keyLen, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, block.BlockSize())
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	n := block.BlockSize() - len(der)%block.BlockSize()
+	data := append(append([]byte{}, der...), bytes.Repeat([]byte{byte(n)}, n)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, data)
+
+	kdf, err := asn1.Marshal(pbkdf2Params{
+		Salt:       salt,
+		Iterations: iterations,
+		PRF:        pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ivParam, _ := asn1.Marshal(iv)
+	params, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdf}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: c.oid, Parameters: asn1.RawValue{FullBytes: ivParam}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}},
+		EncryptedData: data,
+	})
+}
+
+// pbeDecrypt decrypts with PBES2 or with the PKCS#12 password based encryption of 3DES and RC2,
+// password is the passphrase as is, it is converted to a BMPString for PKCS#12
+func pbeDecrypt(alg pkix.AlgorithmIdentifier, data, password []byte) ([]byte, error) {
+	var block cipher.Block
+	var iv []byte
+	switch {
+	case alg.Algorithm.Equal(oidPBES2):
+		var params pbes2Params
+		var kdf pbkdf2Params
+		if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+			return nil, err
+		}
+		if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+			return nil, errors.New("unsupported key derivation function")
+		}
+		if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+			return nil, err
+		}
+		prf := sha1.New
+		if len(kdf.PRF.Algorithm) > 0 {
+			oid := kdf.PRF.Algorithm
+			h, ok := pbeHMACs[oid[len(oid)-1]]
+			if !ok || !oid[:len(oid)-1].Equal(asn1.ObjectIdentifier{1, 2, 840, 113549, 2}) {
+				return nil, errors.New("unsupported prf")
+			}
+			prf = h
+		}
+		var c pbeCipher
+		for _, pc := range pbeCiphers {
+			if pc.oid.Equal(params.EncryptionScheme.Algorithm) {
+				c = pc
+			}
+		}
+		if c.newBlock == nil {
+			return nil, errors.New("unsupported cipher")
+		}
+		if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+			return nil, err
+		}
+		var err error
+		if block, err = c.newBlock(pbkdf2.Key(password, kdf.Salt, kdf.Iterations, c.keyLen, prf)); err != nil {
+			return nil, err
+		}
+	case alg.Algorithm.Equal(oidPBEWithSHAAnd3KeyDESCBC), alg.Algorithm.Equal(oidPBEWithSHAAnd128BitRC2CBC), alg.Algorithm.Equal(oidPBEWithSHAAnd40BitRC2CBC):
+		var params pbeParams
+		if _, err := asn1.Unmarshal(alg.Parameters.FullBytes, &params); err != nil {
+			return nil, err
+		}
+		pass := bmpPassword(password)
+		iv = pkcs12KDF(sha1.New, 2, pass, params.Salt, params.Iterations, 8)
+		switch {
+		case alg.Algorithm.Equal(oidPBEWithSHAAnd3KeyDESCBC):
+			block, _ = des.NewTripleDESCipher(pkcs12KDF(sha1.New, 1, pass, params.Salt, params.Iterations, 24))
+		case alg.Algorithm.Equal(oidPBEWithSHAAnd128BitRC2CBC):
+			block = newRC2(pkcs12KDF(sha1.New, 1, pass, params.Salt, params.Iterations, 16), 128)
+		default:
+			block = newRC2(pkcs12KDF(sha1.New, 1, pass, params.Salt, params.Iterations, 5), 40)
+		}
+	default:
+		return nil, errors.New("unsupported encryption algorithm " + alg.Algorithm.String())
+	}
+	bs := block.BlockSize()
+	if len(data) == 0 || len(data)%bs != 0 || len(iv) != bs {
+		return nil, errors.New("wrong final block length")
+	}
+	out := make([]byte, len(data))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(out, data)
+	n := int(out[len(out)-1])
+	if n == 0 || n > bs || subtle.ConstantTimeCompare(out[len(out)-n:], bytes.Repeat([]byte{byte(n)}, n)) != 1 {
+		return nil, errors.New("bad decrypt")
+	}
+	return out[:len(out)-n], nil
+}
+
+// bmpPassword the password of PKCS#12, UTF-16 big-endian with a NUL terminator, nil stays nil for no password
+func bmpPassword(password []byte) []byte {
+	if password == nil {
+		return nil
+	}
+	units := utf16.Encode([]rune(string(password)))
+	b := make([]byte, 0, 2*len(units)+2)
+	for _, u := range units {
+		b = append(b, byte(u>>8), byte(u))
+	}
+	return append(b, 0, 0)
+}
+
+// pkcs12KDF the key derivation of RFC 7292 appendix B, id is 1 for keys, 2 for ivs and 3 for MAC keys
+func pkcs12KDF(newHash func() hash.Hash, id byte, password, salt []byte, iterations, size int) []byte {
+	h := newHash()
+	v := h.BlockSize()
+	fill := func(b []byte, n int) []byte {
+		out := make([]byte, n)
+		for i := range out {
+			out[i] = b[i%len(b)]
+		}
+		return out
+	}
+	var s, p []byte
+	if len(salt) > 0 {
+		s = fill(salt, v*((len(salt)+v-1)/v))
+	}
+	if len(password) > 0 {
+		p = fill(password, v*((len(password)+v-1)/v))
+	}
+	i := append(s, p...)
+	var out []byte
+	for {
+		h.Reset()
+		h.Write(bytes.Repeat([]byte{id}, v))
+		h.Write(i)
+		a := h.Sum(nil)
+		for n := 1; n < iterations; n++ {
+			h.Reset()
+			h.Write(a)
+			a = h.Sum(a[:0])
+		}
+		if out = append(out, a...); len(out) >= size {
+			return out[:size]
+		}
+		// each v bytes of I become I + B + 1 where B repeats A to v bytes
+		b := fill(a, v)
+		for j := 0; j < len(i); j += v {
+			carry := 1
+			for k := v - 1; k >= 0; k-- {
+				sum := int(i[j+k]) + int(b[k]) + carry
+				i[j+k], carry = byte(sum), sum>>8
+			}
+		}
+	}
+}
+
+// rc2 the RC2 cipher of RFC 2268 for the PKCS#12 files of OpenSSL 1.x
+type rc2 struct {
+	k [64]uint16
+}
+
+var rc2PiTable = [256]byte{
+	0xd9, 0x78, 0xf9, 0xc4, 0x19, 0xdd, 0xb5, 0xed, 0x28, 0xe9, 0xfd, 0x79, 0x4a, 0xa0, 0xd8, 0x9d,
+	0xc6, 0x7e, 0x37, 0x83, 0x2b, 0x76, 0x53, 0x8e, 0x62, 0x4c, 0x64, 0x88, 0x44, 0x8b, 0xfb, 0xa2,
+	0x17, 0x9a, 0x59, 0xf5, 0x87, 0xb3, 0x4f, 0x13, 0x61, 0x45, 0x6d, 0x8d, 0x09, 0x81, 0x7d, 0x32,
+	0xbd, 0x8f, 0x40, 0xeb, 0x86, 0xb7, 0x7b, 0x0b, 0xf0, 0x95, 0x21, 0x22, 0x5c, 0x6b, 0x4e, 0x82,
+	0x54, 0xd6, 0x65, 0x93, 0xce, 0x60, 0xb2, 0x1c, 0x73, 0x56, 0xc0, 0x14, 0xa7, 0x8c, 0xf1, 0xdc,
+	0x12, 0x75, 0xca, 0x1f, 0x3b, 0xbe, 0xe4, 0xd1, 0x42, 0x3d, 0xd4, 0x30, 0xa3, 0x3c, 0xb6, 0x26,
+	0x6f, 0xbf, 0x0e, 0xda, 0x46, 0x69, 0x07, 0x57, 0x27, 0xf2, 0x1d, 0x9b, 0xbc, 0x94, 0x43, 0x03,
+	0xf8, 0x11, 0xc7, 0xf6, 0x90, 0xef, 0x3e, 0xe7, 0x06, 0xc3, 0xd5, 0x2f, 0xc8, 0x66, 0x1e, 0xd7,
+	0x08, 0xe8, 0xea, 0xde, 0x80, 0x52, 0xee, 0xf7, 0x84, 0xaa, 0x72, 0xac, 0x35, 0x4d, 0x6a, 0x2a,
+	0x96, 0x1a, 0xd2, 0x71, 0x5a, 0x15, 0x49, 0x74, 0x4b, 0x9f, 0xd0, 0x5e, 0x04, 0x18, 0xa4, 0xec,
+	0xc2, 0xe0, 0x41, 0x6e, 0x0f, 0x51, 0xcb, 0xcc, 0x24, 0x91, 0xaf, 0x50, 0xa1, 0xf4, 0x70, 0x39,
+	0x99, 0x7c, 0x3a, 0x85, 0x23, 0xb8, 0xb4, 0x7a, 0xfc, 0x02, 0x36, 0x5b, 0x25, 0x55, 0x97, 0x31,
+	0x2d, 0x5d, 0xfa, 0x98, 0xe3, 0x8a, 0x92, 0xae, 0x05, 0xdf, 0x29, 0x10, 0x67, 0x6c, 0xba, 0xc9,
+	0xd3, 0x00, 0xe6, 0xcf, 0xe1, 0x9e, 0xa8, 0x2c, 0x63, 0x16, 0x01, 0x3f, 0x58, 0xe2, 0x89, 0xa9,
+	0x0d, 0x38, 0x34, 0x1b, 0xab, 0x33, 0xff, 0xb0, 0xbb, 0x48, 0x0c, 0x5f, 0xb9, 0xb1, 0xcd, 0x2e,
+	0xc5, 0xf3, 0xdb, 0x47, 0xe5, 0xa5, 0x9c, 0x77, 0x0a, 0xa6, 0x20, 0x68, 0xfe, 0x7f, 0xc1, 0xad,
+}
+
+// newRC2 the RC2 cipher of key with effectiveBits effective key bits
+func newRC2(key []byte, effectiveBits int) *rc2 {
+	var l [128]byte
+	copy(l[:], key)
+	t := len(key)
+	for i := t; i < 128; i++ {
+		l[i] = rc2PiTable[l[i-1]+l[i-t]]
+	}
+	t8 := (effectiveBits + 7) / 8
+	l[128-t8] = rc2PiTable[l[128-t8]&byte(0xff>>(8*t8-effectiveBits))]
+	for i := 127 - t8; i >= 0; i-- {
+		l[i] = rc2PiTable[l[i+1]^l[i+t8]]
+	}
+	c := &rc2{}
+	for i := range c.k {
+		c.k[i] = uint16(l[2*i]) | uint16(l[2*i+1])<<8
+	}
+	return c
+}
+
+func (c *rc2) BlockSize() int { return 8 }
+
+func (c *rc2) Encrypt(dst, src []byte) {
+	var r [4]uint16
+	for i := range r {
+		r[i] = uint16(src[2*i]) | uint16(src[2*i+1])<<8
+	}
+	j := 0
+	for round := 0; round < 16; round++ {
+		for i, s := range [4]int{1, 2, 3, 5} {
+			r[i] += c.k[j] + r[(i+3)&3]&r[(i+2)&3] + ^r[(i+3)&3]&r[(i+1)&3]
+			r[i] = bits.RotateLeft16(r[i], s)
+			j++
+		}
+		if round == 4 || round == 10 {
+			for i := range r {
+				r[i] += c.k[r[(i+3)&3]&63]
+			}
+		}
+	}
+	for i, w := range r {
+		dst[2*i], dst[2*i+1] = byte(w), byte(w>>8)
+	}
+}
+
+func (c *rc2) Decrypt(dst, src []byte) {
+	var r [4]uint16
+	for i := range r {
+		r[i] = uint16(src[2*i]) | uint16(src[2*i+1])<<8
+	}
+	j := 63
+	for round := 15; round >= 0; round-- {
+		for i := 3; i >= 0; i-- {
+			r[i] = bits.RotateLeft16(r[i], -[4]int{1, 2, 3, 5}[i])
+			r[i] -= c.k[j] + r[(i+3)&3]&r[(i+2)&3] + ^r[(i+3)&3]&r[(i+1)&3]
+			j--
+		}
+		if round == 5 || round == 11 {
+			for i := 3; i >= 0; i-- {
+				r[i] -= c.k[r[(i+3)&3]&63]
+			}
+		}
+	}
+	for i, w := range r {
+		dst[2*i], dst[2*i+1] = byte(w), byte(w>>8)
+	}
+}
+
+type pfx struct {
+	Version  int
+	AuthSafe contentInfo
+	MacData  macData `asn1:"optional"`
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"tag:0,explicit,optional"`
+}
+
+type macData struct {
+	Mac struct {
+		Algorithm pkix.AlgorithmIdentifier
+		Digest    []byte
+	}
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type encryptedData struct {
+	Version              int
+	EncryptedContentInfo struct {
+		ContentType                asn1.ObjectIdentifier
+		ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+		EncryptedContent           []byte `asn1:"tag:0,optional"`
+	}
+}
+
+type safeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue   `asn1:"tag:0,explicit"`
+	Attributes []asn1.RawValue `asn1:"set,optional"`
+}
+
+type certBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+// OpensslPkcs12Read openssl_pkcs12_read()
+// certificates receives the PEM of the "cert" of the private key and of the "pkey", the other
+// certificates are the "extracerts". The file can be encrypted with PBES2, 3DES or RC2 like OpenSSL 1.x does.
+func OpensslPkcs12Read(pkcs12 string, certificates map[string]interface{}, passphrase string) error {
+	var p pfx
+	if rest, err := asn1.Unmarshal([]byte(pkcs12), &p); err != nil || len(rest) > 0 {
+		return errors.New("openssl_pkcs12_read(): Failed to parse the PKCS#12 data")
+	}
+	if !p.AuthSafe.ContentType.Equal(oidData) {
+		return errors.New("openssl_pkcs12_read(): Only data content is supported")
+	}
+	var authSafe []byte
+	if _, err := asn1.Unmarshal(p.AuthSafe.Content.Bytes, &authSafe); err != nil {
+		return err
+	}
+	// PKCS12_parse(), an empty passphrase is no password when the MAC verifies with it
+	password := []byte(passphrase)
+	if passphrase == "" {
+		password = nil
+	}
+	if len(p.MacData.Mac.Digest) > 0 {
+		ok := verifyPkcs12Mac(p.MacData, authSafe, password)
+		if !ok && password == nil {
+			password = []byte{}
+			ok = verifyPkcs12Mac(p.MacData, authSafe, password)
+		}
+		if !ok {
+			return errors.New("openssl_pkcs12_read(): mac verify failure")
+		}
+	}
+
+	var infos []contentInfo
+	if _, err := asn1.Unmarshal(authSafe, &infos); err != nil {
+		return err
+	}
+	var key *OpensslKey
+	var certs []*x509.Certificate
+	for _, info := range infos {
+		var contents []byte
+		switch {
+		case info.ContentType.Equal(oidData):
+			if _, err := asn1.Unmarshal(info.Content.Bytes, &contents); err != nil {
+				return err
+			}
+		case info.ContentType.Equal(oidEncryptedData):
+			var ed encryptedData
+			if _, err := asn1.Unmarshal(info.Content.Bytes, &ed); err != nil {
+				return err
+			}
+			var err error
+			eci := ed.EncryptedContentInfo
+			if contents, err = pbeDecrypt(eci.ContentEncryptionAlgorithm, eci.EncryptedContent, password); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+		var bags []safeBag
+		if _, err := asn1.Unmarshal(contents, &bags); err != nil {
+			return err
+		}
+		for _, bag := range bags {
+			switch {
+			case bag.ID.Equal(oidKeyBag), bag.ID.Equal(oidPKCS8ShroudedKeyBag):
+				der := bag.Value.Bytes
+				if bag.ID.Equal(oidPKCS8ShroudedKeyBag) {
+					var info encryptedPrivateKeyInfo
+					if _, err := asn1.Unmarshal(der, &info); err != nil {
+						return err
+					}
+					var err error
+					if der, err = pbeDecrypt(info.Algorithm, info.EncryptedData, password); err != nil {
+						return err
+					}
+				}
+				k, err := parsePrivateKeyDER(der)
+				if err != nil {
+					return err
+				}
+				if key == nil {
+					key = k
+				}
+			case bag.ID.Equal(oidCertBag):
+				var cb certBag
+				if _, err := asn1.Unmarshal(bag.Value.Bytes, &cb); err != nil {
+					return err
+				}
+				if !cb.ID.Equal(oidX509Certificate) {
+					continue
+				}
+				cert, err := x509.ParseCertificate(cb.Data)
+				if err != nil {
+					return err
+				}
+				certs = append(certs, cert)
+			}
+		}
+	}
+
+	var extra []string
+	for _, cert := range certs {
+		block := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+		if _, ok := certificates["cert"]; !ok && key != nil && key.matches(cert.PublicKey) {
+			certificates["cert"] = block
+		} else {
+			extra = append(extra, block)
+		}
+	}
+	if key != nil {
+		der, err := x509.MarshalPKCS8PrivateKey(key.private)
+		if err != nil {
+			return err
+		}
+		certificates["pkey"] = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	}
+	if len(extra) > 0 {
+		certificates["extracerts"] = extra
+	}
+	return nil
+}
+
+// verifyPkcs12Mac checks the HMAC of the authenticated safe with the key of pkcs12KDF
+func verifyPkcs12Mac(md macData, data, password []byte) bool {
+	var newHash func() hash.Hash
+	switch alg := md.Mac.Algorithm.Algorithm; {
+	case alg.Equal(oidSHA1):
+		newHash = sha1.New
+	case alg.Equal(oidSHA256):
+		newHash = sha256.New
+	case alg.Equal(oidSHA384):
+		newHash = sha512.New384
+	case alg.Equal(oidSHA512):
+		newHash = sha512.New
+	default:
+		return false
+	}
+	key := pkcs12KDF(newHash, 3, bmpPassword(password), md.MacSalt, md.Iterations, newHash().Size())
+	mac := hmac.New(newHash, key)
+	mac.Write(data)
+	return hmac.Equal(mac.Sum(nil), md.Mac.Digest)
+}
@@ -0,0 +1,646 @@
+package php2go
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+)
+
+const (
+	// OpensslKeytypeRSA OPENSSL_KEYTYPE_RSA
+	OpensslKeytypeRSA = 0
+	// OpensslKeytypeDSA OPENSSL_KEYTYPE_DSA, not supported
+	OpensslKeytypeDSA = 1
+	// OpensslKeytypeDH OPENSSL_KEYTYPE_DH, not supported
+	OpensslKeytypeDH = 2
+	// OpensslKeytypeEC OPENSSL_KEYTYPE_EC
+	OpensslKeytypeEC = 3
+
+	// OpensslAlgoSHA1 OPENSSL_ALGO_SHA1
+	OpensslAlgoSHA1 = 1
+	// OpensslAlgoMD5 OPENSSL_ALGO_MD5
+	OpensslAlgoMD5 = 2
+	// OpensslAlgoMD4 OPENSSL_ALGO_MD4
+	OpensslAlgoMD4 = 3
+	// OpensslAlgoSHA224 OPENSSL_ALGO_SHA224
+	OpensslAlgoSHA224 = 6
+	// OpensslAlgoSHA256 OPENSSL_ALGO_SHA256
+	OpensslAlgoSHA256 = 7
+	// OpensslAlgoSHA384 OPENSSL_ALGO_SHA384
+	OpensslAlgoSHA384 = 8
+	// OpensslAlgoSHA512 OPENSSL_ALGO_SHA512
+	OpensslAlgoSHA512 = 9
+	// OpensslAlgoRMD160 OPENSSL_ALGO_RMD160
+	OpensslAlgoRMD160 = 10
+
+	// OpensslPkcs1Padding OPENSSL_PKCS1_PADDING
+	OpensslPkcs1Padding = 1
+	// OpensslSslv23Padding OPENSSL_SSLV23_PADDING, not supported
+	OpensslSslv23Padding = 2
+	// OpensslNoPadding OPENSSL_NO_PADDING
+	OpensslNoPadding = 3
+	// OpensslPkcs1OaepPadding OPENSSL_PKCS1_OAEP_PADDING, OAEP with SHA-1 and MGF1 with SHA-1
+	OpensslPkcs1OaepPadding = 4
+
+	// OpensslCipherDES OPENSSL_CIPHER_DES
+	OpensslCipherDES = 3
+	// OpensslCipher3DES OPENSSL_CIPHER_3DES
+	OpensslCipher3DES = 4
+	// OpensslCipherAES128CBC OPENSSL_CIPHER_AES_128_CBC
+	OpensslCipherAES128CBC = 5
+	// OpensslCipherAES192CBC OPENSSL_CIPHER_AES_192_CBC
+	OpensslCipherAES192CBC = 6
+	// OpensslCipherAES256CBC OPENSSL_CIPHER_AES_256_CBC
+	OpensslCipherAES256CBC = 7
+)
+
+// OpensslKey OpenSSLAsymmetricKey, an RSA or EC public key with its private key when known
+type OpensslKey struct {
+	public  crypto.PublicKey
+	private crypto.Signer
+}
+
+// opensslDigests the digests of openssl_sign() by the OPENSSL_ALGO_* constants and by name
+var opensslDigests = map[interface{}]crypto.Hash{
+	OpensslAlgoSHA1:           crypto.SHA1,
+	OpensslAlgoMD5:            crypto.MD5,
+	OpensslAlgoMD4:            crypto.MD4,
+	OpensslAlgoSHA224:         crypto.SHA224,
+	OpensslAlgoSHA256:         crypto.SHA256,
+	OpensslAlgoSHA384:         crypto.SHA384,
+	OpensslAlgoSHA512:         crypto.SHA512,
+	OpensslAlgoRMD160:         crypto.RIPEMD160,
+	"md4":                     crypto.MD4,
+	"md5":                     crypto.MD5,
+	"sha1":                    crypto.SHA1,
+	"sha224":                  crypto.SHA224,
+	"sha256":                  crypto.SHA256,
+	"sha384":                  crypto.SHA384,
+	"sha512":                  crypto.SHA512,
+	"sha512-224":              crypto.SHA512_224,
+	"sha512-256":              crypto.SHA512_256,
+	"sha3-224":                crypto.SHA3_224,
+	"sha3-256":                crypto.SHA3_256,
+	"sha3-384":                crypto.SHA3_384,
+	"sha3-512":                crypto.SHA3_512,
+	"ripemd160":               crypto.RIPEMD160,
+	"rmd160":                  crypto.RIPEMD160,
+	"md5withrsaencryption":    crypto.MD5,
+	"sha1withrsaencryption":   crypto.SHA1,
+	"sha224withrsaencryption": crypto.SHA224,
+	"sha256withrsaencryption": crypto.SHA256,
+	"sha384withrsaencryption": crypto.SHA384,
+	"sha512withrsaencryption": crypto.SHA512,
+}
+
+// opensslCurves the curves of openssl_pkey_new() by their short name and their oid
+var opensslCurves = []struct {
+	name  string
+	oid   string
+	curve elliptic.Curve
+}{
+	{"secp224r1", "1.3.132.0.33", elliptic.P224()},
+	{"prime256v1", "1.2.840.10045.3.1.7", elliptic.P256()},
+	{"secp384r1", "1.3.132.0.34", elliptic.P384()},
+	{"secp521r1", "1.3.132.0.35", elliptic.P521()},
+}
+
+// opensslConfig the options that php_openssl_parse_config() reads, defaulting to the openssl.cnf of OpenSSL
+type opensslConfig struct {
+	digestAlg        string
+	keyType          int
+	keyBits          int
+	curveName        string
+	encryptKey       bool
+	encryptKeyCipher int
+}
+
+func newOpensslConfig(options []map[string]interface{}) opensslConfig {
+	c := opensslConfig{"sha256", OpensslKeytypeRSA, 2048, "", true, OpensslCipher3DES}
+	if len(options) == 0 {
+		return c
+	}
+	o := options[0]
+	if v, ok := o["digest_alg"]; ok {
+		c.digestAlg = strings.ToLower(phpStringVal(v))
+	}
+	if v, ok := o["private_key_type"]; ok {
+		c.keyType = int(phpIntVal(v))
+	}
+	if v, ok := o["private_key_bits"]; ok {
+		c.keyBits = int(phpIntVal(v))
+	}
+	if v, ok := o["curve_name"]; ok {
+		c.curveName = phpStringVal(v)
+	}
+	if v, ok := o["encrypt_key"]; ok {
+		c.encryptKey = phpIntVal(v) != 0
+	}
+	if v, ok := o["encrypt_key_cipher"]; ok {
+		c.encryptKeyCipher = int(phpIntVal(v))
+	}
+	return c
+}
+
+// OpensslPkeyNew openssl_pkey_new()
+// The "private_key_type" option is OpensslKeytypeRSA with "private_key_bits", 2048 by default,
+// or OpensslKeytypeEC with "curve_name" of secp224r1, prime256v1, secp384r1 or secp521r1
+// OpensslPkeyNew(map[string]interface{}{"private_key_type": OpensslKeytypeEC, "curve_name": "prime256v1"})
+func OpensslPkeyNew(options ...map[string]interface{}) (*OpensslKey, error) {
+	return newOpensslConfig(options).generateKey("openssl_pkey_new")
+}
+
+func (c opensslConfig) generateKey(fn string) (*OpensslKey, error) {
+	switch c.keyType {
+	case OpensslKeytypeRSA:
+		if c.keyBits < 384 {
+			return nil, fmt.Errorf("%s(): Private key length must be at least 384 bits, configured to %d", fn, c.keyBits)
+		}
+		k, err := rsa.GenerateKey(rand.Reader, c.keyBits)
+		if err != nil {
+			return nil, err
+		}
+		return &OpensslKey{&k.PublicKey, k}, nil
+	case OpensslKeytypeEC:
+		if c.curveName == "" {
+			return nil, errors.New(fn + `(): Missing configuration value: "curve_name" not set`)
+		}
+		for _, curve := range opensslCurves {
+			if curve.name == c.curveName {
+				k, err := ecdsa.GenerateKey(curve.curve, rand.Reader)
+				if err != nil {
+					return nil, err
+				}
+				return &OpensslKey{&k.PublicKey, k}, nil
+			}
+		}
+		return nil, fmt.Errorf("%s(): Unknown elliptic curve (short) name %s", fn, c.curveName)
+	}
+	return nil, errors.New(fn + "(): Unsupported private key type")
+}
+
+// OpensslPkeyExport openssl_pkey_export()
+// output receives the PKCS#8 PEM of the private key, encrypted with passphrase unless it is empty or the
+// "encrypt_key" option is false. The cipher is the "encrypt_key_cipher" option, OpensslCipher3DES by default.
+func OpensslPkeyExport(key interface{}, output *string, passphrase string, options ...map[string]interface{}) error {
+	k, err := opensslKeyFrom(key, false)
+	if err != nil {
+		return errors.New("openssl_pkey_export(): Cannot get key from parameter 1")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(k.private)
+	if err != nil {
+		return err
+	}
+	block := &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	if c := newOpensslConfig(options); passphrase != "" && c.encryptKey {
+		if der, err = pbes2Encrypt(der, []byte(passphrase), c.encryptKeyCipher); err != nil {
+			return errors.New("openssl_pkey_export(): " + err.Error())
+		}
+		block = &pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: der}
+	}
+	*output = string(pem.EncodeToMemory(block))
+	return nil
+}
+
+// OpensslPkeyGetDetails openssl_pkey_get_details()
+// The "bits", the public "key" PEM and the "type" with the "rsa" numbers n, e, d, p, q, dmp1, dmq1 and iqmp
+// or the "ec" curve_name, curve_oid, x, y and d, as big-endian binary strings, without the private ones of public keys
+func OpensslPkeyGetDetails(key *OpensslKey) map[string]interface{} {
+	der, _ := x509.MarshalPKIXPublicKey(key.public)
+	details := map[string]interface{}{
+		"key": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}
+	switch pub := key.public.(type) {
+	case *rsa.PublicKey:
+		rsaDetails := map[string]string{
+			"n": string(pub.N.Bytes()),
+			"e": string(big.NewInt(int64(pub.E)).Bytes()),
+		}
+		if priv, ok := key.private.(*rsa.PrivateKey); ok {
+			priv.Precompute()
+			rsaDetails["d"] = string(priv.D.Bytes())
+			rsaDetails["p"] = string(priv.Primes[0].Bytes())
+			rsaDetails["q"] = string(priv.Primes[1].Bytes())
+			rsaDetails["dmp1"] = string(priv.Precomputed.Dp.Bytes())
+			rsaDetails["dmq1"] = string(priv.Precomputed.Dq.Bytes())
+			rsaDetails["iqmp"] = string(priv.Precomputed.Qinv.Bytes())
+		}
+		details["bits"] = pub.N.BitLen()
+		details["rsa"] = rsaDetails
+		details["type"] = OpensslKeytypeRSA
+	case *ecdsa.PublicKey:
+		ecDetails := map[string]string{
+			"x": string(pub.X.Bytes()),
+			"y": string(pub.Y.Bytes()),
+		}
+		for _, curve := range opensslCurves {
+			if curve.curve == pub.Curve {
+				ecDetails["curve_name"] = curve.name
+				ecDetails["curve_oid"] = curve.oid
+			}
+		}
+		if priv, ok := key.private.(*ecdsa.PrivateKey); ok {
+			ecDetails["d"] = string(priv.D.Bytes())
+		}
+		details["bits"] = pub.Curve.Params().BitSize
+		details["ec"] = ecDetails
+		details["type"] = OpensslKeytypeEC
+	}
+	return details
+}
+
+// OpensslPkeyGetPublic openssl_pkey_get_public()
+// publicKey is an *OpensslKey, an *OpensslCertificate, or the PEM of a public key or a certificate,
+// either as a string or in the file of a "file://" path
+func OpensslPkeyGetPublic(publicKey interface{}) (*OpensslKey, error) {
+	k, err := opensslKeyFrom(publicKey, true)
+	if err != nil {
+		return nil, errors.New("openssl_pkey_get_public(): Supplied key param cannot be coerced into a public key")
+	}
+	return &OpensslKey{public: k.public}, nil
+}
+
+// OpensslPkeyGetPrivate openssl_pkey_get_private()
+// privateKey is an *OpensslKey or the PEM of a private key as a string or in the file of a "file://" path,
+// the passphrase decrypts encrypted PEM
+func OpensslPkeyGetPrivate(privateKey interface{}, passphrase ...string) (*OpensslKey, error) {
+	if s, ok := privateKey.(string); ok && len(passphrase) > 0 {
+		privateKey = []string{s, passphrase[0]}
+	}
+	k, err := opensslKeyFrom(privateKey, false)
+	if err != nil {
+		return nil, errors.New("openssl_pkey_get_private(): Supplied key param cannot be coerced into a private key")
+	}
+	return k, nil
+}
+
+// opensslKeyFrom php_openssl_pkey_from_zval(), the key of an *OpensslKey, of an *OpensslCertificate when public,
+// of a PEM string or "file://" path, or of a []string with the PEM and its passphrase
+func opensslKeyFrom(key interface{}, public bool) (*OpensslKey, error) {
+	passphrase := ""
+	switch k := key.(type) {
+	case *OpensslKey:
+		if k == nil || !public && k.private == nil {
+			return nil, errors.New("Supplied key param is a public key")
+		}
+		return k, nil
+	case *OpensslCertificate:
+		if !public {
+			return nil, errors.New("Supplied key param is a public key")
+		}
+		return newOpensslKey(k.cert.PublicKey, nil)
+	case []string:
+		if len(k) != 2 {
+			return nil, errors.New("Key array must be of the form array(0 => key, 1 => phrase)")
+		}
+		key, passphrase = k[0], k[1]
+	}
+	s, ok := key.(string)
+	if !ok {
+		return nil, errors.New("Supplied key param cannot be coerced into a key")
+	}
+	data, err := opensslReadParam(s)
+	if err != nil {
+		return nil, err
+	}
+	if public {
+		if block := opensslPEMBlock(data, "CERTIFICATE"); block != nil {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return newOpensslKey(cert.PublicKey, nil)
+		}
+		if block := opensslPEMBlock(data, "PUBLIC KEY"); block != nil {
+			pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return newOpensslKey(pub, nil)
+		}
+		if block := opensslPEMBlock(data, "RSA PUBLIC KEY"); block != nil {
+			pub, err := x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return newOpensslKey(pub, nil)
+		}
+		return nil, errors.New("no public key")
+	}
+	block := opensslPEMBlock(data, "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY", "ENCRYPTED PRIVATE KEY")
+	if block == nil {
+		return nil, errors.New("no private key")
+	}
+	der := block.Bytes
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		var info encryptedPrivateKeyInfo
+		if _, err := asn1.Unmarshal(der, &info); err != nil {
+			return nil, err
+		}
+		if der, err = pbeDecrypt(info.Algorithm, info.EncryptedData, []byte(passphrase)); err != nil {
+			return nil, err
+		}
+	} else if x509.IsEncryptedPEMBlock(block) {
+		// the Proc-Type and DEK-Info headers of the traditional keys of OpenSSL
+		if der, err = x509.DecryptPEMBlock(block, []byte(passphrase)); err != nil {
+			return nil, err
+		}
+	}
+	return parsePrivateKeyDER(der)
+}
+
+// opensslReadParam the contents of the file of a "file://" path or the string itself
+func opensslReadParam(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "file://") {
+		return os.ReadFile(s[len("file://"):])
+	}
+	return []byte(s), nil
+}
+
+// opensslPEMBlock the first PEM block of one of the types, skipping the others like PEM_read_bio does
+func opensslPEMBlock(data []byte, types ...string) *pem.Block {
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return nil
+		}
+		for _, t := range types {
+			if block.Type == t {
+				return block
+			}
+		}
+		data = rest
+	}
+}
+
+// parsePrivateKeyDER a PKCS#8, PKCS#1 or SEC 1 private key
+func parsePrivateKeyDER(der []byte) (*OpensslKey, error) {
+	var priv interface{}
+	var err error
+	if priv, err = x509.ParsePKCS8PrivateKey(der); err != nil {
+		if priv, err = x509.ParsePKCS1PrivateKey(der); err != nil {
+			if priv, err = x509.ParseECPrivateKey(der); err != nil {
+				return nil, errors.New("unsupported private key")
+			}
+		}
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key")
+	}
+	return newOpensslKey(signer.Public(), signer)
+}
+
+func newOpensslKey(pub crypto.PublicKey, priv crypto.Signer) (*OpensslKey, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return &OpensslKey{pub, priv}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", pub)
+}
+
+// matches whether pub is the public key of k
+func (k *OpensslKey) matches(pub crypto.PublicKey) bool {
+	if p, ok := k.public.(interface{ Equal(crypto.PublicKey) bool }); ok {
+		return p.Equal(pub)
+	}
+	return reflect.DeepEqual(k.public, pub)
+}
+
+// opensslDigest the digest of the OPENSSL_ALGO_* constant or the name of algorithm, sha1 by default
+func opensslDigest(algorithm []interface{}) (crypto.Hash, bool) {
+	if len(algorithm) == 0 {
+		return crypto.SHA1, true
+	}
+	var h crypto.Hash
+	var ok bool
+	if s, isString := algorithm[0].(string); isString {
+		h, ok = opensslDigests[strings.TrimPrefix(strings.ToLower(s), "rsa-")]
+	} else {
+		h, ok = opensslDigests[int(phpIntVal(algorithm[0]))]
+	}
+	return h, ok && h.Available()
+}
+
+// OpensslSign openssl_sign()
+// signature receives the PKCS#1 v1.5 signature of RSA keys or the DER signature of EC keys,
+// algorithm is one of the OPENSSL_ALGO_* constants or a digest name like "sha256", OpensslAlgoSHA1 by default
+// OpensslSign(data, &signature, privateKeyPEM, OpensslAlgoSHA256)
+func OpensslSign(data string, signature *string, privateKey interface{}, algorithm ...interface{}) error {
+	k, err := opensslKeyFrom(privateKey, false)
+	if err != nil {
+		return errors.New("openssl_sign(): Supplied key param cannot be coerced into a private key")
+	}
+	h, ok := opensslDigest(algorithm)
+	if !ok {
+		return errors.New("openssl_sign(): Unknown digest algorithm")
+	}
+	d := h.New()
+	d.Write([]byte(data))
+	var sig []byte
+	switch priv := k.private.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, priv, h, d.Sum(nil))
+	case *ecdsa.PrivateKey:
+		sig, err = ecdsa.SignASN1(rand.Reader, priv, d.Sum(nil))
+	}
+	if err != nil {
+		return err
+	}
+	*signature = string(sig)
+	return nil
+}
+
+// OpensslVerify openssl_verify()
+// 1 when signature is the signature of data by the private key of publicKey and 0 when it isn't
+func OpensslVerify(data, signature string, publicKey interface{}, algorithm ...interface{}) (int, error) {
+	k, err := opensslKeyFrom(publicKey, true)
+	if err != nil {
+		return -1, errors.New("openssl_verify(): Supplied key param cannot be coerced into a public key")
+	}
+	h, ok := opensslDigest(algorithm)
+	if !ok {
+		return -1, errors.New("openssl_verify(): Unknown digest algorithm")
+	}
+	d := h.New()
+	d.Write([]byte(data))
+	switch pub := k.public.(type) {
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(pub, h, d.Sum(nil), []byte(signature)) == nil {
+			return 1, nil
+		}
+	case *ecdsa.PublicKey:
+		if ecdsa.VerifyASN1(pub, d.Sum(nil), []byte(signature)) {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// OpensslPublicEncrypt openssl_public_encrypt()
+// padding is OpensslPkcs1Padding by default, OpensslPkcs1OaepPadding, or OpensslNoPadding for data of the key size
+// OpensslPublicEncrypt(data, &encrypted, publicKeyPEM, OpensslPkcs1OaepPadding)
+func OpensslPublicEncrypt(data string, encryptedData *string, publicKey interface{}, padding ...int) error {
+	pub, err := opensslRSAKey("openssl_public_encrypt", publicKey, true)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	switch opensslPadding(padding) {
+	case OpensslPkcs1Padding:
+		out, err = rsa.EncryptPKCS1v15(rand.Reader, pub.public.(*rsa.PublicKey), []byte(data))
+	case OpensslPkcs1OaepPadding:
+		out, err = rsa.EncryptOAEP(sha1.New(), rand.Reader, pub.public.(*rsa.PublicKey), []byte(data), nil)
+	case OpensslNoPadding:
+		out, err = rsaRaw(pub.public.(*rsa.PublicKey), nil, []byte(data))
+	default:
+		err = errors.New("unknown padding type")
+	}
+	if err != nil {
+		return err
+	}
+	*encryptedData = string(out)
+	return nil
+}
+
+// OpensslPrivateDecrypt openssl_private_decrypt()
+// Decrypts the data of OpensslPublicEncrypt with the same padding
+func OpensslPrivateDecrypt(data string, decryptedData *string, privateKey interface{}, padding ...int) error {
+	k, err := opensslRSAKey("openssl_private_decrypt", privateKey, false)
+	if err != nil {
+		return err
+	}
+	priv := k.private.(*rsa.PrivateKey)
+	var out []byte
+	switch opensslPadding(padding) {
+	case OpensslPkcs1Padding:
+		out, err = rsa.DecryptPKCS1v15(rand.Reader, priv, []byte(data))
+	case OpensslPkcs1OaepPadding:
+		out, err = rsa.DecryptOAEP(sha1.New(), rand.Reader, priv, []byte(data), nil)
+	case OpensslNoPadding:
+		out, err = rsaRaw(&priv.PublicKey, priv, []byte(data))
+	default:
+		err = errors.New("unknown padding type")
+	}
+	if err != nil {
+		return err
+	}
+	*decryptedData = string(out)
+	return nil
+}
+
+// OpensslPrivateEncrypt openssl_private_encrypt()
+// padding is OpensslPkcs1Padding by default or OpensslNoPadding
+func OpensslPrivateEncrypt(data string, encryptedData *string, privateKey interface{}, padding ...int) error {
+	k, err := opensslRSAKey("openssl_private_encrypt", privateKey, false)
+	if err != nil {
+		return err
+	}
+	priv := k.private.(*rsa.PrivateKey)
+	var out []byte
+	switch opensslPadding(padding) {
+	case OpensslPkcs1Padding:
+		// a signature of no particular hash is the data with the block type 1 padding
+		out, err = rsa.SignPKCS1v15(nil, priv, 0, []byte(data))
+	case OpensslNoPadding:
+		out, err = rsaRaw(&priv.PublicKey, priv, []byte(data))
+	default:
+		err = errors.New("unknown padding type")
+	}
+	if err != nil {
+		return err
+	}
+	*encryptedData = string(out)
+	return nil
+}
+
+// OpensslPublicDecrypt openssl_public_decrypt()
+// Decrypts the data of OpensslPrivateEncrypt with the same padding
+func OpensslPublicDecrypt(data string, decryptedData *string, publicKey interface{}, padding ...int) error {
+	k, err := opensslRSAKey("openssl_public_decrypt", publicKey, true)
+	if err != nil {
+		return err
+	}
+	pub := k.public.(*rsa.PublicKey)
+	var out []byte
+	switch opensslPadding(padding) {
+	case OpensslPkcs1Padding:
+		if len(data) != pub.Size() {
+			return errors.New("data greater than mod len")
+		}
+		if out, err = rsaRaw(pub, nil, []byte(data)); err != nil {
+			return err
+		}
+		// 00 01 FF .. FF 00 data with at least 8 FF bytes
+		i := bytes.IndexByte(out[2:], 0) + 2
+		if out[0] != 0 || out[1] != 1 || i < 10 || len(bytes.Trim(out[2:i], "\xff")) > 0 {
+			return errors.New("padding check failed")
+		}
+		out = out[i+1:]
+	case OpensslNoPadding:
+		out, err = rsaRaw(pub, nil, []byte(data))
+	default:
+		err = errors.New("unknown padding type")
+	}
+	if err != nil {
+		return err
+	}
+	*decryptedData = string(out)
+	return nil
+}
+
+func opensslPadding(padding []int) int {
+	if len(padding) > 0 {
+		return padding[0]
+	}
+	return OpensslPkcs1Padding
+}
+
+// opensslRSAKey the RSA key of opensslKeyFrom
+func opensslRSAKey(fn string, key interface{}, public bool) (*OpensslKey, error) {
+	k, err := opensslKeyFrom(key, public)
+	if err != nil {
+		if public {
+			return nil, errors.New(fn + "(): key parameter is not a valid public key")
+		}
+		return nil, errors.New(fn + "(): key parameter is not a valid private key")
+	}
+	if _, ok := k.public.(*rsa.PublicKey); !ok {
+		return nil, errors.New(fn + "(): operation not supported for this keytype")
+	}
+	return k, nil
+}
+
+// rsaRaw the RSA operation without padding of RSA_NO_PADDING, with the private exponent when priv is not nil
+func rsaRaw(pub *rsa.PublicKey, priv *rsa.PrivateKey, data []byte) ([]byte, error) {
+	size := pub.Size()
+	if len(data) > size {
+		return nil, errors.New("data too large for key size")
+	}
+	if len(data) < size {
+		return nil, errors.New("data too small for key size")
+	}
+	m := new(big.Int).SetBytes(data)
+	if m.Cmp(pub.N) >= 0 {
+		return nil, errors.New("data too large for modulus")
+	}
+	if priv != nil {
+		m.Exp(m, priv.D, pub.N)
+	} else {
+		m.Exp(m, big.NewInt(int64(pub.E)), pub.N)
+	}
+	return m.FillBytes(make([]byte, size)), nil
+}
@@ -0,0 +1,1342 @@
+package php2go
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// OpensslCertificate OpenSSLCertificate, an X.509 certificate
+type OpensslCertificate struct {
+	cert *x509.Certificate
+}
+
+// OpensslCsr OpenSSLCertificateSigningRequest
+type OpensslCsr struct {
+	csr *x509.CertificateRequest
+}
+
+// opensslObject the short name, long name and NID of an object of OpenSSL
+type opensslObject struct {
+	sn, ln string
+	nid    int
+}
+
+// opensslNameObjects the attributes of distinguished names, in the order openssl_csr_new() adds them
+var opensslNameObjects = []struct {
+	oid string
+	opensslObject
+}{
+	{"2.5.4.6", opensslObject{"C", "countryName", 14}},
+	{"2.5.4.8", opensslObject{"ST", "stateOrProvinceName", 16}},
+	{"2.5.4.7", opensslObject{"L", "localityName", 15}},
+	{"2.5.4.9", opensslObject{"street", "streetAddress", 660}},
+	{"2.5.4.17", opensslObject{"postalCode", "postalCode", 661}},
+	{"2.5.4.10", opensslObject{"O", "organizationName", 17}},
+	{"2.5.4.11", opensslObject{"OU", "organizationalUnitName", 18}},
+	{"2.5.4.3", opensslObject{"CN", "commonName", 13}},
+	{"1.2.840.113549.1.9.1", opensslObject{"emailAddress", "emailAddress", 48}},
+	{"2.5.4.5", opensslObject{"serialNumber", "serialNumber", 105}},
+	{"0.9.2342.19200300.100.1.25", opensslObject{"DC", "domainComponent", 391}},
+	{"0.9.2342.19200300.100.1.1", opensslObject{"UID", "userId", 458}},
+	{"2.5.4.4", opensslObject{"SN", "surname", 100}},
+	{"2.5.4.42", opensslObject{"GN", "givenName", 99}},
+	{"2.5.4.12", opensslObject{"title", "title", 106}},
+	{"2.5.4.43", opensslObject{"initials", "initials", 101}},
+	{"2.5.4.44", opensslObject{"generationQualifier", "generationQualifier", 509}},
+	{"2.5.4.46", opensslObject{"dnQualifier", "dnQualifier", 174}},
+	{"2.5.4.65", opensslObject{"pseudonym", "pseudonym", 510}},
+	{"2.5.4.41", opensslObject{"name", "name", 173}},
+	{"2.5.4.15", opensslObject{"businessCategory", "businessCategory", 860}},
+	{"2.5.4.16", opensslObject{"postalAddress", "postalAddress", 861}},
+	{"2.5.4.18", opensslObject{"postOfficeBox", "postOfficeBox", 862}},
+	{"2.5.4.20", opensslObject{"telephoneNumber", "telephoneNumber", 864}},
+	{"2.5.4.97", opensslObject{"organizationIdentifier", "organizationIdentifier", 1089}},
+	{"1.3.6.1.4.1.311.60.2.1.3", opensslObject{"jurisdictionC", "jurisdictionCountryName", 957}},
+	{"1.3.6.1.4.1.311.60.2.1.2", opensslObject{"jurisdictionST", "jurisdictionStateOrProvinceName", 956}},
+	{"1.3.6.1.4.1.311.60.2.1.1", opensslObject{"jurisdictionL", "jurisdictionLocalityName", 955}},
+	{"1.2.840.113549.1.9.2", opensslObject{"unstructuredName", "unstructuredName", 49}},
+}
+
+// opensslObjects the other objects of certificates by oid
+var opensslObjects = map[string]opensslObject{
+	"1.2.840.113549.1.1.4":    {"RSA-MD5", "md5WithRSAEncryption", 8},
+	"1.2.840.113549.1.1.5":    {"RSA-SHA1", "sha1WithRSAEncryption", 65},
+	"1.2.840.113549.1.1.11":   {"RSA-SHA256", "sha256WithRSAEncryption", 668},
+	"1.2.840.113549.1.1.12":   {"RSA-SHA384", "sha384WithRSAEncryption", 669},
+	"1.2.840.113549.1.1.13":   {"RSA-SHA512", "sha512WithRSAEncryption", 670},
+	"1.2.840.113549.1.1.14":   {"RSA-SHA224", "sha224WithRSAEncryption", 671},
+	"1.2.840.113549.1.1.10":   {"RSASSA-PSS", "rsassaPss", 912},
+	"1.2.840.10045.4.1":       {"ecdsa-with-SHA1", "ecdsa-with-SHA1", 416},
+	"1.2.840.10045.4.3.1":     {"ecdsa-with-SHA224", "ecdsa-with-SHA224", 793},
+	"1.2.840.10045.4.3.2":     {"ecdsa-with-SHA256", "ecdsa-with-SHA256", 794},
+	"1.2.840.10045.4.3.3":     {"ecdsa-with-SHA384", "ecdsa-with-SHA384", 795},
+	"1.2.840.10045.4.3.4":     {"ecdsa-with-SHA512", "ecdsa-with-SHA512", 796},
+	"1.3.101.112":             {"ED25519", "ED25519", 1087},
+	"1.2.840.10040.4.3":       {"DSA-SHA1", "dsaWithSHA1", 113},
+	"2.16.840.1.101.3.4.3.1":  {"dsa_with_SHA224", "dsa_with_SHA224", 802},
+	"2.16.840.1.101.3.4.3.2":  {"dsa_with_SHA256", "dsa_with_SHA256", 803},
+	"2.5.29.19":               {"basicConstraints", "X509v3 Basic Constraints", 87},
+	"2.5.29.15":               {"keyUsage", "X509v3 Key Usage", 83},
+	"2.5.29.37":               {"extendedKeyUsage", "X509v3 Extended Key Usage", 126},
+	"2.5.29.14":               {"subjectKeyIdentifier", "X509v3 Subject Key Identifier", 82},
+	"2.5.29.35":               {"authorityKeyIdentifier", "X509v3 Authority Key Identifier", 90},
+	"2.5.29.17":               {"subjectAltName", "X509v3 Subject Alternative Name", 85},
+	"2.5.29.18":               {"issuerAltName", "X509v3 Issuer Alternative Name", 86},
+	"2.5.29.31":               {"crlDistributionPoints", "X509v3 CRL Distribution Points", 103},
+	"2.5.29.46":               {"freshestCRL", "X509v3 Freshest CRL", 857},
+	"1.3.6.1.5.5.7.1.1":       {"authorityInfoAccess", "Authority Information Access", 177},
+	"1.3.6.1.5.5.7.1.11":      {"subjectInfoAccess", "Subject Information Access", 398},
+	"2.5.29.32":               {"certificatePolicies", "X509v3 Certificate Policies", 89},
+	"2.5.29.30":               {"nameConstraints", "X509v3 Name Constraints", 666},
+	"2.5.29.36":               {"policyConstraints", "X509v3 Policy Constraints", 401},
+	"2.5.29.33":               {"policyMappings", "X509v3 Policy Mappings", 747},
+	"2.5.29.54":               {"inhibitAnyPolicy", "X509v3 Inhibit Any Policy", 748},
+	"2.5.29.16":               {"privateKeyUsagePeriod", "X509v3 Private Key Usage Period", 84},
+	"2.16.840.1.113730.1.1":   {"nsCertType", "Netscape Cert Type", 71},
+	"2.16.840.1.113730.1.13":  {"nsComment", "Netscape Comment", 78},
+	"1.3.6.1.4.1.11129.2.4.2": {"ct_precert_scts", "CT Precertificate SCTs", 951},
+	"1.3.6.1.4.1.11129.2.4.3": {"ct_precert_poison", "CT Precertificate Poison", 952},
+	"1.3.6.1.5.5.7.1.24":      {"tlsfeature", "TLS Feature", 1020},
+	"1.3.6.1.5.5.7.48.1.5":    {"noCheck", "OCSP No Check", 369},
+	"1.3.6.1.5.5.7.3.1":       {"serverAuth", "TLS Web Server Authentication", 129},
+	"1.3.6.1.5.5.7.3.2":       {"clientAuth", "TLS Web Client Authentication", 130},
+	"1.3.6.1.5.5.7.3.3":       {"codeSigning", "Code Signing", 131},
+	"1.3.6.1.5.5.7.3.4":       {"emailProtection", "E-mail Protection", 132},
+	"1.3.6.1.5.5.7.3.5":       {"ipsecEndSystem", "IPSec End System", 294},
+	"1.3.6.1.5.5.7.3.6":       {"ipsecTunnel", "IPSec Tunnel", 295},
+	"1.3.6.1.5.5.7.3.7":       {"ipsecUser", "IPSec User", 296},
+	"1.3.6.1.5.5.7.3.8":       {"timeStamping", "Time Stamping", 133},
+	"1.3.6.1.5.5.7.3.9":       {"OCSPSigning", "OCSP Signing", 180},
+	"1.3.6.1.5.5.7.3.10":      {"DVCS", "dvcs", 297},
+	"1.3.6.1.5.5.7.3.17":      {"ipsecIKE", "ipsec Internet Key Exchange", 1022},
+	"2.5.29.37.0":             {"anyExtendedKeyUsage", "Any Extended Key Usage", 910},
+	"1.3.6.1.4.1.311.2.1.21":  {"msCodeInd", "Microsoft Individual Code Signing", 134},
+	"1.3.6.1.4.1.311.2.1.22":  {"msCodeCom", "Microsoft Commercial Code Signing", 135},
+	"1.3.6.1.4.1.311.10.3.3":  {"msSGC", "Microsoft Server Gated Crypto", 137},
+	"1.3.6.1.4.1.311.10.3.4":  {"msEFS", "Microsoft Encrypted File System", 138},
+	"2.16.840.1.113730.4.1":   {"nsSGC", "Netscape Server Gated Crypto", 139},
+	"1.3.6.1.5.5.7.48.1":      {"OCSP", "OCSP", 178},
+	"1.3.6.1.5.5.7.48.2":      {"caIssuers", "CA Issuers", 179},
+	"2.5.29.32.0":             {"anyPolicy", "X509v3 Any Policy", 746},
+	"1.3.6.1.5.5.7.2.1":       {"id-qt-cps", "Policy Qualifier CPS", 164},
+	"1.3.6.1.5.5.7.2.2":       {"id-qt-unotice", "Policy Qualifier User Notice", 165},
+}
+
+func init() {
+	for _, o := range opensslNameObjects {
+		opensslObjects[o.oid] = o.opensslObject
+	}
+}
+
+// opensslObjectText i2t_ASN1_OBJECT(), the long name of a known object or its oid
+func opensslObjectText(oid asn1.ObjectIdentifier) string {
+	if o, ok := opensslObjects[oid.String()]; ok {
+		return o.ln
+	}
+	return oid.String()
+}
+
+// opensslPurposes the purposes of X509_check_purpose() by id with their short and long names
+var opensslPurposes = []struct {
+	sname, name string
+	check       func(c *x509Flags, ca bool) int
+}{
+	{"sslclient", "SSL client", (*x509Flags).checkSSLClient},
+	{"sslserver", "SSL server", (*x509Flags).checkSSLServer},
+	{"nssslserver", "Netscape SSL server", (*x509Flags).checkNSSSLServer},
+	{"smimesign", "S/MIME signing", (*x509Flags).checkSMIMESign},
+	{"smimeencrypt", "S/MIME encryption", (*x509Flags).checkSMIMEEncrypt},
+	{"crlsign", "CRL signing", (*x509Flags).checkCRLSign},
+	{"any", "Any Purpose", func(*x509Flags, bool) int { return 1 }},
+	{"ocsphelper", "OCSP helper", (*x509Flags).checkOCSPHelper},
+	{"timestampsign", "Time Stamp signing", (*x509Flags).checkTimestampSign},
+}
+
+// OpensslX509Read openssl_x509_read()
+// certificate is an *OpensslCertificate or the PEM of a certificate as a string or in the file of a "file://" path
+func OpensslX509Read(certificate interface{}) (*OpensslCertificate, error) {
+	c, err := opensslCertFrom(certificate)
+	if err != nil {
+		return nil, errors.New("openssl_x509_read(): X.509 Certificate cannot be retrieved")
+	}
+	return c, nil
+}
+
+func opensslCertFrom(certificate interface{}) (*OpensslCertificate, error) {
+	switch c := certificate.(type) {
+	case *OpensslCertificate:
+		if c != nil {
+			return c, nil
+		}
+	case string:
+		data, err := opensslReadParam(c)
+		if err != nil {
+			return nil, err
+		}
+		if block := opensslPEMBlock(data, "CERTIFICATE", "X509 CERTIFICATE", "TRUSTED CERTIFICATE"); block != nil {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return &OpensslCertificate{cert}, nil
+		}
+	}
+	return nil, errors.New("X.509 Certificate cannot be retrieved")
+}
+
+// OpensslX509Export openssl_x509_export()
+// output receives the PEM of the certificate
+func OpensslX509Export(certificate interface{}, output *string) error {
+	c, err := opensslCertFrom(certificate)
+	if err != nil {
+		return errors.New("openssl_x509_export(): X.509 Certificate cannot be retrieved")
+	}
+	*output = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}))
+	return nil
+}
+
+// OpensslX509Parse openssl_x509_parse()
+// The array of PHP: "name", "subject", "hash", "issuer", "version", "serialNumber", "serialNumberHex", "validFrom",
+// "validTo", "validFrom_time_t", "validTo_time_t", "signatureTypeSN", "signatureTypeLN", "signatureTypeNID",
+// "purposes" and "extensions". The subject and issuer keys are the long names when shortNames is false.
+func OpensslX509Parse(certificate interface{}, shortNames ...bool) (map[string]interface{}, error) {
+	c, err := opensslCertFrom(certificate)
+	if err != nil {
+		return nil, errors.New("openssl_x509_parse(): X.509 Certificate cannot be retrieved")
+	}
+	cert := c.cert
+	short := len(shortNames) == 0 || shortNames[0]
+	subject, err := parseX509Name(cert.RawSubject)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := parseX509Name(cert.RawIssuer)
+	if err != nil {
+		return nil, err
+	}
+	var raw struct {
+		TBS       asn1.RawValue
+		Algorithm pkix.AlgorithmIdentifier
+	}
+	if _, err := asn1.Unmarshal(cert.Raw, &raw); err != nil {
+		return nil, err
+	}
+	sig, ok := opensslObjects[raw.Algorithm.Algorithm.String()]
+	if !ok || sig.nid == 0 {
+		sig = opensslObject{"UNDEF", "undefined", 0}
+	}
+
+	flags := newX509Flags(cert)
+	purposes := map[int][]interface{}{}
+	for i, p := range opensslPurposes {
+		name := p.name
+		if short {
+			name = p.sname
+		}
+		purposes[i+1] = []interface{}{p.check(flags, false) != 0, p.check(flags, true) != 0, name}
+	}
+	extensions := map[string]string{}
+	for _, ext := range cert.Extensions {
+		name := ext.Id.String()
+		if o, ok := opensslObjects[name]; ok {
+			name = o.sn
+		}
+		extensions[name] = x509ExtensionText(ext)
+	}
+
+	return map[string]interface{}{
+		"name":             x509NameOneline(subject),
+		"subject":          x509NameArray(subject, short),
+		"hash":             x509NameHash(subject),
+		"issuer":           x509NameArray(issuer, short),
+		"version":          cert.Version - 1,
+		"serialNumber":     asn1IntegerString(cert.SerialNumber),
+		"serialNumberHex":  bnHex(cert.SerialNumber),
+		"validFrom":        asn1TimeString(cert.NotBefore),
+		"validTo":          asn1TimeString(cert.NotAfter),
+		"validFrom_time_t": cert.NotBefore.Unix(),
+		"validTo_time_t":   cert.NotAfter.Unix(),
+		"signatureTypeSN":  sig.sn,
+		"signatureTypeLN":  sig.ln,
+		"signatureTypeNID": sig.nid,
+		"purposes":         purposes,
+		"extensions":       extensions,
+	}, nil
+}
+
+// OpensslCsrNew openssl_csr_new()
+// distinguishedNames maps short names like "CN", long names like "commonName" or oids to a string or a []string,
+// they are added in the order C, ST, L, street, postalCode, O, OU, CN, emailAddress and then the others.
+// A new key is generated with the options of OpensslPkeyNew when *privateKey is nil.
+// The "digest_alg" option is the digest of the signature, sha256 by default.
+func OpensslCsrNew(distinguishedNames map[string]interface{}, privateKey **OpensslKey, options ...map[string]interface{}) (*OpensslCsr, error) {
+	c := newOpensslConfig(options)
+	type entry struct {
+		order int
+		key   string
+		oid   asn1.ObjectIdentifier
+		value interface{}
+	}
+	var entries []entry
+	for key, value := range distinguishedNames {
+		e := entry{order: len(opensslNameObjects), key: key, value: value}
+		for i, o := range opensslNameObjects {
+			if key == o.sn || key == o.ln || key == o.oid {
+				e.order, e.oid = i, parseOID(o.oid)
+			}
+		}
+		if e.oid == nil {
+			if e.oid = parseOID(key); e.oid == nil {
+				return nil, fmt.Errorf("openssl_csr_new(): dn: %s is not a recognized name", key)
+			}
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].order != entries[j].order {
+			return entries[i].order < entries[j].order
+		}
+		return entries[i].key < entries[j].key
+	})
+	var name pkix.RDNSequence
+	for _, e := range entries {
+		values, ok := e.value.([]string)
+		if !ok {
+			values = []string{phpStringVal(e.value)}
+		}
+		for _, v := range values {
+			// the string types of OpenSSL with string_mask = utf8only
+			tag := asn1.TagUTF8String
+			switch e.oid.String() {
+			case "2.5.4.6", "2.5.4.5", "2.5.4.46", "1.3.6.1.4.1.311.60.2.1.3":
+				tag = asn1.TagPrintableString
+			case "1.2.840.113549.1.9.1", "0.9.2342.19200300.100.1.25":
+				tag = asn1.TagIA5String
+			}
+			name = append(name, pkix.RelativeDistinguishedNameSET{{Type: e.oid, Value: asn1.RawValue{Tag: tag, Bytes: []byte(v)}}})
+		}
+	}
+	rawSubject, err := asn1.Marshal(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if privateKey == nil || *privateKey == nil {
+		k, err := c.generateKey("openssl_csr_new")
+		if err != nil {
+			return nil, err
+		}
+		if privateKey != nil {
+			*privateKey = k
+		}
+		privateKey = &k
+	}
+	k := *privateKey
+	if k.private == nil {
+		return nil, errors.New("openssl_csr_new(): Supplied key param is a public key")
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		RawSubject:         rawSubject,
+		SignatureAlgorithm: opensslSignatureAlgorithm(c.digestAlg, k.public),
+	}, k.private)
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, err
+	}
+	return &OpensslCsr{csr}, nil
+}
+
+// OpensslCsrExport openssl_csr_export()
+// output receives the PEM of the certificate signing request
+func OpensslCsrExport(csr interface{}, output *string) error {
+	c, err := opensslCsrFrom(csr)
+	if err != nil {
+		return errors.New("openssl_csr_export(): X.509 Certificate Signing Request cannot be retrieved")
+	}
+	*output = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: c.csr.Raw}))
+	return nil
+}
+
+// OpensslCsrSign openssl_csr_sign()
+// A version 3 certificate of the subject and the public key of csr valid for days, issued by caCertificate
+// or self-signed when it is nil, and signed by privateKey with the "digest_alg" option, sha256 by default
+// OpensslCsrSign(csr, nil, key, 365, nil, 0)
+func OpensslCsrSign(csr, caCertificate, privateKey interface{}, days int, options map[string]interface{}, serial int) (*OpensslCertificate, error) {
+	c, err := opensslCsrFrom(csr)
+	if err != nil {
+		return nil, errors.New("openssl_csr_sign(): Cannot get CSR from parameter 1")
+	}
+	var ca *OpensslCertificate
+	if caCertificate != nil {
+		if ca, err = opensslCertFrom(caCertificate); err != nil {
+			return nil, errors.New("openssl_csr_sign(): Cannot get cert from parameter 2")
+		}
+	}
+	k, err := opensslKeyFrom(privateKey, false)
+	if err != nil {
+		return nil, errors.New("openssl_csr_sign(): Cannot get private key from parameter 3")
+	}
+	if ca != nil && !k.matches(ca.cert.PublicKey) {
+		return nil, errors.New("openssl_csr_sign(): Private key does not correspond to signing cert")
+	}
+	if err := c.csr.CheckSignature(); err != nil {
+		return nil, errors.New("openssl_csr_sign(): Signature did not match the certificate request")
+	}
+	now := time.Now().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber:       big.NewInt(int64(serial)),
+		RawSubject:         c.csr.RawSubject,
+		NotBefore:          now,
+		NotAfter:           now.Add(time.Duration(days) * 24 * time.Hour),
+		SignatureAlgorithm: opensslSignatureAlgorithm(newOpensslConfig([]map[string]interface{}{options}).digestAlg, k.public),
+	}
+	parent := template
+	if ca != nil {
+		parent = ca.cert
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, c.csr.PublicKey, k.private)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &OpensslCertificate{cert}, nil
+}
+
+func opensslCsrFrom(csr interface{}) (*OpensslCsr, error) {
+	switch c := csr.(type) {
+	case *OpensslCsr:
+		if c != nil {
+			return c, nil
+		}
+	case string:
+		data, err := opensslReadParam(c)
+		if err != nil {
+			return nil, err
+		}
+		if block := opensslPEMBlock(data, "CERTIFICATE REQUEST", "NEW CERTIFICATE REQUEST"); block != nil {
+			req, err := x509.ParseCertificateRequest(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			return &OpensslCsr{req}, nil
+		}
+	}
+	return nil, errors.New("X.509 Certificate Signing Request cannot be retrieved")
+}
+
+// opensslSignatureAlgorithm the signature algorithm of the digest and the key, sha1 for unknown digests like PHP
+func opensslSignatureAlgorithm(digest string, pub crypto.PublicKey) x509.SignatureAlgorithm {
+	_, ec := pub.(*ecdsa.PublicKey)
+	switch digest {
+	case "md5":
+		if !ec {
+			return x509.MD5WithRSA
+		}
+	case "sha256":
+		if ec {
+			return x509.ECDSAWithSHA256
+		}
+		return x509.SHA256WithRSA
+	case "sha384":
+		if ec {
+			return x509.ECDSAWithSHA384
+		}
+		return x509.SHA384WithRSA
+	case "sha512":
+		if ec {
+			return x509.ECDSAWithSHA512
+		}
+		return x509.SHA512WithRSA
+	}
+	if ec {
+		return x509.ECDSAWithSHA1
+	}
+	return x509.SHA1WithRSA
+}
+
+func parseOID(s string) asn1.ObjectIdentifier {
+	var oid asn1.ObjectIdentifier
+	for _, arc := range strings.Split(s, ".") {
+		n, ok := new(big.Int).SetString(arc, 10)
+		if !ok || n.Sign() < 0 || !n.IsInt64() {
+			return nil
+		}
+		oid = append(oid, int(n.Int64()))
+	}
+	if len(oid) < 2 {
+		return nil
+	}
+	return oid
+}
+
+type x509Attribute struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue
+}
+
+// x509RDNSET a relative distinguished name with the raw values of its attributes
+type x509RDNSET []x509Attribute
+
+func parseX509Name(der []byte) ([]x509RDNSET, error) {
+	var name []x509RDNSET
+	if _, err := asn1.Unmarshal(der, &name); err != nil {
+		return nil, err
+	}
+	return name, nil
+}
+
+// asn1StringUTF8 ASN1_STRING_to_UTF8(), T61String is taken as Latin-1 like OpenSSL does
+func asn1StringUTF8(v asn1.RawValue) string {
+	switch v.Tag {
+	case asn1.TagT61String:
+		r := make([]rune, len(v.Bytes))
+		for i, b := range v.Bytes {
+			r[i] = rune(b)
+		}
+		return string(r)
+	case asn1.TagBMPString:
+		u := make([]uint16, len(v.Bytes)/2)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(v.Bytes[2*i:])
+		}
+		return string(utf16.Decode(u))
+	case 28: // UniversalString
+		r := make([]rune, len(v.Bytes)/4)
+		for i := range r {
+			r[i] = rune(binary.BigEndian.Uint32(v.Bytes[4*i:]))
+		}
+		return string(r)
+	}
+	return string(v.Bytes)
+}
+
+// x509NameArray the subject and issuer arrays, the values of repeated names are a []string
+func x509NameArray(name []x509RDNSET, short bool) map[string]interface{} {
+	arr := map[string]interface{}{}
+	for _, rdn := range name {
+		for _, atv := range rdn {
+			key := "UNDEF"
+			if !short {
+				key = "undefined"
+			}
+			if o, ok := opensslObjects[atv.Type.String()]; ok {
+				if key = o.sn; !short {
+					key = o.ln
+				}
+			}
+			value := asn1StringUTF8(atv.Value)
+			switch old := arr[key].(type) {
+			case nil:
+				arr[key] = value
+			case string:
+				arr[key] = []string{old, value}
+			case []string:
+				arr[key] = append(old, value)
+			}
+		}
+	}
+	return arr
+}
+
+// x509NameOneline X509_NAME_oneline(), /C=US/O=Example with / and + escaped and the bytes out of the ASCII range as \xHH
+func x509NameOneline(name []x509RDNSET) string {
+	var b strings.Builder
+	for _, rdn := range name {
+		for i, atv := range rdn {
+			if i == 0 {
+				b.WriteByte('/')
+			} else {
+				b.WriteByte('+')
+			}
+			if o, ok := opensslObjects[atv.Type.String()]; ok {
+				b.WriteString(o.sn)
+			} else {
+				b.WriteString(atv.Type.String())
+			}
+			b.WriteByte('=')
+			for _, c := range atv.Value.Bytes {
+				if c < ' ' || c > '~' {
+					fmt.Fprintf(&b, "\\x%02X", c)
+				} else if c == '/' || c == '+' {
+					b.WriteByte('\\')
+					b.WriteByte(c)
+				} else {
+					b.WriteByte(c)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// x509NamePrint X509_NAME_print_ex() with XN_FLAG_ONELINE, C = US, O = "Example, Inc."
+func x509NamePrint(name []x509RDNSET) string {
+	var b strings.Builder
+	for i, rdn := range name {
+		for j, atv := range rdn {
+			if i > 0 && j == 0 {
+				b.WriteString(", ")
+			} else if j > 0 {
+				b.WriteString(" + ")
+			}
+			if o, ok := opensslObjects[atv.Type.String()]; ok {
+				b.WriteString(o.sn)
+			} else {
+				b.WriteString(atv.Type.String())
+			}
+			b.WriteString(" = ")
+			value := asn1StringUTF8(atv.Value)
+			quote := false
+			var v strings.Builder
+			for k := 0; k < len(value); k++ {
+				c := value[k]
+				switch {
+				case c > '~' || c < ' ':
+					fmt.Fprintf(&v, "\\%02X", c)
+				case c == '"' || c == '\\':
+					v.WriteByte('\\')
+					v.WriteByte(c)
+				case strings.IndexByte(",+<>;", c) >= 0, c == ' ' && (k == 0 || k == len(value)-1), c == '#' && k == 0:
+					quote = true
+					v.WriteByte(c)
+				default:
+					v.WriteByte(c)
+				}
+			}
+			if quote {
+				b.WriteString(`"` + v.String() + `"`)
+			} else {
+				b.WriteString(v.String())
+			}
+		}
+	}
+	return b.String()
+}
+
+// x509NameHash X509_subject_name_hash(), the SHA-1 of the canonical encoding of the name, where the
+// strings are lowercase UTF8Strings with the spaces trimmed and collapsed, without the outer SEQUENCE
+func x509NameHash(name []x509RDNSET) string {
+	var canon []byte
+	for _, rdn := range name {
+		var entries [][]byte
+		for _, atv := range rdn {
+			v := atv.Value
+			if v.Class == asn1.ClassUniversal {
+				switch v.Tag {
+				case asn1.TagUTF8String, asn1.TagBMPString, 28, asn1.TagPrintableString, asn1.TagT61String, asn1.TagIA5String, 26:
+					v = asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: []byte(canonicalNameString(asn1StringUTF8(v)))}
+				}
+			}
+			entry, _ := asn1.Marshal(x509Attribute{atv.Type, v})
+			entries = append(entries, entry)
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i], entries[j]) < 0 })
+		set, _ := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: bytes.Join(entries, nil)})
+		canon = append(canon, set...)
+	}
+	sum := sha1.Sum(canon)
+	return fmt.Sprintf("%08x", binary.LittleEndian.Uint32(sum[:4]))
+}
+
+func canonicalNameString(s string) string {
+	isSpace := func(c byte) bool { return c == ' ' || c >= '\t' && c <= '\r' }
+	for len(s) > 0 && isSpace(s[0]) {
+		s = s[1:]
+	}
+	for len(s) > 0 && isSpace(s[len(s)-1]) {
+		s = s[:len(s)-1]
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case isSpace(c):
+			b.WriteByte(' ')
+			for i+1 < len(s) && isSpace(s[i+1]) {
+				i++
+			}
+		case c >= 'A' && c <= 'Z':
+			b.WriteByte(c + 'a' - 'A')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// asn1IntegerString i2s_ASN1_INTEGER(), decimal below 128 bits and 0x prefixed hexadecimal above
+func asn1IntegerString(n *big.Int) string {
+	if n.BitLen() < 128 {
+		return n.String()
+	}
+	if n.Sign() < 0 {
+		return "-0x" + bnHex(new(big.Int).Neg(n))
+	}
+	return "0x" + bnHex(n)
+}
+
+// bnHex BN_bn2hex(), uppercase whole bytes
+func bnHex(n *big.Int) string {
+	if n.Sign() == 0 {
+		return "0"
+	}
+	if n.Sign() < 0 {
+		return "-" + bnHex(new(big.Int).Neg(n))
+	}
+	return fmt.Sprintf("%X", n.Bytes())
+}
+
+// asn1TimeString the UTCTime of the years 1950 to 2049 and the GeneralizedTime of the others like RFC 5280
+func asn1TimeString(t time.Time) string {
+	t = t.UTC()
+	if t.Year() >= 1950 && t.Year() < 2050 {
+		return t.Format("060102150405Z")
+	}
+	return t.Format("20060102150405Z")
+}
+
+// x509Flags the extension flags that the purpose checks of OpenSSL's v3_purp.c look at
+type x509Flags struct {
+	keyUsage, extKeyUsage, nsCertType bool
+	ku                                x509.KeyUsage
+	xku                               int
+	ns                                byte
+	bcons, ca                         bool
+	v1Root                            bool
+	timestampCritical                 bool
+}
+
+// the XKU_* bits of OpenSSL
+const (
+	xkuSSLServer = 1 << iota
+	xkuSSLClient
+	xkuSMIME
+	xkuCodeSign
+	xkuSGC
+	xkuOCSPSign
+	xkuTimestamp
+	xkuDVCS
+	xkuAnyEKU
+)
+
+// the NS_* bits of the Netscape certificate type
+const (
+	nsSSLClient = 0x80
+	nsSSLServer = 0x40
+	nsSMIME     = 0x20
+	nsSSLCA     = 0x04
+	nsSMIMECA   = 0x02
+	nsAnyCA     = 0x07
+)
+
+func newX509Flags(cert *x509.Certificate) *x509Flags {
+	f := &x509Flags{ku: cert.KeyUsage}
+	var akid []byte
+	for _, ext := range cert.Extensions {
+		switch ext.Id.String() {
+		case "2.5.29.15":
+			f.keyUsage = true
+		case "2.5.29.37":
+			f.extKeyUsage = true
+			f.timestampCritical = ext.Critical
+		case "2.5.29.19":
+			f.bcons = true
+			f.ca = cert.IsCA
+		case "2.16.840.1.113730.1.1":
+			var bs asn1.BitString
+			if _, err := asn1.Unmarshal(ext.Value, &bs); err == nil && len(bs.Bytes) > 0 {
+				f.nsCertType, f.ns = true, bs.Bytes[0]
+			}
+		case "2.5.29.35":
+			akid = cert.AuthorityKeyId
+		}
+	}
+	for _, u := range cert.ExtKeyUsage {
+		f.xku |= map[x509.ExtKeyUsage]int{
+			x509.ExtKeyUsageServerAuth:                 xkuSSLServer,
+			x509.ExtKeyUsageClientAuth:                 xkuSSLClient,
+			x509.ExtKeyUsageEmailProtection:            xkuSMIME,
+			x509.ExtKeyUsageCodeSigning:                xkuCodeSign,
+			x509.ExtKeyUsageMicrosoftServerGatedCrypto: xkuSGC,
+			x509.ExtKeyUsageNetscapeServerGatedCrypto:  xkuSGC,
+			x509.ExtKeyUsageOCSPSigning:                xkuOCSPSign,
+			x509.ExtKeyUsageTimeStamping:               xkuTimestamp,
+			x509.ExtKeyUsageAny:                        xkuAnyEKU,
+		}[u]
+	}
+	for _, u := range cert.UnknownExtKeyUsage {
+		if u.Equal(asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 10}) {
+			f.xku |= xkuDVCS
+		}
+	}
+	// a self-signed version 1 certificate
+	selfSigned := bytes.Equal(cert.RawSubject, cert.RawIssuer) &&
+		(akid == nil || cert.SubjectKeyId == nil || bytes.Equal(akid, cert.SubjectKeyId))
+	f.v1Root = cert.Version == 1 && selfSigned
+	return f
+}
+
+func (f *x509Flags) kuReject(usage x509.KeyUsage) bool {
+	return f.keyUsage && f.ku&usage == 0
+}
+
+func (f *x509Flags) xkuReject(usage int) bool {
+	return f.extKeyUsage && f.xku&usage == 0
+}
+
+func (f *x509Flags) nsReject(usage byte) bool {
+	return f.nsCertType && f.ns&usage == 0
+}
+
+func (f *x509Flags) checkCA() int {
+	switch {
+	case f.kuReject(x509.KeyUsageCertSign):
+		return 0
+	case f.bcons:
+		if f.ca {
+			return 1
+		}
+		return 0
+	case f.v1Root:
+		return 3
+	case f.keyUsage:
+		return 4
+	case f.nsCertType && f.ns&nsAnyCA != 0:
+		return 5
+	}
+	return 0
+}
+
+func (f *x509Flags) checkSSLCA() int {
+	ret := f.checkCA()
+	if ret == 0 || ret == 5 && f.ns&nsSSLCA == 0 {
+		return 0
+	}
+	return 1
+}
+
+func (f *x509Flags) checkSSLClient(ca bool) int {
+	if f.xkuReject(xkuSSLClient) {
+		return 0
+	}
+	if ca {
+		return f.checkSSLCA()
+	}
+	if f.kuReject(x509.KeyUsageDigitalSignature|x509.KeyUsageKeyAgreement) || f.nsReject(nsSSLClient) {
+		return 0
+	}
+	return 1
+}
+
+func (f *x509Flags) checkSSLServer(ca bool) int {
+	if f.xkuReject(xkuSSLServer | xkuSGC) {
+		return 0
+	}
+	if ca {
+		return f.checkSSLCA()
+	}
+	if f.nsReject(nsSSLServer) || f.kuReject(x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment|x509.KeyUsageKeyAgreement) {
+		return 0
+	}
+	return 1
+}
+
+func (f *x509Flags) checkNSSSLServer(ca bool) int {
+	ret := f.checkSSLServer(ca)
+	if ret == 0 || ca {
+		return ret
+	}
+	if f.kuReject(x509.KeyUsageKeyEncipherment) {
+		return 0
+	}
+	return ret
+}
+
+func (f *x509Flags) checkSMIME(ca bool) int {
+	if f.xkuReject(xkuSMIME) {
+		return 0
+	}
+	if ca {
+		ret := f.checkCA()
+		if ret == 0 || ret == 5 && f.ns&nsSMIMECA == 0 {
+			return 0
+		}
+		return ret
+	}
+	if f.nsCertType {
+		if f.ns&nsSMIME != 0 {
+			return 1
+		}
+		if f.ns&nsSSLClient != 0 {
+			return 2
+		}
+		return 0
+	}
+	return 1
+}
+
+func (f *x509Flags) checkSMIMESign(ca bool) int {
+	ret := f.checkSMIME(ca)
+	if ret == 0 || ca {
+		return ret
+	}
+	if f.kuReject(x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment) {
+		return 0
+	}
+	return ret
+}
+
+func (f *x509Flags) checkSMIMEEncrypt(ca bool) int {
+	ret := f.checkSMIME(ca)
+	if ret == 0 || ca {
+		return ret
+	}
+	if f.kuReject(x509.KeyUsageKeyEncipherment) {
+		return 0
+	}
+	return ret
+}
+
+func (f *x509Flags) checkCRLSign(ca bool) int {
+	if ca {
+		if ret := f.checkCA(); ret != 2 {
+			return ret
+		}
+		return 0
+	}
+	if f.kuReject(x509.KeyUsageCRLSign) {
+		return 0
+	}
+	return 1
+}
+
+func (f *x509Flags) checkOCSPHelper(ca bool) int {
+	if ca {
+		return f.checkCA()
+	}
+	return 1
+}
+
+func (f *x509Flags) checkTimestampSign(ca bool) int {
+	if ca {
+		return f.checkCA()
+	}
+	signing := x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment
+	if f.keyUsage && (f.ku&^signing != 0 || f.ku&signing == 0) {
+		return 0
+	}
+	if !f.extKeyUsage || f.xku != xkuTimestamp || !f.timestampCritical {
+		return 0
+	}
+	return 1
+}
+
+// x509ExtensionText the extension as X509V3_EXT_print() prints it, and as ASN1_STRING_print() prints
+// the raw value of the unknown ones. The subjectAltName is printed like PHP does.
+func x509ExtensionText(ext pkix.Extension) string {
+	var s string
+	var err error
+	switch ext.Id.String() {
+	case "2.5.29.19":
+		var bc struct {
+			CA      bool `asn1:"optional"`
+			PathLen int  `asn1:"optional,default:-1"`
+		}
+		if _, err = asn1.Unmarshal(ext.Value, &bc); err == nil {
+			s = "CA:FALSE"
+			if bc.CA {
+				s = "CA:TRUE"
+			}
+			if bc.PathLen >= 0 {
+				s += fmt.Sprintf(", pathlen:%d", bc.PathLen)
+			}
+		}
+	case "2.5.29.15":
+		s, err = x509BitNames(ext.Value, []string{"Digital Signature", "Non Repudiation", "Key Encipherment",
+			"Data Encipherment", "Key Agreement", "Certificate Sign", "CRL Sign", "Encipher Only", "Decipher Only"})
+	case "2.16.840.1.113730.1.1":
+		s, err = x509BitNames(ext.Value, []string{"SSL Client", "SSL Server", "S/MIME", "Object Signing",
+			"Unused", "SSL CA", "S/MIME CA", "Object Signing CA"})
+	case "2.5.29.37":
+		var oids []asn1.ObjectIdentifier
+		if _, err = asn1.Unmarshal(ext.Value, &oids); err == nil {
+			values := make([]string, len(oids))
+			for i, oid := range oids {
+				values[i] = opensslObjectText(oid)
+			}
+			s = x509Values(values, false)
+		}
+	case "2.5.29.14":
+		var id []byte
+		if _, err = asn1.Unmarshal(ext.Value, &id); err == nil {
+			s = x509Hex(id)
+		}
+	case "2.16.840.1.113730.1.13":
+		var comment asn1.RawValue
+		if _, err = asn1.Unmarshal(ext.Value, &comment); err == nil {
+			s = string(comment.Bytes)
+		}
+	case "2.5.29.35":
+		s, err = x509AuthorityKeyID(ext.Value)
+	case "2.5.29.17":
+		var names []asn1.RawValue
+		if names, err = x509GeneralNames(ext.Value); err == nil {
+			values := make([]string, len(names))
+			for i, gn := range names {
+				switch gn.Tag {
+				case 1:
+					values[i] = "email:" + string(gn.Bytes)
+				case 2:
+					values[i] = "DNS:" + string(gn.Bytes)
+				case 6:
+					values[i] = "URI:" + string(gn.Bytes)
+				default:
+					values[i] = x509GeneralNamePrint(gn)
+				}
+			}
+			s = strings.Join(values, ", ")
+		}
+	case "2.5.29.18":
+		var names []asn1.RawValue
+		if names, err = x509GeneralNames(ext.Value); err == nil {
+			values := make([]string, len(names))
+			for i, gn := range names {
+				values[i] = x509GeneralNameValue(gn)
+			}
+			s = x509Values(values, false)
+		}
+	case "1.3.6.1.5.5.7.1.1", "1.3.6.1.5.5.7.1.11":
+		var access []struct {
+			Method   asn1.ObjectIdentifier
+			Location asn1.RawValue
+		}
+		if _, err = asn1.Unmarshal(ext.Value, &access); err == nil {
+			values := make([]string, len(access))
+			for i, a := range access {
+				values[i] = opensslObjectText(a.Method) + " - " + x509GeneralNameValue(a.Location)
+			}
+			s = x509Values(values, true)
+		}
+	case "2.5.29.31", "2.5.29.46":
+		s, err = x509DistributionPoints(ext.Value)
+	case "2.5.29.32":
+		s, err = x509Policies(ext.Value)
+	default:
+		err = errors.New("unsupported extension")
+	}
+	if err != nil {
+		b := []byte(ext.Value)
+		for i, c := range b {
+			if c != '\n' && c != '\r' && (c < ' ' || c > '~') {
+				b[i] = '.'
+			}
+		}
+		return string(b)
+	}
+	return s
+}
+
+// x509Values X509V3_EXT_val_prn(), the values on a line or on a line each
+func x509Values(values []string, multiline bool) string {
+	if len(values) == 0 {
+		return "<EMPTY>\n"
+	}
+	if multiline {
+		return strings.Join(values, "\n")
+	}
+	return strings.Join(values, ", ")
+}
+
+func x509BitNames(der []byte, names []string) (string, error) {
+	var bs asn1.BitString
+	if _, err := asn1.Unmarshal(der, &bs); err != nil {
+		return "", err
+	}
+	var values []string
+	for i, name := range names {
+		if bs.At(i) == 1 {
+			values = append(values, name)
+		}
+	}
+	return x509Values(values, false), nil
+}
+
+// x509Hex the uppercase hex bytes separated by colons of OPENSSL_buf2hexstr()
+func x509Hex(b []byte) string {
+	hex := make([]string, len(b))
+	for i, c := range b {
+		hex[i] = fmt.Sprintf("%02X", c)
+	}
+	return strings.Join(hex, ":")
+}
+
+// x509GeneralNames the GeneralName elements of a GeneralNames sequence
+func x509GeneralNames(der []byte) ([]asn1.RawValue, error) {
+	var seq asn1.RawValue
+	if _, err := asn1.Unmarshal(der, &seq); err != nil {
+		return nil, err
+	}
+	return x509Elements(seq.Bytes)
+}
+
+func x509Elements(b []byte) ([]asn1.RawValue, error) {
+	var elements []asn1.RawValue
+	for len(b) > 0 {
+		var v asn1.RawValue
+		var err error
+		if b, err = asn1.Unmarshal(b, &v); err != nil {
+			return nil, err
+		}
+		elements = append(elements, v)
+	}
+	return elements, nil
+}
+
+func x509IPAddress(ip []byte) string {
+	switch len(ip) {
+	case 4:
+		return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
+	case 16:
+		groups := make([]string, 8)
+		for i := range groups {
+			groups[i] = fmt.Sprintf("%X", binary.BigEndian.Uint16(ip[2*i:]))
+		}
+		return strings.Join(groups, ":")
+	}
+	return fmt.Sprintf("<invalid length=%d>", len(ip))
+}
+
+func x509RegisteredID(b []byte) string {
+	var oid asn1.ObjectIdentifier
+	der, _ := asn1.Marshal(asn1.RawValue{Tag: asn1.TagOID, Bytes: b})
+	if _, err := asn1.Unmarshal(der, &oid); err != nil {
+		return "<invalid>"
+	}
+	return opensslObjectText(oid)
+}
+
+// x509GeneralNameValue the name:value of i2v_GENERAL_NAME()
+func x509GeneralNameValue(gn asn1.RawValue) string {
+	switch gn.Tag {
+	case 1:
+		return "email:" + string(gn.Bytes)
+	case 2:
+		return "DNS:" + string(gn.Bytes)
+	case 6:
+		return "URI:" + string(gn.Bytes)
+	case 4:
+		if name, err := parseX509Name(gn.Bytes); err == nil {
+			return "DirName:" + x509NameOneline(name)
+		}
+	case 7:
+		return "IP Address:" + x509IPAddress(gn.Bytes)
+	case 8:
+		return "Registered ID:" + x509RegisteredID(gn.Bytes)
+	case 3:
+		return "X400Name:<unsupported>"
+	case 5:
+		return "EdiPartyName:<unsupported>"
+	}
+	return "othername:<unsupported>"
+}
+
+// x509GeneralNamePrint GENERAL_NAME_print()
+func x509GeneralNamePrint(gn asn1.RawValue) string {
+	if gn.Tag == 4 {
+		if name, err := parseX509Name(gn.Bytes); err == nil {
+			return "DirName:" + x509NamePrint(name)
+		}
+	}
+	return x509GeneralNameValue(gn)
+}
+
+func x509AuthorityKeyID(der []byte) (string, error) {
+	var seq asn1.RawValue
+	if _, err := asn1.Unmarshal(der, &seq); err != nil {
+		return "", err
+	}
+	fields, err := x509Elements(seq.Bytes)
+	if err != nil {
+		return "", err
+	}
+	var values []string
+	for _, f := range fields {
+		switch f.Tag {
+		case 0:
+			if len(fields) > 1 {
+				values = append(values, "keyid:"+x509Hex(f.Bytes))
+			} else {
+				values = append(values, x509Hex(f.Bytes))
+			}
+		case 1:
+			names, err := x509Elements(f.Bytes)
+			if err != nil {
+				return "", err
+			}
+			for _, gn := range names {
+				values = append(values, x509GeneralNameValue(gn))
+			}
+		case 2:
+			values = append(values, "serial:"+x509Hex(new(big.Int).SetBytes(f.Bytes).Bytes()))
+		}
+	}
+	return x509Values(values, true), nil
+}
+
+func x509DistributionPoints(der []byte) (string, error) {
+	var points []asn1.RawValue
+	var seq asn1.RawValue
+	if _, err := asn1.Unmarshal(der, &seq); err != nil {
+		return "", err
+	}
+	points, err := x509Elements(seq.Bytes)
+	if err != nil {
+		return "", err
+	}
+	printNames := func(b *strings.Builder, der []byte) error {
+		names, err := x509Elements(der)
+		for i, gn := range names {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("  " + x509GeneralNamePrint(gn))
+		}
+		return err
+	}
+	var b strings.Builder
+	for i, point := range points {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fields, err := x509Elements(point.Bytes)
+		if err != nil {
+			return "", err
+		}
+		for _, f := range fields {
+			switch f.Tag {
+			case 0:
+				var dpn asn1.RawValue
+				if _, err := asn1.Unmarshal(f.Bytes, &dpn); err != nil {
+					return "", err
+				}
+				if dpn.Tag == 0 {
+					b.WriteString("Full Name:\n")
+					if err := printNames(&b, dpn.Bytes); err != nil {
+						return "", err
+					}
+				} else {
+					var rdn x509RDNSET
+					der, _ := asn1.Marshal(asn1.RawValue{Tag: asn1.TagSet, IsCompound: true, Bytes: dpn.Bytes})
+					if _, err := asn1.Unmarshal(der, &rdn); err != nil {
+						return "", err
+					}
+					b.WriteString("Relative Name:\n  " + x509NamePrint([]x509RDNSET{rdn}) + "\n")
+				}
+			case 1:
+				der, _ := asn1.Marshal(asn1.RawValue{Tag: asn1.TagBitString, Bytes: f.Bytes})
+				reasons, err := x509BitNames(der, []string{"Unused", "Key Compromise", "CA Compromise", "Affiliation Changed",
+					"Superseded", "Cessation Of Operation", "Certificate Hold", "Privilege Withdrawn", "AA Compromise"})
+				if err != nil {
+					return "", err
+				}
+				if reasons != "<EMPTY>\n" {
+					reasons += "\n"
+				}
+				b.WriteString("Reasons:\n  " + reasons)
+			case 2:
+				b.WriteString("CRL Issuer:\n")
+				if err := printNames(&b, f.Bytes); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+func x509Policies(der []byte) (string, error) {
+	var policies []struct {
+		ID         asn1.ObjectIdentifier
+		Qualifiers []struct {
+			ID        asn1.ObjectIdentifier
+			Qualifier asn1.RawValue
+		} `asn1:"optional"`
+	}
+	if _, err := asn1.Unmarshal(der, &policies); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, p := range policies {
+		b.WriteString("Policy: " + opensslObjectText(p.ID))
+		if len(p.Qualifiers) > 0 {
+			b.WriteString("\n")
+		}
+		for j, q := range p.Qualifiers {
+			if j > 0 {
+				b.WriteString("\n")
+			}
+			switch q.ID.String() {
+			case "1.3.6.1.5.5.7.2.1":
+				b.WriteString("  CPS: " + string(q.Qualifier.Bytes))
+			case "1.3.6.1.5.5.7.2.2":
+				b.WriteString("  User Notice:\n")
+				fields, err := x509Elements(q.Qualifier.Bytes)
+				if err != nil {
+					return "", err
+				}
+				for _, f := range fields {
+					if f.Tag != asn1.TagSequence {
+						b.WriteString("    Explicit Text: " + string(f.Bytes))
+						continue
+					}
+					var ref struct {
+						Organization asn1.RawValue
+						Numbers      []*big.Int
+					}
+					if _, err := asn1.Unmarshal(f.FullBytes, &ref); err != nil {
+						return "", err
+					}
+					plural := ""
+					if len(ref.Numbers) > 1 {
+						plural = "s"
+					}
+					numbers := make([]string, len(ref.Numbers))
+					for k, n := range ref.Numbers {
+						numbers[k] = asn1IntegerString(n)
+					}
+					b.WriteString("    Organization: " + string(ref.Organization.Bytes) + "\n")
+					b.WriteString("    Number" + plural + ": " + strings.Join(numbers, ", "))
+					if len(fields) > 1 {
+						b.WriteString("\n")
+					}
+				}
+			default:
+				b.WriteString("    Unknown Qualifier: " + opensslObjectText(q.ID))
+			}
+		}
+		if i < len(policies)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,314 @@
+package php2go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	// OutputHandlerStart PHP_OUTPUT_HANDLER_START, the first call of the callback
+	OutputHandlerStart = 1
+	// OutputHandlerWrite PHP_OUTPUT_HANDLER_WRITE, the buffer reached the chunk size
+	OutputHandlerWrite = 0
+	// OutputHandlerCont PHP_OUTPUT_HANDLER_CONT
+	OutputHandlerCont = OutputHandlerWrite
+	// OutputHandlerClean PHP_OUTPUT_HANDLER_CLEAN, the buffer is discarded
+	OutputHandlerClean = 2
+	// OutputHandlerFlush PHP_OUTPUT_HANDLER_FLUSH
+	OutputHandlerFlush = 4
+	// OutputHandlerFinal PHP_OUTPUT_HANDLER_FINAL, the buffer is removed
+	OutputHandlerFinal = 8
+	// OutputHandlerEnd PHP_OUTPUT_HANDLER_END
+	OutputHandlerEnd = OutputHandlerFinal
+)
+
+// Output an output buffer stack in front of a writer, Echo and the printf family write to it.
+// The package level functions use the one in front of os.Stdout,
+// give each request its own with NewOutput and WithOutput so concurrent requests do not mix their output:
+//
+//	o := NewOutput(w) // w http.ResponseWriter
+//	defer o.Close()
+//	o.ObStart(nil, 0)
+//	o.Echo("hello")
+//	s, _ := o.ObGetClean()
+type Output struct {
+	mu            sync.Mutex
+	w             io.Writer
+	buffers       []*outputBuffer
+	implicitFlush bool
+}
+
+type outputBuffer struct {
+	buf       []byte
+	callback  func(buffer string, phase int) string
+	chunkSize int
+	started   bool
+}
+
+type outputContextKey struct{}
+
+// stdoutWriter writes to os.Stdout as it is at the time of the write, tests and wrappers may replace it
+type stdoutWriter struct{}
+
+func (stdoutWriter) Write(p []byte) (int, error) {
+	return os.Stdout.Write(p)
+}
+
+var stdOutput = NewOutput(stdoutWriter{})
+
+// NewOutput an Output writing to w
+func NewOutput(w io.Writer) *Output {
+	return &Output{w: w}
+}
+
+// WithOutput a copy of ctx carrying o
+func WithOutput(ctx context.Context, o *Output) context.Context {
+	return context.WithValue(ctx, outputContextKey{}, o)
+}
+
+// OutputFromContext the Output carried by ctx, the one in front of os.Stdout when there is none
+func OutputFromContext(ctx context.Context) *Output {
+	if o, ok := ctx.Value(outputContextKey{}).(*Output); ok {
+		return o
+	}
+	return stdOutput
+}
+
+// Write writes p to the active output buffer, or to the underlying writer when there is none
+func (o *Output) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.write(len(o.buffers), p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Echo echo
+func (o *Output) Echo(args ...interface{}) {
+	fmt.Fprint(o, args...)
+}
+
+// Printf printf()
+// Returns the length of the outputted string
+func (o *Output) Printf(format string, args ...interface{}) (int, error) {
+	s, err := phpSprintf(format, args, 1)
+	if err != nil {
+		return 0, err
+	}
+	return io.WriteString(o, s)
+}
+
+// Vprintf vprintf()
+func (o *Output) Vprintf(format string, values []interface{}) (int, error) {
+	s, err := phpSprintf(format, values, -1)
+	if err != nil {
+		return 0, err
+	}
+	return io.WriteString(o, s)
+}
+
+// ObStart ob_start()
+// callback, when not nil, receives the buffer and a bitmask of the OutputHandler constants
+// and returns what is passed on. It must not write to the Output itself.
+// With a chunkSize greater than 0 the buffer is flushed after any output making it at least that long.
+func (o *Output) ObStart(callback func(buffer string, phase int) string, chunkSize int) bool {
+	if chunkSize < 0 {
+		chunkSize = 0
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.buffers = append(o.buffers, &outputBuffer{callback: callback, chunkSize: chunkSize})
+	return true
+}
+
+// ObGetContents ob_get_contents()
+// false when output buffering isn't active
+func (o *Output) ObGetContents() (string, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.buffers) == 0 {
+		return "", false
+	}
+	return string(o.buffers[len(o.buffers)-1].buf), true
+}
+
+// ObGetLength ob_get_length()
+func (o *Output) ObGetLength() (int, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.buffers) == 0 {
+		return 0, false
+	}
+	return len(o.buffers[len(o.buffers)-1].buf), true
+}
+
+// ObGetLevel ob_get_level()
+func (o *Output) ObGetLevel() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.buffers)
+}
+
+// ObFlush ob_flush()
+// Passes the contents of the active buffer through its callback to the level below and empties it
+func (o *Output) ObFlush() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.buffers) == 0 {
+		return false
+	}
+	level := len(o.buffers)
+	return o.write(level-1, []byte(o.handle(o.buffers[level-1], OutputHandlerFlush))) == nil
+}
+
+// ObClean ob_clean()
+// Empties the active buffer
+func (o *Output) ObClean() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.buffers) == 0 {
+		return false
+	}
+	o.handle(o.buffers[len(o.buffers)-1], OutputHandlerClean)
+	return true
+}
+
+// ObEndFlush ob_end_flush()
+// Passes the contents of the active buffer through its callback to the level below and removes it
+func (o *Output) ObEndFlush() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.end(true) == nil
+}
+
+// ObEndClean ob_end_clean()
+// Removes the active buffer, discarding its contents
+func (o *Output) ObEndClean() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.end(false) == nil
+}
+
+// ObGetFlush ob_get_flush()
+// The contents of the active buffer, then ObEndFlush
+func (o *Output) ObGetFlush() (string, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.buffers) == 0 {
+		return "", false
+	}
+	s := string(o.buffers[len(o.buffers)-1].buf)
+	o.end(true)
+	return s, true
+}
+
+// ObGetClean ob_get_clean()
+// The contents of the active buffer, then ObEndClean
+func (o *Output) ObGetClean() (string, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.buffers) == 0 {
+		return "", false
+	}
+	s := string(o.buffers[len(o.buffers)-1].buf)
+	o.end(false)
+	return s, true
+}
+
+// ObImplicitFlush ob_implicit_flush()
+// Flush the underlying writer after every write that reaches it
+func (o *Output) ObImplicitFlush(enable bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.implicitFlush = enable
+}
+
+// Flush flush()
+// Flushes the underlying writer when it is an http.Flusher or has a Flush() error method like bufio.Writer
+func (o *Output) Flush() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.flush()
+}
+
+// Close flushes and removes all the output buffers, like PHP does at the end of the script
+func (o *Output) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var err error
+	for len(o.buffers) > 0 {
+		if e := o.end(true); e != nil && err == nil {
+			err = e
+		}
+	}
+	if e := o.flush(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+// write writes p at level, 0 being the underlying writer
+func (o *Output) write(level int, p []byte) error {
+	if level == 0 {
+		if len(p) == 0 {
+			return nil
+		}
+		if _, err := o.w.Write(p); err != nil {
+			return err
+		}
+		if o.implicitFlush {
+			return o.flush()
+		}
+		return nil
+	}
+	b := o.buffers[level-1]
+	b.buf = append(b.buf, p...)
+	if b.chunkSize > 0 && len(b.buf) >= b.chunkSize {
+		return o.write(level-1, []byte(o.handle(b, OutputHandlerWrite)))
+	}
+	return nil
+}
+
+// handle empties b and returns its contents passed through the callback
+func (o *Output) handle(b *outputBuffer, phase int) string {
+	s := string(b.buf)
+	b.buf = b.buf[:0]
+	if b.callback == nil {
+		return s
+	}
+	if !b.started {
+		b.started = true
+		phase |= OutputHandlerStart
+	}
+	return b.callback(s, phase)
+}
+
+// end removes the active buffer, its contents are passed to the level below when flush is true
+func (o *Output) end(flush bool) error {
+	level := len(o.buffers)
+	if level == 0 {
+		return errors.New("Failed to delete buffer. No buffer to delete")
+	}
+	b := o.buffers[level-1]
+	o.buffers = o.buffers[:level-1]
+	if !flush {
+		o.handle(b, OutputHandlerClean|OutputHandlerFinal)
+		return nil
+	}
+	return o.write(level-1, []byte(o.handle(b, OutputHandlerFinal)))
+}
+
+func (o *Output) flush() error {
+	switch w := o.w.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case interface{ Flush() }:
+		w.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package php2go
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// PasswordDefault PASSWORD_DEFAULT
+	PasswordDefault = PasswordBcrypt
+	// PasswordBcrypt PASSWORD_BCRYPT
+	PasswordBcrypt = "2y"
+	// PasswordArgon2i PASSWORD_ARGON2I
+	PasswordArgon2i = "argon2i"
+	// PasswordArgon2id PASSWORD_ARGON2ID
+	PasswordArgon2id = "argon2id"
+
+	// PasswordBcryptDefaultCost PASSWORD_BCRYPT_DEFAULT_COST, 12 since PHP 8.4
+	PasswordBcryptDefaultCost = 12
+	// PasswordArgon2DefaultMemoryCost PASSWORD_ARGON2_DEFAULT_MEMORY_COST in KiB
+	PasswordArgon2DefaultMemoryCost = 65536
+	// PasswordArgon2DefaultTimeCost PASSWORD_ARGON2_DEFAULT_TIME_COST
+	PasswordArgon2DefaultTimeCost = 4
+	// PasswordArgon2DefaultThreads PASSWORD_ARGON2_DEFAULT_THREADS
+	PasswordArgon2DefaultThreads = 1
+)
+
+// PasswordInfo the result of PasswordGetInfo, Algo is "" for unknown hashes
+type PasswordInfo struct {
+	Algo     string
+	AlgoName string
+	Options  map[string]int
+}
+
+// argon2Params the parameters of an encoded argon2 hash
+type argon2Params struct {
+	algo                 string
+	version              int
+	memoryCost, timeCost uint32
+	threads              uint8
+	salt, hash           []byte
+}
+
+// PasswordHash password_hash()
+// algo is PasswordBcrypt with the "cost" option, or PasswordArgon2i and PasswordArgon2id with
+// the "memory_cost", "time_cost" and "threads" options. The salt is random.
+// PasswordHash("rasmuslerdorf", PasswordDefault, nil), PasswordHash("rasmuslerdorf", PasswordBcrypt, map[string]int{"cost": 10})
+func PasswordHash(password, algo string, options map[string]int) (string, error) {
+	switch algo {
+	case PasswordBcrypt:
+		cost := passwordOption(options, "cost", PasswordBcryptDefaultCost)
+		if cost < 4 || cost > 31 {
+			return "", fmt.Errorf("Invalid bcrypt cost parameter specified: %d", cost)
+		}
+		if strings.IndexByte(password, 0) >= 0 {
+			return "", errors.New("Bcrypt password must not contain null character")
+		}
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		hashed, _ := bcryptCrypt(password, fmt.Sprintf("$2y$%02d$%s", cost, bcryptEncoding.EncodeToString(salt)))
+		return hashed, nil
+	case PasswordArgon2i, PasswordArgon2id:
+		p := argon2Params{
+			algo:    algo,
+			version: argon2.Version,
+		}
+		memoryCost := passwordOption(options, "memory_cost", PasswordArgon2DefaultMemoryCost)
+		if memoryCost < 8 || int64(memoryCost) > math.MaxUint32 {
+			return "", errors.New("Memory cost is outside of allowed memory range")
+		}
+		timeCost := passwordOption(options, "time_cost", PasswordArgon2DefaultTimeCost)
+		if timeCost < 1 || int64(timeCost) > math.MaxUint32 {
+			return "", errors.New("Time cost is outside of allowed time range")
+		}
+		threads := passwordOption(options, "threads", PasswordArgon2DefaultThreads)
+		if threads < 1 || threads > 255 {
+			return "", errors.New("Invalid number of threads")
+		}
+		p.memoryCost, p.timeCost, p.threads = uint32(memoryCost), uint32(timeCost), uint8(threads)
+		p.salt = make([]byte, 16)
+		if _, err := rand.Read(p.salt); err != nil {
+			return "", err
+		}
+		p.hash = p.key(password, 32)
+		return p.String(), nil
+	}
+	return "", errors.New("password_hash(): Argument #2 ($algo) must be a valid password hashing algorithm")
+}
+
+// PasswordVerify password_verify()
+// Works for the hashes of PasswordHash and of Crypt
+func PasswordVerify(password, hash string) bool {
+	if p, ok := parseArgon2(hash); ok {
+		return subtle.ConstantTimeCompare(p.hash, p.key(password, uint32(len(p.hash)))) == 1
+	}
+	hashed := Crypt(password, hash)
+	if len(hash) < 13 || len(hashed) != len(hash) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashed), []byte(hash)) == 1
+}
+
+// PasswordGetInfo password_get_info()
+func PasswordGetInfo(hash string) PasswordInfo {
+	if passwordIsBcrypt(hash) {
+		cost, _ := strconv.Atoi(hash[4:6])
+		return PasswordInfo{Algo: PasswordBcrypt, AlgoName: "bcrypt", Options: map[string]int{"cost": cost}}
+	}
+	if p, ok := parseArgon2(hash); ok {
+		return PasswordInfo{Algo: p.algo, AlgoName: p.algo, Options: map[string]int{
+			"memory_cost": int(p.memoryCost),
+			"time_cost":   int(p.timeCost),
+			"threads":     int(p.threads),
+		}}
+	}
+	return PasswordInfo{AlgoName: "unknown", Options: map[string]int{}}
+}
+
+// PasswordNeedsRehash password_needs_rehash()
+// Whether hash was not made by algo with options
+func PasswordNeedsRehash(hash, algo string, options map[string]int) bool {
+	info := PasswordGetInfo(hash)
+	if info.Algo != algo {
+		return true
+	}
+	switch algo {
+	case PasswordBcrypt:
+		return info.Options["cost"] != passwordOption(options, "cost", PasswordBcryptDefaultCost)
+	case PasswordArgon2i, PasswordArgon2id:
+		return info.Options["memory_cost"] != passwordOption(options, "memory_cost", PasswordArgon2DefaultMemoryCost) ||
+			info.Options["time_cost"] != passwordOption(options, "time_cost", PasswordArgon2DefaultTimeCost) ||
+			info.Options["threads"] != passwordOption(options, "threads", PasswordArgon2DefaultThreads)
+	}
+	return true
+}
+
+// PasswordAlgos password_algos()
+func PasswordAlgos() []string {
+	return []string{PasswordBcrypt, PasswordArgon2i, PasswordArgon2id}
+}
+
+func passwordOption(options map[string]int, name string, def int) int {
+	if v, ok := options[name]; ok {
+		return v
+	}
+	return def
+}
+
+// passwordIsBcrypt php_password_bcrypt_valid(), only $2y$ hashes are identified as bcrypt
+func passwordIsBcrypt(hash string) bool {
+	return len(hash) == 60 && strings.HasPrefix(hash, "$2y$")
+}
+
+// parseArgon2 $argon2id$v=19$m=65536,t=4,p=1$c2FsdHNhbHQ$aGFzaA, a missing version is 16
+func parseArgon2(hash string) (argon2Params, bool) {
+	var p argon2Params
+	parts := strings.Split(hash, "$")
+	if len(parts) < 5 || parts[0] != "" || parts[1] != PasswordArgon2i && parts[1] != PasswordArgon2id {
+		return p, false
+	}
+	p.algo, parts = parts[1], parts[2:]
+	p.version = 0x10
+	if strings.HasPrefix(parts[0], "v=") {
+		v, err := strconv.Atoi(parts[0][2:])
+		if err != nil {
+			return p, false
+		}
+		p.version, parts = v, parts[1:]
+	}
+	if len(parts) != 3 {
+		return p, false
+	}
+	var m, t, threads uint64
+	for i, param := range strings.Split(parts[0], ",") {
+		var err error
+		switch {
+		case i == 0 && strings.HasPrefix(param, "m="):
+			m, err = strconv.ParseUint(param[2:], 10, 32)
+		case i == 1 && strings.HasPrefix(param, "t="):
+			t, err = strconv.ParseUint(param[2:], 10, 32)
+		case i == 2 && strings.HasPrefix(param, "p="):
+			threads, err = strconv.ParseUint(param[2:], 10, 8)
+		default:
+			return p, false
+		}
+		if err != nil {
+			return p, false
+		}
+	}
+	if m == 0 || t == 0 || threads == 0 {
+		return p, false
+	}
+	p.memoryCost, p.timeCost, p.threads = uint32(m), uint32(t), uint8(threads)
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[1]); err != nil {
+		return p, false
+	}
+	if p.hash, err = base64.RawStdEncoding.DecodeString(parts[2]); err != nil || len(p.hash) == 0 {
+		return p, false
+	}
+	return p, true
+}
+
+// key the argon2 key of password, only the version 19 is supported
+func (p argon2Params) key(password string, length uint32) []byte {
+	if p.version != argon2.Version {
+		return nil
+	}
+	if p.algo == PasswordArgon2id {
+		return argon2.IDKey([]byte(password), p.salt, p.timeCost, p.memoryCost, p.threads, length)
+	}
+	return argon2.Key([]byte(password), p.salt, p.timeCost, p.memoryCost, p.threads, length)
+}
+
+// String the encoded format of PHP and libargon2
+func (p argon2Params) String() string {
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", p.algo, p.version, p.memoryCost, p.timeCost, p.threads,
+		base64.RawStdEncoding.EncodeToString(p.salt), base64.RawStdEncoding.EncodeToString(p.hash))
+}
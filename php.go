@@ -13,9 +13,9 @@ import (
 	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/crc32"
-	"html"
 	"io"
 	"io/ioutil"
 	"math"
@@ -28,6 +28,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"syscall"
@@ -43,22 +44,225 @@ func Time() int64 {
 	return time.Now().Unix()
 }
 
-// Strtotime strtotime()
-// Strtotime("02/01/2006 15:04:05", "02/01/2016 15:04:05") == 1451747045
-// Strtotime("3 04 PM", "8 41 PM") == -62167144740
-func Strtotime(format, strtime string) (int64, error) {
-	t, err := time.Parse(format, strtime)
-	if err != nil {
-		return 0, err
+// Date date()
+// Date("d/m/Y H:i:s A", 1524799394)
+// Supports all of php's format characters, see DateFormat.
+// Times are in loc when given, the default timezone otherwise, see DateDefaultTimezoneSet.
+func Date(format string, timestamp int64, loc ...*time.Location) string {
+	return DateFormat(time.Unix(timestamp, 0).In(dateLocation(loc)), format)
+}
+
+// Gmdate gmdate()
+func Gmdate(format string, timestamp int64) string {
+	return DateFormat(time.Unix(timestamp, 0).UTC(), format)
+}
+
+// Idate idate()
+// format is one character: B d h H i I L m N o s t U w W y Y z Z
+func Idate(format string, timestamp int64, loc ...*time.Location) (int, error) {
+	if len(format) != 1 || !strings.Contains("BdhHiILmNostUwWyYzZ", format) {
+		return 0, errors.New("idate format is one char")
+	}
+	if format == "y" {
+		return time.Unix(timestamp, 0).In(dateLocation(loc)).Year() % 100, nil
+	}
+	return strconv.Atoi(Date(format, timestamp, loc...))
+}
+
+// Getdate getdate()
+// keys: seconds, minutes, hours, mday, wday, mon, year, yday, weekday, month, 0
+func Getdate(timestamp int64, loc ...*time.Location) map[string]interface{} {
+	t := time.Unix(timestamp, 0).In(dateLocation(loc))
+	return map[string]interface{}{
+		"seconds": t.Second(),
+		"minutes": t.Minute(),
+		"hours":   t.Hour(),
+		"mday":    t.Day(),
+		"wday":    int(t.Weekday()),
+		"mon":     int(t.Month()),
+		"year":    t.Year(),
+		"yday":    t.YearDay() - 1,
+		"weekday": t.Weekday().String(),
+		"month":   t.Month().String(),
+		"0":       timestamp,
+	}
+}
+
+// Localtime localtime()
+// isAssociative: keys are tm_sec, tm_min, tm_hour, tm_mday, tm_mon, tm_year, tm_wday, tm_yday, tm_isdst,
+// otherwise "0" to "8" in the same order.
+func Localtime(timestamp int64, isAssociative bool, loc ...*time.Location) map[string]int {
+	t := time.Unix(timestamp, 0).In(dateLocation(loc))
+	isdst := 0
+	if t.IsDST() {
+		isdst = 1
+	}
+	keys := []string{"tm_sec", "tm_min", "tm_hour", "tm_mday", "tm_mon", "tm_year", "tm_wday", "tm_yday", "tm_isdst"}
+	values := []int{t.Second(), t.Minute(), t.Hour(), t.Day(), int(t.Month()) - 1, t.Year() - 1900, int(t.Weekday()), t.YearDay() - 1, isdst}
+	ret := make(map[string]int, len(keys))
+	for i, v := range values {
+		if isAssociative {
+			ret[keys[i]] = v
+		} else {
+			ret[strconv.Itoa(i)] = v
+		}
 	}
-	return t.Unix(), nil
+	return ret
 }
 
-// Date date()
-// Date("02/01/2006 15:04:05 PM", 1524799394)
-// Note: the behavior is inconsistent with php's date function
-func Date(format string, timestamp int64) string {
-	return time.Unix(timestamp, 0).Format(format)
+// Strftime strftime()
+// Strftime("%Y-%m-%d %H:%M:%S", 1524799394)
+func Strftime(format string, timestamp int64, loc ...*time.Location) string {
+	return dateStrftime(time.Unix(timestamp, 0).In(dateLocation(loc)), format)
+}
+
+// Gmstrftime gmstrftime()
+func Gmstrftime(format string, timestamp int64) string {
+	return dateStrftime(time.Unix(timestamp, 0).UTC(), format)
+}
+
+// DateParse date_parse()
+// Returns year, month, day, hour, minute, second, fraction (false when not found), warnings, errors,
+// the timezone and the relative part when present.
+func DateParse(datetime string) map[string]interface{} {
+	return dateParseString(datetime).result()
+}
+
+// Microtime microtime()
+// getAsFloat false: string "msec sec", true: float64
+func Microtime(getAsFloat bool) interface{} {
+	now := time.Now()
+	if getAsFloat {
+		return float64(now.UnixNano()) / 1e9
+	}
+	return fmt.Sprintf("%.8f %d", float64(now.Nanosecond()/1000)/1e6, now.Unix())
+}
+
+var hrtimeStart = time.Now()
+
+// Hrtime hrtime()
+// asNumber true: int64 nanoseconds, false: []int64{seconds, nanoseconds}
+// The time is measured from an arbitrary point, with a monotonic clock.
+func Hrtime(asNumber bool) interface{} {
+	ns := time.Since(hrtimeStart).Nanoseconds()
+	if asNumber {
+		return ns
+	}
+	return []int64{ns / 1e9, ns % 1e9}
+}
+
+// Mktime mktime()
+// Out of range values are normalized, mktime(0, 0, 0, 13, 1, 2020) is 2021-01-01.
+// Years 0-69 map to 2000-2069 and 70-100 to 1970-2000.
+func Mktime(hour, minute, second, month, day, year int, loc ...*time.Location) int64 {
+	return mktime(hour, minute, second, month, day, year, dateLocation(loc))
+}
+
+// Gmmktime gmmktime()
+func Gmmktime(hour, minute, second, month, day, year int) int64 {
+	return mktime(hour, minute, second, month, day, year, time.UTC)
+}
+
+func mktime(hour, minute, second, month, day, year int, loc *time.Location) int64 {
+	if year >= 0 && year < 70 {
+		year += 2000
+	} else if year >= 70 && year <= 100 {
+		year += 1900
+	}
+	return time.Date(year, time.Month(month), day, hour, minute, second, 0, loc).Unix()
+}
+
+const (
+	// SunFuncsRetTimestamp SUNFUNCS_RET_TIMESTAMP
+	SunFuncsRetTimestamp = 0
+	// SunFuncsRetString SUNFUNCS_RET_STRING
+	SunFuncsRetString = 1
+	// SunFuncsRetDouble SUNFUNCS_RET_DOUBLE
+	SunFuncsRetDouble = 2
+	// DateSunriseZenith the default zenith of date_sunrise() and date_sunset(), date.sunrise_zenith
+	DateSunriseZenith = 90.833333
+)
+
+// DateSunInfo date_sun_info()
+// keys: sunrise, sunset, transit, civil_twilight_begin, civil_twilight_end, nautical_twilight_begin,
+// nautical_twilight_end, astronomical_twilight_begin, astronomical_twilight_end.
+// Values are int64 timestamps, or true/false when the sun is always above/below for the whole day.
+// The day of timestamp is taken in the default timezone.
+func DateSunInfo(timestamp int64, latitude, longitude float64) map[string]interface{} {
+	t := time.Unix(timestamp, 0).In(dateLocation(nil))
+	ret := make(map[string]interface{}, 9)
+	for _, v := range []struct {
+		altitude   float64
+		begin, end string
+	}{
+		{-50.0 / 60, "sunrise", "sunset"},
+		{-6, "civil_twilight_begin", "civil_twilight_end"},
+		{-12, "nautical_twilight_begin", "nautical_twilight_end"},
+		{-18, "astronomical_twilight_begin", "astronomical_twilight_end"},
+	} {
+		_, _, rise, set, transit, rc := dateAstroRiseSet(t, longitude, latitude, v.altitude, false)
+		switch rc {
+		case -1:
+			ret[v.begin], ret[v.end] = false, false
+		case 1:
+			ret[v.begin], ret[v.end] = true, true
+		default:
+			ret[v.begin], ret[v.end] = rise, set
+		}
+		if v.begin == "sunrise" {
+			ret["transit"] = transit
+		}
+	}
+	return ret
+}
+
+// DateSunrise date_sunrise()
+// returnFormat SunFuncsRetString: "06:21", SunFuncsRetDouble: 6.35, SunFuncsRetTimestamp: int64.
+// The result is false when the sun does not rise that day.
+// zenith is usually DateSunriseZenith, utcOffset in hours defaults to the offset of the default timezone.
+func DateSunrise(timestamp int64, returnFormat int, latitude, longitude, zenith float64, utcOffset ...float64) (interface{}, error) {
+	return dateSunriseSunset(timestamp, returnFormat, latitude, longitude, zenith, utcOffset, false)
+}
+
+// DateSunset date_sunset()
+// See DateSunrise
+func DateSunset(timestamp int64, returnFormat int, latitude, longitude, zenith float64, utcOffset ...float64) (interface{}, error) {
+	return dateSunriseSunset(timestamp, returnFormat, latitude, longitude, zenith, utcOffset, true)
+}
+
+func dateSunriseSunset(timestamp int64, returnFormat int, latitude, longitude, zenith float64, utcOffset []float64, sunset bool) (interface{}, error) {
+	if returnFormat != SunFuncsRetTimestamp && returnFormat != SunFuncsRetString && returnFormat != SunFuncsRetDouble {
+		return nil, errors.New("returnFormat must be one of SunFuncsRetTimestamp, SunFuncsRetString, or SunFuncsRetDouble")
+	}
+	t := time.Unix(timestamp, 0).In(dateLocation(nil))
+	var offset float64
+	if len(utcOffset) > 0 {
+		offset = utcOffset[0]
+	} else {
+		_, seconds := t.Zone()
+		offset = float64(seconds / 3600)
+	}
+	hRise, hSet, rise, set, _, rc := dateAstroRiseSet(t, longitude, latitude, 90-zenith, true)
+	if rc != 0 {
+		return false, nil
+	}
+	if returnFormat == SunFuncsRetTimestamp {
+		if sunset {
+			return set, nil
+		}
+		return rise, nil
+	}
+	n := hRise + offset
+	if sunset {
+		n = hSet + offset
+	}
+	if n > 24 || n < 0 {
+		n -= math.Floor(n/24) * 24
+	}
+	if returnFormat == SunFuncsRetDouble {
+		return n, nil
+	}
+	return fmt.Sprintf("%02d:%02d", int(n), int(60*(n-float64(int(n))))), nil
 }
 
 // Checkdate checkdate()
@@ -176,6 +380,147 @@ func StrReplace(search, replace, subject string, count int) string {
 	return strings.Replace(subject, search, replace, count)
 }
 
+// StrReplaceArray str_replace()
+// search and replace are a string or an array, subject is a string, an array or a map[string]string or
+// map[string]interface{} associative array and the result has its type, a []string for the arrays of numbers.
+// The values of an associative array are replaced and its keys kept, other types are an error.
+// With an array search, its elements are replaced in order by the elements of replace with the same index,
+// "" when replace is shorter, or all by replace when it is a string.
+// count, when not nil, receives the number of replacements.
+// StrReplaceArray([]string{"a", "b"}, []string{"b", "c"}, "ab", nil) = "cc"
+func StrReplaceArray(search, replace, subject interface{}, count *int) (interface{}, error) {
+	return strReplace("str_replace", search, replace, subject, count, false)
+}
+
+// StrIreplace str_ireplace()
+// The case-insensitive StrReplaceArray
+func StrIreplace(search, replace, subject interface{}, count *int) (interface{}, error) {
+	return strReplace("str_ireplace", search, replace, subject, count, true)
+}
+
+func strReplace(fn string, search, replace, subject interface{}, count *int, caseInsensitive bool) (interface{}, error) {
+	searches, searchIsArray := phpArray(search)
+	replaces, replaceIsArray := phpArray(replace)
+	if !searchIsArray && !phpScalar(search) {
+		return nil, fmt.Errorf("%s(): Argument #1 ($search) must be of type array|string, %T given", fn, search)
+	}
+	if !replaceIsArray && !phpScalar(replace) {
+		return nil, fmt.Errorf("%s(): Argument #2 ($replace) must be of type array|string, %T given", fn, replace)
+	}
+	if !searchIsArray {
+		if replaceIsArray {
+			return nil, fmt.Errorf("%s(): Argument #2 ($replace) must be of type string when argument #1 ($search) is a string", fn)
+		}
+		searches = []interface{}{search}
+	}
+	pairs := make([][2]string, len(searches))
+	for i, s := range searches {
+		pairs[i][0] = phpStringVal(s)
+		if !replaceIsArray {
+			pairs[i][1] = phpStringVal(replace)
+		} else if i < len(replaces) {
+			pairs[i][1] = phpStringVal(replaces[i])
+		}
+	}
+	total := 0
+	replaceIn := func(str string) string {
+		for _, pair := range pairs {
+			if pair[0] == "" || str == "" {
+				continue
+			}
+			var n int
+			if caseInsensitive {
+				str, n = strIreplace(str, pair[0], pair[1])
+			} else {
+				n = strings.Count(str, pair[0])
+				str = strings.ReplaceAll(str, pair[0], pair[1])
+			}
+			total += n
+		}
+		return str
+	}
+
+	var result interface{}
+	switch v := subject.(type) {
+	case []string, []int, []int64, []float64:
+		// the elements are converted to strings like in PHP
+		values, _ := phpArray(v)
+		strs := make([]string, len(values))
+		for i, value := range values {
+			strs[i] = replaceIn(phpStringVal(value))
+		}
+		result = strs
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, value := range v {
+			// nested arrays are kept as is
+			if phpScalar(value) {
+				values[i] = replaceIn(phpStringVal(value))
+			} else {
+				values[i] = value
+			}
+		}
+		result = values
+	case map[string]string:
+		// the associative arrays keep their keys
+		strs := make(map[string]string, len(v))
+		for k, value := range v {
+			strs[k] = replaceIn(value)
+		}
+		result = strs
+	case map[string]interface{}:
+		values := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			if phpScalar(value) {
+				values[k] = replaceIn(phpStringVal(value))
+			} else {
+				values[k] = value
+			}
+		}
+		result = values
+	default:
+		if !phpScalar(subject) {
+			return nil, fmt.Errorf("%s(): Argument #3 ($subject) must be of type array|string, %T given", fn, subject)
+		}
+		result = replaceIn(phpStringVal(subject))
+	}
+	if count != nil {
+		*count = total
+	}
+	return result, nil
+}
+
+// strIreplace replaces the case-insensitive occurrences of search, with their number
+func strIreplace(str, search, replace string) (string, int) {
+	lower := func(s string) string {
+		b := []byte(s)
+		for i, c := range b {
+			if 'A' <= c && c <= 'Z' {
+				b[i] = c + 'a' - 'A'
+			}
+		}
+		return string(b)
+	}
+	haystack, needle := lower(str), lower(search)
+	var b strings.Builder
+	n, i := 0, 0
+	for {
+		j := strings.Index(haystack[i:], needle)
+		if j < 0 {
+			break
+		}
+		b.WriteString(str[i : i+j])
+		b.WriteString(replace)
+		i += j + len(needle)
+		n++
+	}
+	if n == 0 {
+		return str, 0
+	}
+	b.WriteString(str[i:])
+	return b.String(), n
+}
+
 // Strtoupper strtoupper()
 func Strtoupper(str string) string {
 	return strings.ToUpper(str)
@@ -227,6 +572,89 @@ func Substr(str string, start uint, length int) string {
 	return str[start:end]
 }
 
+// SubstrReplace substr_replace()
+// str and replace are a string or an array, offset and length an int or an array, length nil to replace until the end.
+// A negative offset counts from the end of the string, a negative length stops that many bytes before the end.
+// With an array str, the result is an array and the arrays replace, offset and length apply to the element with the same index.
+// SubstrReplace("Hello World", "PHP", -5, nil) = "Hello PHP"
+func SubstrReplace(str, replace, offset, length interface{}) (interface{}, error) {
+	replaces, replaceIsArray := phpArray(replace)
+	offsets, offsetIsArray := phpArray(offset)
+	lengths, lengthIsArray := phpArray(length)
+	strs, strIsArray := phpArray(str)
+	if !strIsArray {
+		if offsetIsArray {
+			return nil, errors.New("substr_replace(): Argument #3 ($offset) cannot be an array when working on a single string")
+		}
+		if lengthIsArray {
+			return nil, errors.New("substr_replace(): Argument #4 ($length) cannot be an array when working on a single string")
+		}
+		repl := ""
+		if !replaceIsArray {
+			repl = phpStringVal(replace)
+		} else if len(replaces) > 0 {
+			repl = phpStringVal(replaces[0])
+		}
+		s := phpStringVal(str)
+		l := len(s)
+		if length != nil {
+			l = int(phpIntVal(length))
+		}
+		return substrReplace(s, repl, int(phpIntVal(offset)), l), nil
+	}
+
+	result := make([]interface{}, len(strs))
+	for i, value := range strs {
+		s := phpStringVal(value)
+		f, l, repl := 0, len(s), ""
+		if !offsetIsArray {
+			f = int(phpIntVal(offset))
+		} else if i < len(offsets) {
+			f = int(phpIntVal(offsets[i]))
+		}
+		if lengthIsArray {
+			if i < len(lengths) {
+				l = int(phpIntVal(lengths[i]))
+			}
+		} else if length != nil {
+			l = int(phpIntVal(length))
+		}
+		if !replaceIsArray {
+			repl = phpStringVal(replace)
+		} else if i < len(replaces) {
+			repl = phpStringVal(replaces[i])
+		}
+		result[i] = substrReplace(s, repl, f, l)
+	}
+	if _, ok := str.([]string); ok {
+		strs := make([]string, len(result))
+		for i, s := range result {
+			strs[i] = s.(string)
+		}
+		return strs, nil
+	}
+	return result, nil
+}
+
+func substrReplace(str, replace string, offset, length int) string {
+	if offset < 0 {
+		if offset += len(str); offset < 0 {
+			offset = 0
+		}
+	} else if offset > len(str) {
+		offset = len(str)
+	}
+	if length < 0 {
+		if length += len(str) - offset; length < 0 {
+			length = 0
+		}
+	}
+	if offset+length > len(str) {
+		length = len(str) - offset
+	}
+	return str[:offset] + replace + str[offset+length:]
+}
+
 // Strrev strrev()
 func Strrev(str string) string {
 	runes := []rune(str)
@@ -431,6 +859,58 @@ func NumberFormat(number float64, decimals uint, decPoint, thousandsSep string)
 	return s
 }
 
+// Sprintf sprintf()
+// Sprintf("%'*10s|%-10s|%+d|%u", "php", "go", 5, -1), Sprintf("%2$s %1$s", "world", "hello")
+// Arguments are converted like PHP does: Sprintf("%d", "12abc") = "12", Sprintf("%s", 0.1+0.2) = "0.3".
+func Sprintf(format string, args ...interface{}) (string, error) {
+	return phpSprintf(format, args, 1)
+}
+
+// Printf printf()
+// Returns the length of the outputted string
+func Printf(format string, args ...interface{}) (int, error) {
+	return stdOutput.Printf(format, args...)
+}
+
+// Vsprintf vsprintf()
+func Vsprintf(format string, values []interface{}) (string, error) {
+	return phpSprintf(format, values, -1)
+}
+
+// Vprintf vprintf()
+func Vprintf(format string, values []interface{}) (int, error) {
+	return stdOutput.Vprintf(format, values)
+}
+
+// Fprintf fprintf()
+// Returns the length of the string written to w
+func Fprintf(w io.Writer, format string, args ...interface{}) (int, error) {
+	s, err := phpSprintf(format, args, 2)
+	if err != nil {
+		return 0, err
+	}
+	return io.WriteString(w, s)
+}
+
+// Sscanf sscanf()
+// Without vars the values are returned as a []interface{} of int, float64 and string, nil for the missing ones.
+// With pointers in vars the values are assigned to them and the number of assigned values is returned.
+// -1 is returned when str ends before the first conversion.
+// Sscanf("age: 25 name: Tom", "age: %d name: %s"), Sscanf("12 apples", "%d %s", &n, &s)
+func Sscanf(str, format string, vars ...interface{}) (interface{}, error) {
+	return phpSscanf(str, format, vars)
+}
+
+// Fscanf fscanf()
+// Parses the next line of r like Sscanf, io.EOF when there is no line left
+func Fscanf(r io.Reader, format string, vars ...interface{}) (interface{}, error) {
+	line, err := scanfReadLine(r)
+	if err != nil {
+		return nil, err
+	}
+	return phpSscanf(line, format, vars)
+}
+
 // ChunkSplit chunk_split()
 func ChunkSplit(body string, chunklen uint, end string) string {
 	if end == "" {
@@ -524,6 +1004,349 @@ func StrRepeat(input string, multiplier int) string {
 	return strings.Repeat(input, multiplier)
 }
 
+// Strcmp strcmp()
+// -1, 0 or 1 like PHP 8.2
+func Strcmp(string1, string2 string) int {
+	return strings.Compare(string1, string2)
+}
+
+// Strcasecmp strcasecmp()
+// Binary safe case-insensitive comparison of the ASCII letters
+func Strcasecmp(string1, string2 string) int {
+	return strncasecmp(string1, string2, len(string1)+len(string2))
+}
+
+// Strncmp strncmp()
+// Compares at most the first length bytes
+func Strncmp(string1, string2 string, length int) (int, error) {
+	if length < 0 {
+		return 0, errors.New("strncmp(): Argument #3 ($length) must be greater than or equal to 0")
+	}
+	return strncmp(string1, string2, length), nil
+}
+
+// Strncasecmp strncasecmp()
+func Strncasecmp(string1, string2 string, length int) (int, error) {
+	if length < 0 {
+		return 0, errors.New("strncasecmp(): Argument #3 ($length) must be greater than or equal to 0")
+	}
+	return strncasecmp(string1, string2, length), nil
+}
+
+// strncmp zend_binary_strncmp()
+func strncmp(string1, string2 string, length int) int {
+	if len(string1) > length {
+		string1 = string1[:length]
+	}
+	if len(string2) > length {
+		string2 = string2[:length]
+	}
+	return strings.Compare(string1, string2)
+}
+
+// strncasecmp zend_binary_strncasecmp()
+func strncasecmp(string1, string2 string, length int) int {
+	for i := 0; i < length && i < len(string1) && i < len(string2); i++ {
+		c1, c2 := string1[i], string2[i]
+		if 'A' <= c1 && c1 <= 'Z' {
+			c1 += 'a' - 'A'
+		}
+		if 'A' <= c2 && c2 <= 'Z' {
+			c2 += 'a' - 'A'
+		}
+		if c1 != c2 {
+			if c1 < c2 {
+				return -1
+			}
+			return 1
+		}
+	}
+	len1, len2 := len(string1), len(string2)
+	if len1 > length {
+		len1 = length
+	}
+	if len2 > length {
+		len2 = length
+	}
+	switch {
+	case len1 < len2:
+		return -1
+	case len1 > len2:
+		return 1
+	}
+	return 0
+}
+
+// Strnatcmp strnatcmp()
+// Natural order comparison: "img12.png" > "img10.png" > "img2.png"
+func Strnatcmp(string1, string2 string) int {
+	return strnatcmp(string1, string2, false)
+}
+
+// Strnatcasecmp strnatcasecmp()
+func Strnatcasecmp(string1, string2 string) int {
+	return strnatcmp(string1, string2, true)
+}
+
+// strnatcmp strnatcmp_ex()
+func strnatcmp(a, b string, caseInsensitive bool) int {
+	if len(a) == 0 || len(b) == 0 {
+		switch {
+		case len(a) == len(b):
+			return 0
+		case len(a) > len(b):
+			return 1
+		}
+		return -1
+	}
+	at := func(s string, i int) byte {
+		if i < len(s) {
+			return s[i]
+		}
+		return 0
+	}
+	isSpace := func(c byte) bool {
+		return c == ' ' || '\t' <= c && c <= '\r'
+	}
+	ai, bi := 0, 0
+	for leading := true; ; leading = false {
+		ca, cb := at(a, ai), at(b, bi)
+
+		// skip over leading zeros
+		for leading && ca == '0' && ai+1 < len(a) && isDigit(a[ai+1]) {
+			ai++
+			ca = a[ai]
+		}
+		for leading && cb == '0' && bi+1 < len(b) && isDigit(b[bi+1]) {
+			bi++
+			cb = b[bi]
+		}
+
+		// skip consecutive whitespace
+		for isSpace(ca) {
+			ai++
+			ca = at(a, ai)
+		}
+		for isSpace(cb) {
+			bi++
+			cb = at(b, bi)
+		}
+
+		// process run of digits
+		if isDigit(ca) && isDigit(cb) {
+			var result int
+			if ca == '0' || cb == '0' {
+				result = strnatcmpLeft(a, b, &ai, &bi)
+			} else {
+				result = strnatcmpRight(a, b, &ai, &bi)
+			}
+			switch {
+			case result != 0:
+				return result
+			case ai == len(a) && bi == len(b):
+				return 0
+			case ai == len(a):
+				return -1
+			case bi == len(b):
+				return 1
+			}
+			ca, cb = a[ai], b[bi]
+		}
+
+		if caseInsensitive {
+			if 'a' <= ca && ca <= 'z' {
+				ca -= 'a' - 'A'
+			}
+			if 'a' <= cb && cb <= 'z' {
+				cb -= 'a' - 'A'
+			}
+		}
+		if ca < cb {
+			return -1
+		} else if ca > cb {
+			return 1
+		}
+
+		ai++
+		bi++
+		switch {
+		case ai >= len(a) && bi >= len(b):
+			return 0
+		case ai >= len(a):
+			return -1
+		case bi >= len(b):
+			return 1
+		}
+	}
+}
+
+// strnatcmpRight compare_right(), the longest run of digits wins, then the greatest value
+func strnatcmpRight(a, b string, ai, bi *int) int {
+	bias := 0
+	for ; ; *ai, *bi = *ai+1, *bi+1 {
+		aDigit := *ai < len(a) && isDigit(a[*ai])
+		bDigit := *bi < len(b) && isDigit(b[*bi])
+		switch {
+		case !aDigit && !bDigit:
+			return bias
+		case !aDigit:
+			return -1
+		case !bDigit:
+			return 1
+		case bias == 0 && a[*ai] < b[*bi]:
+			bias = -1
+		case bias == 0 && a[*ai] > b[*bi]:
+			bias = 1
+		}
+	}
+}
+
+// strnatcmpLeft compare_left(), fractional parts: the first different digit wins
+func strnatcmpLeft(a, b string, ai, bi *int) int {
+	for ; ; *ai, *bi = *ai+1, *bi+1 {
+		aDigit := *ai < len(a) && isDigit(a[*ai])
+		bDigit := *bi < len(b) && isDigit(b[*bi])
+		switch {
+		case !aDigit && !bDigit:
+			return 0
+		case !aDigit:
+			return -1
+		case !bDigit:
+			return 1
+		case a[*ai] < b[*bi]:
+			return -1
+		case a[*ai] > b[*bi]:
+			return 1
+		}
+	}
+}
+
+// Strcoll strcoll()
+// Compares with the C locale, like Strcmp
+func Strcoll(string1, string2 string) int {
+	return strings.Compare(string1, string2)
+}
+
+// SubstrCompare substr_compare()
+// Compares haystack from offset with needle, at most length bytes, until the end of the longest when length is nil.
+// A negative offset counts from the end of haystack.
+func SubstrCompare(haystack, needle string, offset int, length *int, caseInsensitive bool) (int, error) {
+	if length != nil && *length <= 0 {
+		if *length == 0 {
+			return 0, nil
+		}
+		return 0, errors.New("substr_compare(): Argument #4 ($length) must be greater than or equal to 0")
+	}
+	if offset < 0 {
+		if offset += len(haystack); offset < 0 {
+			offset = 0
+		}
+	}
+	if offset > len(haystack) {
+		return 0, errors.New("substr_compare(): Argument #3 ($offset) must be contained in argument #1 ($haystack)")
+	}
+	cmpLen := len(haystack) - offset
+	if length != nil {
+		cmpLen = *length
+	} else if len(needle) > cmpLen {
+		cmpLen = len(needle)
+	}
+	if caseInsensitive {
+		return strncasecmp(haystack[offset:], needle, cmpLen), nil
+	}
+	return strncmp(haystack[offset:], needle, cmpLen), nil
+}
+
+// Strspn strspn()
+// The length of the initial segment of str[offset:offset+length] made of characters.
+// A negative offset counts from the end, a negative length stops that many bytes before the end.
+func Strspn(str, characters string, offset int, length ...int) int {
+	str = strspnSegment(str, offset, length)
+	for i := 0; i < len(str); i++ {
+		if strings.IndexByte(characters, str[i]) < 0 {
+			return i
+		}
+	}
+	return len(str)
+}
+
+// Strcspn strcspn()
+// The length of the initial segment of str[offset:offset+length] without characters.
+func Strcspn(str, characters string, offset int, length ...int) int {
+	str = strspnSegment(str, offset, length)
+	for i := 0; i < len(str); i++ {
+		if strings.IndexByte(characters, str[i]) >= 0 {
+			return i
+		}
+	}
+	return len(str)
+}
+
+// strspnSegment the part of str php_spn_common_handler() looks at
+func strspnSegment(str string, offset int, length []int) string {
+	if offset < 0 {
+		if offset += len(str); offset < 0 {
+			offset = 0
+		}
+	} else if offset > len(str) {
+		offset = len(str)
+	}
+	str = str[offset:]
+	if len(length) > 0 {
+		l := length[0]
+		if l < 0 {
+			if l += len(str); l < 0 {
+				l = 0
+			}
+		} else if l > len(str) {
+			l = len(str)
+		}
+		str = str[:l]
+	}
+	return str
+}
+
+// Strpbrk strpbrk()
+// str from the first occurrence of any of characters, "" when there is none
+func Strpbrk(str, characters string) (string, error) {
+	if characters == "" {
+		return "", errors.New("strpbrk(): Argument #2 ($characters) must be a non-empty string")
+	}
+	for i := 0; i < len(str); i++ {
+		if strings.IndexByte(characters, str[i]) >= 0 {
+			return str[i:], nil
+		}
+	}
+	return "", nil
+}
+
+// SubstrCount substr_count()
+// The number of non overlapping occurrences of needle in haystack[offset:offset+length].
+// A negative offset counts from the end, a negative length stops that many bytes before the end.
+func SubstrCount(haystack, needle string, offset int, length ...int) (int, error) {
+	if needle == "" {
+		return 0, errors.New("substr_count(): Argument #2 ($needle) cannot be empty")
+	}
+	if offset < 0 {
+		offset += len(haystack)
+	}
+	if offset < 0 || offset > len(haystack) {
+		return 0, errors.New("substr_count(): Argument #3 ($offset) must be contained in argument #1 ($haystack)")
+	}
+	haystack = haystack[offset:]
+	if len(length) > 0 {
+		l := length[0]
+		if l < 0 {
+			l += len(haystack)
+		}
+		if l < 0 || l > len(haystack) {
+			return 0, errors.New("substr_count(): Argument #4 ($length) must be contained in argument #1 ($haystack)")
+		}
+		haystack = haystack[:l]
+	}
+	return strings.Count(haystack, needle), nil
+}
+
 // Strstr strstr()
 func Strstr(haystack string, needle string) string {
 	if needle == "" {
@@ -740,14 +1563,73 @@ func Quotemeta(str string) string {
 	return buf.String()
 }
 
+// Htmlspecialchars htmlspecialchars()
+// options are the flags int and doubleEncode bool, PHP's defaults EntQuotes | EntSubstitute | EntHTML401 and true when omitted.
+// flags: EntQuotes, EntCompat or EntNoQuotes, EntHTML401, EntXML1, EntXHTML or EntHTML5, EntIgnore, EntSubstitute, EntDisallowed.
+// Without doubleEncode the existing entities are not encoded again.
+// Invalid UTF-8 gives "" unless EntIgnore or EntSubstitute is set.
+func Htmlspecialchars(str string, options ...interface{}) string {
+	flags, doubleEncode := htmlOptions(options)
+	return htmlEscape(str, flags, false, doubleEncode)
+}
+
+// HtmlspecialcharsDecode htmlspecialchars_decode()
+func HtmlspecialcharsDecode(str string, flags ...int) string {
+	return htmlUnescape(str, htmlFlags(flags), false)
+}
+
 // Htmlentities htmlentities()
-func Htmlentities(str string) string {
-	return html.EscapeString(str)
+// Like Htmlspecialchars, with all the named entities of the document type of flags.
+// Htmlentities("café <b>", EntQuotes|EntSubstitute|EntHTML401, true) = "caf&eacute; &lt;b&gt;"
+func Htmlentities(str string, options ...interface{}) string {
+	flags, doubleEncode := htmlOptions(options)
+	return htmlEscape(str, flags, true, doubleEncode)
 }
 
 // HTMLEntityDecode html_entity_decode()
-func HTMLEntityDecode(str string) string {
-	return html.UnescapeString(str)
+// The named entities of the document type of flags and the numeric entities are decoded.
+func HTMLEntityDecode(str string, flags ...int) string {
+	return htmlUnescape(str, htmlFlags(flags), true)
+}
+
+// htmlFlags the flags argument, EntQuotes | EntSubstitute | EntHTML401 by default
+func htmlFlags(flags []int) int {
+	if len(flags) > 0 {
+		return flags[0]
+	}
+	return EntQuotes | EntSubstitute | EntHTML401
+}
+
+// htmlOptions the flags int and doubleEncode bool arguments of Htmlspecialchars and Htmlentities
+func htmlOptions(options []interface{}) (flags int, doubleEncode bool) {
+	flags, doubleEncode = htmlFlags(nil), true
+	if len(options) > 0 {
+		flags = options[0].(int)
+	}
+	if len(options) > 1 {
+		doubleEncode = options[1].(bool)
+	}
+	return flags, doubleEncode
+}
+
+// GetHTMLTranslationTable get_html_translation_table()
+// table: HTMLSpecialchars or HTMLEntities, the keys are the characters and the values their entities
+func GetHTMLTranslationTable(table, flags int) map[string]string {
+	return htmlTranslationTable(table, flags)
+}
+
+// StripTags strip_tags()
+// allowedTags are the tags to keep, like "<a><b>" or "a", "b".
+// HTML comments, PHP code and the tags, including malformed or unterminated ones, are removed.
+func StripTags(str string, allowedTags ...string) string {
+	var allow strings.Builder
+	for _, tag := range allowedTags {
+		if !strings.HasPrefix(tag, "<") {
+			tag = "<" + tag + ">"
+		}
+		allow.WriteString(strings.ToLower(tag))
+	}
+	return stripTags(str, allow.String())
 }
 
 // Md5 md5()
@@ -973,6 +1855,27 @@ func Soundex(str string) string {
 	return string(sd)
 }
 
+// Metaphone metaphone()
+// The metaphone key of str, maxPhonemes limits the number of phonemes, 0 means no limit.
+// Metaphone("Thompson") = "0MPSN", TH is 0 (theta) and P is only silent before H
+func Metaphone(str string, maxPhonemes ...int) (string, error) {
+	max := 0
+	if len(maxPhonemes) > 0 {
+		max = maxPhonemes[0]
+	}
+	if max < 0 {
+		return "", errors.New("metaphone(): Argument #2 ($max_phonemes) must be greater than or equal to 0")
+	}
+	return metaphone(str, max), nil
+}
+
+// DoubleMetaphone double_metaphone()
+// The primary and secondary Double Metaphone keys of str, at most 4 characters each.
+// DoubleMetaphone("Smith") = "SM0", "XMT"
+func DoubleMetaphone(str string) (primary, secondary string) {
+	return newDoubleMetaphone(str).encode()
+}
+
 //////////// URL Functions ////////////
 
 // ParseURL parse_url()
@@ -1343,6 +2246,21 @@ func InArray(needle interface{}, haystack interface{}) bool {
 	return false
 }
 
+// Natsort natsort()
+// Sorts s in natural order, in place
+func Natsort(s []string) {
+	sort.SliceStable(s, func(i, j int) bool {
+		return Strnatcmp(s[i], s[j]) < 0
+	})
+}
+
+// Natcasesort natcasesort()
+func Natcasesort(s []string) {
+	sort.SliceStable(s, func(i, j int) bool {
+		return Strnatcasecmp(s[i], s[j]) < 0
+	})
+}
+
 //////////// Mathematical Functions ////////////
 
 // Abs abs()
@@ -2079,11 +2997,75 @@ func Long2ip(properAddress uint32) string {
 	return ip.String()
 }
 
+//////////// Output Control Functions ////////////
+
+// ObStart ob_start()
+// Starts buffering the standard output, see Output.ObStart
+func ObStart(callback func(buffer string, phase int) string, chunkSize int) bool {
+	return stdOutput.ObStart(callback, chunkSize)
+}
+
+// ObGetContents ob_get_contents()
+func ObGetContents() (string, bool) {
+	return stdOutput.ObGetContents()
+}
+
+// ObGetLength ob_get_length()
+func ObGetLength() (int, bool) {
+	return stdOutput.ObGetLength()
+}
+
+// ObGetLevel ob_get_level()
+func ObGetLevel() int {
+	return stdOutput.ObGetLevel()
+}
+
+// ObFlush ob_flush()
+func ObFlush() bool {
+	return stdOutput.ObFlush()
+}
+
+// ObClean ob_clean()
+func ObClean() bool {
+	return stdOutput.ObClean()
+}
+
+// ObEndFlush ob_end_flush()
+func ObEndFlush() bool {
+	return stdOutput.ObEndFlush()
+}
+
+// ObEndClean ob_end_clean()
+func ObEndClean() bool {
+	return stdOutput.ObEndClean()
+}
+
+// ObGetFlush ob_get_flush()
+func ObGetFlush() (string, bool) {
+	return stdOutput.ObGetFlush()
+}
+
+// ObGetClean ob_get_clean()
+func ObGetClean() (string, bool) {
+	return stdOutput.ObGetClean()
+}
+
+// ObImplicitFlush ob_implicit_flush()
+func ObImplicitFlush(enable bool) {
+	stdOutput.ObImplicitFlush(enable)
+}
+
+// Flush flush()
+func Flush() error {
+	return stdOutput.Flush()
+}
+
 //////////// Misc. Functions ////////////
 
 // Echo echo
+// Writes to the standard output, through its output buffers
 func Echo(args ...interface{}) {
-	fmt.Print(args...)
+	stdOutput.Echo(args...)
 }
 
 // Uniqid uniqid()
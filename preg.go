@@ -0,0 +1,839 @@
+package php2go
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// PregPatternOrder PREG_PATTERN_ORDER
+	PregPatternOrder = 1
+	// PregSetOrder PREG_SET_ORDER
+	PregSetOrder = 2
+	// PregOffsetCapture PREG_OFFSET_CAPTURE, the offsets are always captured in PregGroup
+	PregOffsetCapture = 1 << 8
+	// PregUnmatchedAsNull PREG_UNMATCHED_AS_NULL, the trailing groups that didn't participate are kept
+	PregUnmatchedAsNull = 1 << 9
+
+	// PregSplitNoEmpty PREG_SPLIT_NO_EMPTY
+	PregSplitNoEmpty = 1
+	// PregSplitDelimCapture PREG_SPLIT_DELIM_CAPTURE
+	PregSplitDelimCapture = 2
+	// PregSplitOffsetCapture PREG_SPLIT_OFFSET_CAPTURE, the offsets are always captured in PregGroup
+	PregSplitOffsetCapture = 4
+
+	// PregGrepInvert PREG_GREP_INVERT
+	PregGrepInvert = 1
+)
+
+// PregGroup a captured group, or a piece of PregSplit.
+// Offset is the byte offset in the subject, -1 when the group didn't participate in the match.
+type PregGroup struct {
+	Name   string
+	Text   string
+	Offset int
+}
+
+// PregGroups the groups of a match, 0 is the whole match
+type PregGroups []PregGroup
+
+// Named the text of the group name, "" when there is no such group
+func (g PregGroups) Named(name string) string {
+	for _, group := range g {
+		if group.Name == name {
+			return group.Text
+		}
+	}
+	return ""
+}
+
+// pregPattern a compiled PHP pattern
+type pregPattern struct {
+	re *regexp.Regexp
+	// after the pattern preceded by any character, to search from an offset with the character before it seen by ^ and \b
+	after *regexp.Regexp
+	// groups the regexp groups of the pattern groups, the others are added by the translation of $
+	groups []int
+	// eol the groups of the translated $, they consume the newline at the end of the subject $ matches before
+	eol      []int
+	anchored bool
+	utf8     bool
+}
+
+// pregCacheSize the number of patterns kept compiled, like PCRE_G(per_request_cache)
+const pregCacheSize = 4096
+
+var pregCache = struct {
+	sync.RWMutex
+	patterns map[string]*pregPattern
+}{patterns: map[string]*pregPattern{}}
+
+// pregCompile the pattern compiled by pcre_get_compiled_regex_cache(), fn is the PHP function for the errors
+func pregCompile(fn, pattern string) (*pregPattern, error) {
+	pregCache.RLock()
+	p, ok := pregCache.patterns[pattern]
+	pregCache.RUnlock()
+	if ok {
+		return p, nil
+	}
+	p, err := pregParse(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s(): %s", fn, err)
+	}
+	pregCache.Lock()
+	if len(pregCache.patterns) >= pregCacheSize {
+		pregCache.patterns = map[string]*pregPattern{}
+	}
+	pregCache.patterns[pattern] = p
+	pregCache.Unlock()
+	return p, nil
+}
+
+// pregParse splits the delimiters and modifiers of pattern and translates the expression to Go syntax
+func pregParse(pattern string) (*pregPattern, error) {
+	i := 0
+	for i < len(pattern) && strings.IndexByte(" \t\n\r\v\f", pattern[i]) >= 0 {
+		i++
+	}
+	if i >= len(pattern) {
+		return nil, errors.New("Empty regular expression")
+	}
+	start := pattern[i]
+	if isDigit(start) || 'a' <= start|0x20 && start|0x20 <= 'z' || start == '\\' || start == 0 {
+		return nil, errors.New("Delimiter must not be alphanumeric, backslash, or NUL byte")
+	}
+	i++
+	exprStart := i
+	end := start
+	if j := strings.IndexByte("([{<", start); j >= 0 {
+		end = ")]}>"[j]
+	}
+	for brackets := 1; ; i++ {
+		if i >= len(pattern) {
+			if end == start {
+				return nil, fmt.Errorf("No ending delimiter '%c' found", end)
+			}
+			return nil, fmt.Errorf("No ending matching delimiter '%c' found", end)
+		}
+		if pattern[i] == '\\' && i+1 < len(pattern) {
+			i++
+		} else if pattern[i] == end {
+			if brackets--; brackets == 0 {
+				break
+			}
+		} else if pattern[i] == start {
+			brackets++
+		}
+	}
+	expr := pattern[exprStart:i]
+
+	p := &pregPattern{}
+	var mode pregMode
+	var flags string
+	for _, c := range []byte(pattern[i+1:]) {
+		switch c {
+		case 'i', 'm', 's', 'U':
+			if strings.IndexByte(flags, c) < 0 {
+				flags += string(c)
+			}
+		case 'x':
+			mode.extended = true
+		case 'D':
+			mode.dollarEndOnly = true
+		case 'n':
+			mode.noAutoCapture = true
+		case 'A':
+			p.anchored = true
+		case 'u':
+			p.utf8 = true
+		case 'S', 'X', ' ', '\n', '\r':
+		case 0:
+			return nil, errors.New("NUL byte is not a valid modifier")
+		default:
+			return nil, fmt.Errorf("Unknown modifier '%c'", c)
+		}
+	}
+	mode.multiline = strings.IndexByte(flags, 'm') >= 0
+	if p.utf8 && !utf8.ValidString(expr) {
+		return nil, errors.New("Compilation failed: UTF-8 error: invalid UTF-8 string")
+	}
+	if !p.utf8 {
+		// without u PCRE matches bytes, Go runes: both the pattern and the subject are matched as Latin-1
+		expr, _ = pregLatin1(expr)
+	}
+
+	translated, err := mode.translate(expr)
+	if err != nil {
+		return nil, errors.New("Compilation failed: " + err.Error())
+	}
+	if flags != "" {
+		translated = "(?" + flags + ")" + translated
+	}
+	if p.re, err = regexp.Compile(translated); err != nil {
+		return nil, errors.New("Compilation failed: " + strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+	p.after = regexp.MustCompile(`(?s:.)(?:` + translated + ")")
+	p.groups = append(p.groups, 0)
+	for i := 1; i <= p.re.NumSubexp(); i++ {
+		if mode.eol[i] {
+			p.eol = append(p.eol, i)
+		} else {
+			p.groups = append(p.groups, i)
+		}
+	}
+	return p, nil
+}
+
+// pregMode the state of the translation of a PCRE expression to Go syntax
+type pregMode struct {
+	extended, dollarEndOnly, multiline, noAutoCapture bool
+	// groups the number of capturing groups translated so far
+	groups int
+	// eol the capturing groups added for $
+	eol map[int]bool
+}
+
+// pregHorizontalSpace the characters of \h, pregVerticalSpace those of \v
+const (
+	pregHorizontalSpace = `\t \x{A0}\x{1680}\x{180E}\x{2000}-\x{200A}\x{202F}\x{205F}\x{3000}`
+	pregVerticalSpace   = `\n\x0B\f\r\x{85}\x{2028}\x{2029}`
+)
+
+// translate the PCRE expression to Go syntax, with an error for the constructs Go doesn't support
+func (m *pregMode) translate(expr string) (string, error) {
+	unsupported := func(i int, what string) error {
+		return fmt.Errorf("%s are not supported at offset %d", what, i)
+	}
+	// eol $ as PCRE does without the m and D modifiers: at the end or before a newline at the end
+	eol := func() string {
+		m.groups++
+		if m.eol == nil {
+			m.eol = map[int]bool{}
+		}
+		m.eol[m.groups] = true
+		return `(\n?)\z`
+	}
+
+	var b strings.Builder
+	inClass, quantifier := false, false
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		afterQuantifier := quantifier
+		quantifier = false
+
+		if c == '\\' {
+			if i+1 >= len(expr) {
+				return "", fmt.Errorf(`\ at end of pattern at offset %d`, i)
+			}
+			i++
+			e := expr[i]
+			switch {
+			case e == 'Q':
+				// literal up to \E
+				j := strings.Index(expr[i:], `\E`)
+				if j < 0 {
+					j = len(expr) - i
+				} else {
+					j += 2
+				}
+				b.WriteString(`\` + expr[i:i+j])
+				i += j - 1
+			case '1' <= e && e <= '9' && !inClass, e == 'g', e == 'k':
+				return "", unsupported(i-1, "backreferences")
+			case e == 'K', e == 'G', e == 'X', e == 'C':
+				return "", unsupported(i-1, `\`+string(e)+" escapes")
+			case e == 'h' || e == 'v':
+				set := pregHorizontalSpace
+				if e == 'v' {
+					set = pregVerticalSpace
+				}
+				if inClass {
+					b.WriteString(set)
+				} else {
+					b.WriteString("[" + set + "]")
+				}
+			case e == 'H' || e == 'V':
+				if inClass {
+					return "", unsupported(i-1, `\`+string(e)+" escapes in a character class")
+				}
+				if e == 'H' {
+					b.WriteString("[^" + pregHorizontalSpace + "]")
+				} else {
+					b.WriteString("[^" + pregVerticalSpace + "]")
+				}
+			case e == 'R' && !inClass:
+				b.WriteString(`(?:\r\n|[` + pregVerticalSpace + `])`)
+			case e == 'N' && !inClass:
+				b.WriteString(`[^\n]`)
+			case e == 'E':
+				// \E without \Q
+			case e == 'Z' && !inClass:
+				b.WriteString(eol())
+			case e == 'b' && inClass:
+				b.WriteString(`\x08`)
+			case e == 'e':
+				b.WriteString(`\x1B`)
+			case e == 'c':
+				if i+1 >= len(expr) {
+					return "", fmt.Errorf(`\c at end of pattern at offset %d`, i-1)
+				}
+				i++
+				fmt.Fprintf(&b, `\x{%X}`, expr[i]&^0x20^0x40)
+			case e >= utf8.RuneSelf:
+				// an escaped non ASCII character is itself, Go only accepts escaped punctuation
+				r, size := utf8.DecodeRuneInString(expr[i:])
+				b.WriteRune(r)
+				i += size - 1
+			default:
+				b.WriteString(`\` + string(e))
+			}
+			continue
+		}
+
+		if inClass {
+			switch {
+			case c == '[' && i+1 < len(expr) && strings.IndexByte(":.=", expr[i+1]) >= 0:
+				// POSIX class [:alpha:]
+				if j := strings.Index(expr[i+2:], string(expr[i+1])+"]"); j >= 0 {
+					b.WriteString(expr[i : i+j+4])
+					i += j + 3
+					continue
+				}
+				b.WriteString(`\[`)
+			case c == '[':
+				b.WriteString(`\[`)
+			case c == ']':
+				inClass = false
+				b.WriteByte(c)
+			default:
+				b.WriteByte(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '[':
+			inClass = true
+			b.WriteByte(c)
+			if i+1 < len(expr) && expr[i+1] == '^' {
+				i++
+				b.WriteByte('^')
+			}
+			if i+1 < len(expr) && expr[i+1] == ']' {
+				i++
+				b.WriteString(`\]`)
+			}
+		case '(':
+			group, err := m.translateGroup(expr, &i)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(group)
+		case '*', '+', '?':
+			if afterQuantifier && c == '+' {
+				return "", unsupported(i, "possessive quantifiers")
+			}
+			quantifier = !afterQuantifier
+			b.WriteByte(c)
+		case '{':
+			j := i + 1
+			for j < len(expr) && (isDigit(expr[j]) || expr[j] == ',') {
+				j++
+			}
+			if j < len(expr) && expr[j] == '}' && j > i+1 && isDigit(expr[i+1]) {
+				b.WriteString(expr[i : j+1])
+				i = j
+				quantifier = true
+			} else {
+				b.WriteString(`\{`)
+			}
+		case '$':
+			if m.multiline || m.dollarEndOnly {
+				b.WriteByte(c)
+			} else {
+				b.WriteString(eol())
+			}
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+			if !m.extended {
+				b.WriteByte(c)
+			}
+		case '#':
+			if !m.extended {
+				b.WriteByte(c)
+				break
+			}
+			for i < len(expr) && expr[i] != '\n' {
+				i++
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// translateGroup the opening of the group at expr[*i], *i is moved to its last byte
+func (m *pregMode) translateGroup(expr string, i *int) (string, error) {
+	rest := expr[*i+1:]
+	unsupported := func(what string) error {
+		return fmt.Errorf("%s are not supported at offset %d", what, *i)
+	}
+	name := func(prefix string, end byte) (string, error) {
+		j := strings.IndexByte(rest[len(prefix):], end)
+		if j < 0 {
+			return "", fmt.Errorf("syntax error in subpattern name (missing terminator?) at offset %d", *i+1+len(prefix))
+		}
+		m.groups++
+		*i += len(prefix) + j + 1
+		return "(?P<" + rest[len(prefix):len(prefix)+j] + ">", nil
+	}
+	switch {
+	case rest == "" || rest[0] != '?' && rest[0] != '*':
+		if m.noAutoCapture {
+			return "(?:", nil
+		}
+		m.groups++
+		return "(", nil
+	case rest[0] == '*':
+		return "", unsupported("backtracking control verbs")
+	case strings.HasPrefix(rest, "?<=") || strings.HasPrefix(rest, "?<!"):
+		return "", unsupported("lookbehind assertions")
+	case strings.HasPrefix(rest, "?=") || strings.HasPrefix(rest, "?!"):
+		return "", unsupported("lookahead assertions")
+	case strings.HasPrefix(rest, "?>"):
+		return "", unsupported("atomic groups")
+	case strings.HasPrefix(rest, "?|"):
+		return "", unsupported("branch reset groups")
+	case strings.HasPrefix(rest, "?("):
+		return "", unsupported("conditional groups")
+	case strings.HasPrefix(rest, "?P="), strings.HasPrefix(rest, "?P>"), strings.HasPrefix(rest, "?&"),
+		strings.HasPrefix(rest, "?R"), len(rest) > 1 && (isDigit(rest[1]) || rest[1] == '+' || rest[1] == '-' && len(rest) > 2 && isDigit(rest[2])):
+		return "", unsupported("recursion and subroutine calls")
+	case strings.HasPrefix(rest, "?#"):
+		j := strings.IndexByte(rest, ')')
+		if j < 0 {
+			return "", fmt.Errorf("missing ) at offset %d", len(expr))
+		}
+		*i += j + 1
+		return "", nil
+	case strings.HasPrefix(rest, "?P<"):
+		return name("?P<", '>')
+	case strings.HasPrefix(rest, "?<"):
+		return name("?<", '>')
+	case strings.HasPrefix(rest, "?'"):
+		return name("?'", '\'')
+	}
+	// options (?i) (?-i) (?i:...)
+	j := 1
+	for j < len(rest) && strings.IndexByte("imsU-", rest[j]) >= 0 {
+		j++
+	}
+	if j < len(rest) && (rest[j] == ')' || rest[j] == ':') {
+		*i += j
+		return "(" + rest[:j], nil
+	}
+	return "", unsupported("inline options other than i, m, s and U")
+}
+
+// check the subject and the offset of a match, a negative offset counts from the end
+func (p *pregPattern) check(fn, subject string, offset int) (int, error) {
+	if offset < 0 {
+		if offset += len(subject); offset < 0 {
+			offset = 0
+		}
+	}
+	if offset > len(subject) {
+		return 0, fmt.Errorf("%s(): Internal error", fn)
+	}
+	if p.utf8 {
+		if !utf8.ValidString(subject) {
+			return 0, fmt.Errorf("%s(): Malformed UTF-8 characters, possibly incorrectly encoded", fn)
+		}
+		if offset < len(subject) && !utf8.RuneStart(subject[offset]) {
+			return 0, fmt.Errorf("%s(): The offset did not correspond to the beginning of a valid UTF-8 code point", fn)
+		}
+	}
+	return offset, nil
+}
+
+// pregLatin1 s with each byte as the rune of the same value, and the offsets in s of the offsets in the result,
+// nil when s is ASCII and they are the same
+func pregLatin1(s string) (string, []int) {
+	ascii := true
+	for i := 0; i < len(s) && ascii; i++ {
+		ascii = s[i] < utf8.RuneSelf
+	}
+	if ascii {
+		return s, nil
+	}
+	b := make([]byte, 0, 2*len(s))
+	index := make([]int, 0, 2*len(s)+1)
+	for i := 0; i < len(s); i++ {
+		index = append(index, i)
+		if s[i] < utf8.RuneSelf {
+			b = append(b, s[i])
+		} else {
+			index = append(index, i)
+			b = utf8.AppendRune(b, rune(s[i]))
+		}
+	}
+	return string(b), append(index, len(s))
+}
+
+// find the first n matches starting at offset, all of them when n < 0, like the loop of php_pcre_match_impl():
+// the subject before offset is still seen by ^, \A and \b, and after an empty match the next one is searched
+// one character further. Each match holds the start and end of the pattern groups, -1 for those that didn't participate.
+func (p *pregPattern) find(subject string, offset, n int) [][]int {
+	s, index := subject, []int(nil)
+	if !p.utf8 {
+		s, index = pregLatin1(subject)
+	}
+	pos := offset
+	if index != nil {
+		for pos < len(s) && index[pos] < offset {
+			pos++
+		}
+	}
+	var matches [][]int
+	for (n < 0 || len(matches) < n) && pos <= len(s) {
+		var loc []int
+		if pos == 0 {
+			loc = p.re.FindStringSubmatchIndex(s)
+		} else {
+			_, size := utf8.DecodeLastRuneInString(s[:pos])
+			if loc = p.after.FindStringSubmatchIndex(s[pos-size:]); loc != nil {
+				for i := range loc {
+					if loc[i] >= 0 {
+						loc[i] += pos - size
+					}
+				}
+				// the match of the pattern starts after the character before it
+				_, size = utf8.DecodeRuneInString(s[loc[0]:])
+				loc[0] += size
+			}
+		}
+		if loc == nil || p.anchored && loc[0] != pos {
+			break
+		}
+		// the newline consumed by $
+		eol := -1
+		for _, g := range p.eol {
+			if loc[2*g+1] > loc[2*g] {
+				eol = loc[2*g]
+			}
+		}
+		match := make([]int, 0, 2*len(p.groups))
+		for _, g := range p.groups {
+			start, end := loc[2*g], loc[2*g+1]
+			if start >= 0 && eol >= 0 && end > eol {
+				end = eol
+				if start > eol {
+					start = eol
+				}
+			}
+			match = append(match, start, end)
+		}
+		matches = append(matches, match)
+		pos = match[1]
+		if match[0] == match[1] {
+			if pos >= len(s) {
+				break
+			}
+			_, size := utf8.DecodeRuneInString(s[pos:])
+			pos += size
+		}
+	}
+	if index != nil {
+		for _, match := range matches {
+			for i, o := range match {
+				if o >= 0 {
+					match[i] = index[o]
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// pregGroups the groups of a match, without the trailing groups that didn't participate when trim
+func (p *pregPattern) pregGroups(subject string, match []int, trim bool) PregGroups {
+	n := len(match) / 2
+	for trim && n > 1 && match[2*n-2] < 0 {
+		n--
+	}
+	groups := make(PregGroups, n)
+	for i := range groups {
+		groups[i] = p.pregGroup(subject, match, i)
+	}
+	return groups
+}
+
+func (p *pregPattern) pregGroup(subject string, match []int, i int) PregGroup {
+	group := PregGroup{Name: p.re.SubexpNames()[p.groups[i]], Offset: match[2*i]}
+	if group.Offset >= 0 {
+		group.Text = subject[match[2*i]:match[2*i+1]]
+	}
+	return group
+}
+
+// PregMatch preg_match()
+// Patterns are PHP ones with delimiters and the i, m, s, x, u, U, D, A and n modifiers, translated to Go regexp:
+// lookarounds, backreferences, possessive quantifiers, atomic groups and recursion are not supported.
+// Without the u modifier the pattern and the subject are bytes, with it UTF-8 characters.
+// matches, when not nil, receives the groups of the match.
+// The search starts at the byte offset offset, ^ and \A still only match at the start of the subject.
+// PregMatch(`/^(?<year>\d{4})-(\d{2})/`, "2023-05-01", &matches, 0, 0) = 1, matches.Named("year") = "2023"
+func PregMatch(pattern, subject string, matches *PregGroups, flags, offset int) (int, error) {
+	p, err := pregCompile("preg_match", pattern)
+	if err != nil {
+		return 0, err
+	}
+	if offset, err = p.check("preg_match", subject, offset); err != nil {
+		return 0, err
+	}
+	found := p.find(subject, offset, 1)
+	if matches != nil {
+		*matches = PregGroups{}
+		if len(found) > 0 {
+			*matches = p.pregGroups(subject, found[0], flags&PregUnmatchedAsNull == 0)
+		}
+	}
+	return len(found), nil
+}
+
+// PregMatchAll preg_match_all()
+// With PregPatternOrder, the default, (*matches)[i] holds the group i of every match,
+// with PregSetOrder (*matches)[i] holds the groups of the match i.
+func PregMatchAll(pattern, subject string, matches *[]PregGroups, flags, offset int) (int, error) {
+	order := flags &^ (PregOffsetCapture | PregUnmatchedAsNull)
+	if order == 0 {
+		order = PregPatternOrder
+	}
+	if order != PregPatternOrder && order != PregSetOrder {
+		return 0, errors.New("preg_match_all(): Argument #4 ($flags) must be a PREG_* constant")
+	}
+	p, err := pregCompile("preg_match_all", pattern)
+	if err != nil {
+		return 0, err
+	}
+	if offset, err = p.check("preg_match_all", subject, offset); err != nil {
+		return 0, err
+	}
+	found := p.find(subject, offset, -1)
+	if matches != nil {
+		var result []PregGroups
+		if order == PregSetOrder {
+			for _, match := range found {
+				result = append(result, p.pregGroups(subject, match, flags&PregUnmatchedAsNull == 0))
+			}
+		} else {
+			result = make([]PregGroups, len(p.groups))
+			for i := range result {
+				result[i] = make(PregGroups, len(found))
+				for j, match := range found {
+					result[i][j] = p.pregGroup(subject, match, i)
+				}
+			}
+		}
+		*matches = result
+	}
+	return len(found), nil
+}
+
+// PregReplace preg_replace()
+// replacement can refer to the groups with $n, ${n} and \n, n from 0 to 99.
+// limit is the maximum number of replacements, -1 for no limit. count, when not nil, receives the number of replacements.
+// PregReplace(`/(\w+) (\w+)/`, "$2 ${1}", "hello world", -1, nil) = "world hello"
+func PregReplace(pattern, replacement, subject string, limit int, count *int) (string, error) {
+	return pregReplace("preg_replace", pattern, subject, limit, count, func(p *pregPattern, match []int) string {
+		return pregExpand(replacement, subject, match)
+	})
+}
+
+// PregReplaceCallback preg_replace_callback()
+// callback receives the groups of each match and returns its replacement
+func PregReplaceCallback(pattern string, callback func(matches PregGroups) string, subject string, limit int, count *int) (string, error) {
+	return pregReplace("preg_replace_callback", pattern, subject, limit, count, func(p *pregPattern, match []int) string {
+		return callback(p.pregGroups(subject, match, true))
+	})
+}
+
+func pregReplace(fn, pattern, subject string, limit int, count *int, replace func(p *pregPattern, match []int) string) (string, error) {
+	p, err := pregCompile(fn, pattern)
+	if err != nil {
+		return "", err
+	}
+	if _, err = p.check(fn, subject, 0); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	last := 0
+	found := p.find(subject, 0, limit)
+	for _, match := range found {
+		b.WriteString(subject[last:match[0]])
+		b.WriteString(replace(p, match))
+		last = match[1]
+	}
+	b.WriteString(subject[last:])
+	if count != nil {
+		*count = len(found)
+	}
+	return b.String(), nil
+}
+
+// pregExpand the replacement of a match, like php_pcre_replace_impl(): a backslash before \ or $ escapes it
+func pregExpand(replacement, subject string, match []int) string {
+	var b strings.Builder
+	escaped := false
+	for i := 0; i < len(replacement); {
+		c := replacement[i]
+		if c == '\\' || c == '$' {
+			if escaped {
+				// replace the escaping backslash
+				s := b.String()
+				b.Reset()
+				b.WriteString(s[:len(s)-1])
+				b.WriteByte(c)
+				i++
+				escaped = false
+				continue
+			}
+			if ref, n := pregBackref(replacement[i:]); n > 0 {
+				if 2*ref < len(match) && match[2*ref] >= 0 {
+					b.WriteString(subject[match[2*ref]:match[2*ref+1]])
+				}
+				i += n
+				escaped = false
+				continue
+			}
+		}
+		b.WriteByte(c)
+		escaped = c == '\\'
+		i++
+	}
+	return b.String()
+}
+
+// pregBackref preg_get_backref(), the group of the reference at the start of s and its length, 0 when there is none
+func pregBackref(s string) (ref, n int) {
+	n = 1
+	inBrace := s[0] == '$' && len(s) > 1 && s[1] == '{'
+	if inBrace {
+		n++
+	}
+	if n >= len(s) || !isDigit(s[n]) {
+		return 0, 0
+	}
+	ref = int(s[n] - '0')
+	n++
+	if n < len(s) && isDigit(s[n]) {
+		ref = ref*10 + int(s[n]-'0')
+		n++
+	}
+	if inBrace {
+		if n >= len(s) || s[n] != '}' {
+			return 0, 0
+		}
+		n++
+	}
+	return ref, n
+}
+
+// PregSplit preg_split()
+// limit is the maximum number of pieces, -1 or 0 for no limit.
+// flags: PregSplitNoEmpty, PregSplitDelimCapture to include the groups of the delimiters, PregSplitOffsetCapture
+func PregSplit(pattern, subject string, limit, flags int) ([]PregGroup, error) {
+	p, err := pregCompile("preg_split", pattern)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = p.check("preg_split", subject, 0); err != nil {
+		return nil, err
+	}
+	noEmpty := flags&PregSplitNoEmpty != 0
+	if limit == 0 {
+		limit = -1
+	}
+	var pieces []PregGroup
+	add := func(start, end int) {
+		if !noEmpty || end > start {
+			pieces = append(pieces, PregGroup{Text: subject[start:end], Offset: start})
+		}
+	}
+	last := 0
+	if limit != 1 {
+		for _, match := range p.find(subject, 0, -1) {
+			if !noEmpty || match[0] != last {
+				add(last, match[0])
+				if limit > 0 {
+					limit--
+				}
+			}
+			if flags&PregSplitDelimCapture != 0 {
+				n := len(match) / 2
+				for n > 1 && match[2*n-2] < 0 {
+					n--
+				}
+				for i := 1; i < n; i++ {
+					if match[2*i] >= 0 {
+						add(match[2*i], match[2*i+1])
+					} else if !noEmpty {
+						pieces = append(pieces, PregGroup{Offset: -1})
+					}
+				}
+			}
+			last = match[1]
+			if limit == 1 {
+				break
+			}
+		}
+	}
+	add(last, len(subject))
+	return pieces, nil
+}
+
+// PregGrep preg_grep()
+// The elements of input that match pattern, those that don't with PregGrepInvert
+func PregGrep(pattern string, input []string, flags int) ([]string, error) {
+	p, err := pregCompile("preg_grep", pattern)
+	if err != nil {
+		return nil, err
+	}
+	result := []string{}
+	for _, s := range input {
+		if _, err = p.check("preg_grep", s, 0); err != nil {
+			return result, err
+		}
+		if (len(p.find(s, 0, 1)) > 0) != (flags&PregGrepInvert != 0) {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// PregQuote preg_quote()
+// Escapes the regular expression special characters . \ + * ? [ ^ ] $ ( ) { } = ! < > | : - # and NUL,
+// and the delimiter when given
+func PregQuote(str string, delimiter ...string) string {
+	var delim byte
+	if len(delimiter) > 0 && delimiter[0] != "" {
+		delim = delimiter[0][0]
+	}
+	var b strings.Builder
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case c == 0:
+			b.WriteString(`\000`)
+			continue
+		case strings.IndexByte(`.\+*?[^]$(){}=!<>|:-#`, c) >= 0, c == delim:
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
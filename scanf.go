@@ -0,0 +1,579 @@
+package php2go
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// scanfMaxArgs the highest "%n$" index allowed without variables
+const scanfMaxArgs = 0xFF
+
+// phpSscanf php_sscanf_internal()
+// Without vars the values are returned as a []interface{}, with nil for the conversions that were not reached.
+// With vars the values are assigned to them and the number of conversions is returned.
+// -1 is returned when str ends before the first conversion.
+func phpSscanf(str, format string, vars []interface{}) (interface{}, error) {
+	totalVars, err := scanfValidateFormat(format, len(vars))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range vars {
+		if !scanfIsPointer(v) {
+			return nil, fmt.Errorf("Argument #%d must be a pointer to string, int, int64, uint, uint64, float64, float32 or interface{}, %T given", k+3, v)
+		}
+	}
+	var result []interface{}
+	if len(vars) == 0 {
+		result = make([]interface{}, totalVars)
+	}
+
+	at := func(s string, i int) byte {
+		if i < len(s) {
+			return s[i]
+		}
+		return 0
+	}
+	pos, objIndex, nconversions := 0, 0, 0
+	underflow := false
+	// assign stores the value of a conversion, false when the variables are exhausted
+	assign := func(value interface{}) bool {
+		if len(vars) > 0 {
+			if objIndex >= len(vars) {
+				return false
+			}
+			scanfAssign(vars[objIndex], value)
+		} else {
+			result[objIndex] = value
+		}
+		objIndex++
+		return true
+	}
+
+scan:
+	for f := 0; f < len(format); {
+		ch := format[f]
+		f++
+		// whitespace in the format skips whitespace in the string
+		if scanfIsSpace(ch) {
+			for scanfIsSpace(at(str, pos)) {
+				pos++
+			}
+			continue
+		}
+		if ch != '%' || at(format, f) == '%' {
+			if ch == '%' {
+				f++
+			}
+			if pos >= len(str) {
+				underflow = true
+				break
+			}
+			if str[pos] != ch {
+				break
+			}
+			pos++
+			continue
+		}
+
+		ch = at(format, f)
+		f++
+		// assignment suppression ('*') or an XPG3-style assignment ('%n$')
+		suppress := false
+		if ch == '*' {
+			suppress = true
+			ch = at(format, f)
+			f++
+		} else if isDigit(ch) {
+			end := f
+			for isDigit(at(format, end)) {
+				end++
+			}
+			if at(format, end) == '$' {
+				n, _ := strconv.Atoi(format[f-1 : end])
+				objIndex = n - 1
+				f = end + 2
+				ch = at(format, end+1)
+			}
+		}
+		// width and size specifiers
+		width := 0
+		if isDigit(ch) {
+			start := f - 1
+			for isDigit(at(format, f)) {
+				f++
+			}
+			width, _ = strconv.Atoi(format[start:f])
+			ch = at(format, f)
+			f++
+		}
+		if ch == 'h' || ch == 'l' || ch == 'L' {
+			ch = at(format, f)
+			f++
+		}
+
+		base, unsigned, noSkip := 0, false, false
+		switch ch {
+		case 'n':
+			if !suppress && !assign(pos) {
+				break scan
+			}
+			nconversions++
+			continue
+		case 'd', 'D':
+			base = 10
+		case 'i':
+			base = 0
+		case 'o':
+			base = 8
+		case 'x', 'X':
+			base = 16
+		case 'u':
+			base, unsigned = 10, true
+		case 'c', '[':
+			noSkip = true
+		}
+
+		// at the end of the input string the scan is done
+		if !noSkip {
+			for scanfIsSpace(at(str, pos)) {
+				pos++
+			}
+		}
+		if pos >= len(str) {
+			underflow = true
+			break
+		}
+
+		var value interface{}
+		switch ch {
+		case 's':
+			end := pos
+			for end < len(str) && !scanfIsSpace(str[end]) {
+				end++
+				if width > 0 && end-pos == width {
+					break
+				}
+			}
+			value = str[pos:end]
+			pos = end
+		case '[':
+			var set string
+			set, f = scanfCharSet(format, f)
+			end := pos
+			for end < len(str) && scanfInSet(set, str[end]) {
+				end++
+				if width > 0 && end-pos == width {
+					break
+				}
+			}
+			if end == pos {
+				// nothing matched the range, stop processing
+				break scan
+			}
+			value = str[pos:end]
+			pos = end
+		case 'c':
+			value = str[pos : pos+1]
+			pos++
+		case 'f', 'e', 'E', 'g':
+			var n string
+			if n, pos = scanfFloat(str, pos, width); n == "" {
+				underflow = pos >= len(str)
+				break scan
+			}
+			value, _ = strconv.ParseFloat(n, 64)
+		default:
+			var n string
+			if n, base, pos = scanfInt(str, pos, width, base); n == "" {
+				underflow = pos >= len(str)
+				break scan
+			}
+			value = scanfParseInt(n, base, unsigned)
+		}
+		if !suppress && !assign(value) {
+			break
+		}
+		nconversions++
+	}
+
+	if underflow && nconversions == 0 {
+		return -1, nil
+	}
+	if len(vars) > 0 {
+		return nconversions, nil
+	}
+	return result, nil
+}
+
+// scanfValidateFormat ValidateFormat(), the number of values of format
+func scanfValidateFormat(format string, numVars int) (int, error) {
+	at := func(i int) byte {
+		if i < len(format) {
+			return format[i]
+		}
+		return 0
+	}
+	var nassign []int
+	objIndex, xpgSize := 0, 0
+	gotXpg, gotSequential := false, false
+	badIndex := func() (int, error) {
+		if gotXpg {
+			return 0, errors.New(`"%n$" argument index out of range`)
+		}
+		return 0, errors.New("Different numbers of variable names and field specifiers")
+	}
+	for f := 0; f < len(format); {
+		ch := format[f]
+		f++
+		if ch != '%' {
+			continue
+		}
+		ch = at(f)
+		f++
+		if ch == '%' {
+			continue
+		}
+		suppress := false
+		if ch == '*' {
+			suppress = true
+			ch = at(f)
+			f++
+		} else {
+			end := f - 1
+			for isDigit(at(end)) {
+				end++
+			}
+			if end > f-1 && at(end) == '$' {
+				// an XPG3-style %n$ specification, which can't be mixed with non-XPG3 specs
+				value, _ := strconv.Atoi(format[f-1 : end])
+				f = end + 2
+				ch = at(end + 1)
+				gotXpg = true
+				if gotSequential {
+					return 0, errors.New(`cannot mix "%" and "%n$" conversion specifiers`)
+				}
+				objIndex = value - 1
+				if objIndex < 0 || numVars > 0 && objIndex >= numVars {
+					return badIndex()
+				}
+				if numVars == 0 {
+					if value > scanfMaxArgs {
+						return badIndex()
+					}
+					if value > xpgSize {
+						xpgSize = value
+					}
+				}
+			} else {
+				gotSequential = true
+				if gotXpg {
+					return 0, errors.New(`cannot mix "%" and "%n$" conversion specifiers`)
+				}
+			}
+		}
+		for isDigit(ch) {
+			ch = at(f)
+			f++
+		}
+		if ch == 'l' || ch == 'L' || ch == 'h' {
+			ch = at(f)
+			f++
+		}
+		if !suppress && numVars > 0 && objIndex >= numVars {
+			return badIndex()
+		}
+
+		switch ch {
+		case 'n', 'd', 'D', 'i', 'o', 'x', 'X', 'u', 'f', 'e', 'E', 'g', 's', 'c':
+		case '[':
+			_, next := scanfCharSet(format, f)
+			if next < 0 {
+				return 0, errors.New("Unmatched [ in format string")
+			}
+			f = next
+		default:
+			if ch == 0 {
+				return 0, errors.New(`Bad scan conversion character ""`)
+			}
+			return 0, fmt.Errorf("Bad scan conversion character \"%c\"", ch)
+		}
+		if !suppress {
+			for len(nassign) <= objIndex {
+				nassign = append(nassign, 0)
+			}
+			nassign[objIndex]++
+			objIndex++
+		}
+	}
+
+	// verify that all of the variables were assigned exactly once
+	if numVars == 0 {
+		if xpgSize > 0 {
+			numVars = xpgSize
+		} else {
+			numVars = objIndex
+		}
+	}
+	for i := 0; i < numVars; i++ {
+		n := 0
+		if i < len(nassign) {
+			n = nassign[i]
+		}
+		if n > 1 {
+			return 0, errors.New(`Variable is assigned by multiple "%n$" conversion specifiers`)
+		} else if xpgSize == 0 && n == 0 {
+			return 0, errors.New("Variable is not assigned by any conversion specifiers")
+		}
+	}
+	return numVars, nil
+}
+
+// scanfCharSet the set of a %[...] conversion starting after the '[' at format[f:], and the position after its ']'.
+// The set keeps the leading '^', next is -1 when the ']' is missing.
+func scanfCharSet(format string, f int) (set string, next int) {
+	start := f
+	if f < len(format) && format[f] == '^' {
+		f++
+	}
+	// a ']' right after '[' or '[^' is part of the set
+	if f < len(format) && format[f] == ']' {
+		f++
+	}
+	end := strings.IndexByte(format[f:], ']')
+	if end < 0 {
+		return "", -1
+	}
+	return format[start : f+end], f + end + 1
+}
+
+// scanfInSet CharInSet()
+func scanfInSet(set string, c byte) bool {
+	exclude := strings.HasPrefix(set, "^")
+	if exclude {
+		set = set[1:]
+	}
+	for i := 0; i < len(set); i++ {
+		if i+2 < len(set) && set[i+1] == '-' {
+			// a range, possibly in reverse order
+			lo, hi := set[i], set[i+2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if lo <= c && c <= hi {
+				return !exclude
+			}
+			i += 2
+		} else if set[i] == c {
+			return !exclude
+		}
+	}
+	return exclude
+}
+
+// scanfInt the integer at str[pos:] in base (0 detects the 0x and 0 prefixes) of at most width characters.
+// It returns the characters read, "" when there are no digits, the base found and the new position.
+func scanfInt(str string, pos, width, base int) (n string, b, end int) {
+	if width == 0 || width > 63 {
+		width = 63
+	}
+	signOK, noDigits, noZero, xOK := true, true, true, false
+	start := pos
+	for ; width > 0 && pos < len(str); width-- {
+		c, accept := str[pos], true
+		switch {
+		case c == '0':
+			if base == 16 {
+				xOK = true
+			}
+			if base == 0 {
+				base = 8
+				xOK = true
+			}
+			if noZero {
+				signOK, noDigits, noZero = false, false, false
+			} else {
+				signOK, xOK, noDigits = false, false, false
+			}
+		case '1' <= c && c <= '9':
+			if c >= '8' && base == 8 {
+				accept = false
+				break
+			}
+			if base == 0 {
+				base = 10
+			}
+			signOK, xOK, noDigits = false, false, false
+		case 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F':
+			if base <= 10 {
+				accept = false
+				break
+			}
+			signOK, xOK, noDigits = false, false, false
+		case c == '+' || c == '-':
+			accept = signOK
+			signOK = false
+		case c == 'x' || c == 'X':
+			accept = xOK && pos == start+1
+			if accept {
+				base, xOK = 16, false
+			}
+		default:
+			accept = false
+		}
+		if !accept {
+			break
+		}
+		pos++
+	}
+	if noDigits {
+		return "", base, pos
+	}
+	if c := str[pos-1]; c == 'x' || c == 'X' {
+		// "0x" without hex digits
+		pos--
+	}
+	return str[start:pos], base, pos
+}
+
+// scanfParseInt strtol() of the digits of scanfInt, saturating on overflow.
+// With unsigned the result is strtoul(), negative values are returned as the string of the unsigned number.
+func scanfParseInt(n string, base int, unsigned bool) interface{} {
+	neg := false
+	if n[0] == '+' || n[0] == '-' {
+		neg = n[0] == '-'
+		n = n[1:]
+	}
+	if base == 16 && len(n) > 1 && n[0] == '0' && (n[1] == 'x' || n[1] == 'X') {
+		n = n[2:]
+	}
+	u, err := strconv.ParseUint(n, base, 64)
+	if err != nil {
+		u = math.MaxUint64
+	}
+	if unsigned {
+		if neg {
+			u = -u
+		}
+		if u > math.MaxInt {
+			return strconv.FormatUint(u, 10)
+		}
+		return int(u)
+	}
+	switch {
+	case !neg && u > math.MaxInt:
+		return math.MaxInt
+	case neg && u > -math.MinInt:
+		return math.MinInt
+	case neg:
+		return int(-int64(u))
+	}
+	return int(u)
+}
+
+// scanfFloat the float at str[pos:] of at most width characters.
+// It returns the characters read, "" when there are no digits, and the new position.
+func scanfFloat(str string, pos, width int) (n string, end int) {
+	if width == 0 || width > 63 {
+		width = 63
+	}
+	signOK, noDigits, ptOK, expOK := true, true, true, true
+	start := pos
+	for ; width > 0 && pos < len(str); width-- {
+		c := str[pos]
+		if isDigit(c) {
+			signOK, noDigits = false, false
+		} else if (c == '+' || c == '-') && signOK {
+			signOK = false
+		} else if c == '.' && ptOK {
+			signOK, ptOK = false, false
+		} else if (c == 'e' || c == 'E') && !noDigits && expOK {
+			// an exponent is not allowed until there has been at least one digit
+			expOK, ptOK = false, false
+			signOK, noDigits = true, true
+		} else {
+			break
+		}
+		pos++
+	}
+	if noDigits {
+		if expOK {
+			// there were no digits at all
+			return "", pos
+		}
+		// a bad exponent, 'e' and maybe a sign
+		pos--
+		if str[pos] != 'e' && str[pos] != 'E' {
+			pos--
+		}
+	}
+	return str[start:pos], pos
+}
+
+func scanfIsSpace(c byte) bool {
+	return c == ' ' || '\t' <= c && c <= '\r'
+}
+
+func scanfIsPointer(v interface{}) bool {
+	switch v.(type) {
+	case *string, *int, *int64, *uint, *uint64, *float64, *float32, *interface{}:
+		return true
+	}
+	return false
+}
+
+// scanfAssign stores value, an int, float64 or string, into the pointer dst
+func scanfAssign(dst, value interface{}) {
+	switch d := dst.(type) {
+	case *interface{}:
+		*d = value
+	case *string:
+		*d = phpStringVal(value)
+	case *int:
+		*d = int(phpIntVal(value))
+	case *int64:
+		*d = phpIntVal(value)
+	case *uint:
+		*d = uint(scanfUint(value))
+	case *uint64:
+		*d = scanfUint(value)
+	case *float64:
+		*d = phpFloatVal(value)
+	case *float32:
+		*d = float32(phpFloatVal(value))
+	}
+}
+
+// scanfUint the unsigned value of value, keeping the numbers above math.MaxInt that %u returns as strings
+func scanfUint(value interface{}) uint64 {
+	if s, ok := value.(string); ok {
+		if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return u
+		}
+	}
+	return uint64(phpIntVal(value))
+}
+
+// scanfReadLine the next line of r including the "\n", reading byte by byte to not consume more than the line
+func scanfReadLine(r io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n > 0 {
+			line = append(line, b[0])
+			if b[0] == '\n' {
+				return string(line), nil
+			}
+		}
+		if err != nil {
+			if err == io.EOF && len(line) > 0 {
+				return string(line), nil
+			}
+			return string(line), err
+		}
+	}
+}
@@ -0,0 +1,909 @@
+package php2go
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PhpArrayItem a key and its value in a PhpArray, the key is an int64 or a string
+type PhpArrayItem struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// PhpArray an ordered PHP array, what Unserialize gives for the arrays of serialize()
+type PhpArray []PhpArrayItem
+
+// Get the value of key, numeric string keys are the same as integer keys like in PHP
+func (a PhpArray) Get(key interface{}) (interface{}, bool) {
+	k := phpArrayKey(key)
+	for _, item := range a {
+		if item.Key == k {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+// PhpObject an object of serialize(), the names of the protected properties are prefixed
+// with "\x00*\x00" and those of the private ones with "\x00Class\x00" like in PHP
+type PhpObject struct {
+	Class      string
+	Properties PhpArray
+}
+
+// PhpSerializable an object of a class implementing Serializable, the C: format of serialize()
+type PhpSerializable struct {
+	Class string
+	Data  string
+}
+
+// PhpEnum a case of an enum, the E: format of serialize()
+type PhpEnum struct {
+	Class string
+	Case  string
+}
+
+// PhpClassNamer sets the class name of a struct in Serialize, which is its type name otherwise
+type PhpClassNamer interface {
+	PhpClassName() string
+}
+
+// phpIncompleteClass __PHP_Incomplete_Class, the objects of the classes not allowed by Unserialize
+const phpIncompleteClass = "__PHP_Incomplete_Class"
+
+// phpArrayKey the key of a PHP array, integer-like strings are integers
+func phpArrayKey(key interface{}) interface{} {
+	switch k := key.(type) {
+	case string:
+		if i, ok := phpNumericKey(k); ok {
+			return i
+		}
+		return k
+	case bool, float32, float64:
+		return phpIntVal(k)
+	case nil:
+		return ""
+	}
+	return phpIntVal(key)
+}
+
+// phpNumericKey ZEND_HANDLE_NUMERIC_STR, a decimal integer without leading zeros or sign other than -
+func phpNumericKey(s string) (int64, bool) {
+	if s == "" || len(s) > 20 || s == "-0" || s[0] == '-' && len(s) == 1 {
+		return 0, false
+	}
+	digits := s
+	if s[0] == '-' {
+		digits = s[1:]
+	}
+	if digits[0] == '0' && len(digits) > 1 {
+		return 0, false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return 0, false
+		}
+	}
+	i, err := strconv.ParseInt(s, 10, 64)
+	return i, err == nil
+}
+
+// Serialize serialize()
+// nil, bool, integers, floats, strings, PhpArray, slices, maps, PhpObject, PhpSerializable, PhpEnum and structs,
+// which are objects of the class of PhpClassNamer or of their type name with the exported fields as properties
+// named by the "php" tag. The keys of maps are sorted, the integers first. A *PhpObject met again is an r: reference.
+// Serialize(PhpArray{{"a", 1}, {int64(0), true}}) gives a:2:{s:1:"a";i:1;i:0;b:1;}
+func Serialize(value interface{}) (string, error) {
+	s := &phpSerializer{objects: map[*PhpObject]int{}}
+	if err := s.serialize(reflect.ValueOf(value)); err != nil {
+		return "", err
+	}
+	return s.buf.String(), nil
+}
+
+type phpSerializer struct {
+	buf     strings.Builder
+	n       int
+	objects map[*PhpObject]int
+}
+
+func (s *phpSerializer) string(str string) {
+	s.buf.WriteString("s:" + strconv.Itoa(len(str)) + `:"` + str + `";`)
+}
+
+func (s *phpSerializer) key(key interface{}) {
+	switch k := phpArrayKey(key).(type) {
+	case int64:
+		s.buf.WriteString("i:" + strconv.FormatInt(k, 10) + ";")
+	case string:
+		s.string(k)
+	}
+}
+
+func (s *phpSerializer) float(f float64) {
+	s.buf.WriteString("d:")
+	switch {
+	case math.IsInf(f, 1):
+		s.buf.WriteString("INF")
+	case math.IsInf(f, -1):
+		s.buf.WriteString("-INF")
+	case math.IsNaN(f):
+		s.buf.WriteString("NAN")
+	default:
+		s.buf.WriteString(phpGcvt(f, -1, 'E'))
+	}
+	s.buf.WriteByte(';')
+}
+
+func (s *phpSerializer) serialize(v reflect.Value) error {
+	s.n++
+	if !v.IsValid() {
+		s.buf.WriteString("N;")
+		return nil
+	}
+	switch x := v.Interface().(type) {
+	case PhpArray:
+		return s.array(len(x), func(i int) (interface{}, reflect.Value) {
+			return x[i].Key, reflect.ValueOf(x[i].Value)
+		})
+	case *PhpObject:
+		if x == nil {
+			s.buf.WriteString("N;")
+			return nil
+		}
+		if n, ok := s.objects[x]; ok {
+			s.buf.WriteString("r:" + strconv.Itoa(n) + ";")
+			return nil
+		}
+		s.objects[x] = s.n
+		return s.object(x)
+	case PhpObject:
+		return s.object(&x)
+	case PhpSerializable:
+		s.buf.WriteString("C:" + strconv.Itoa(len(x.Class)) + `:"` + x.Class + `":` + strconv.Itoa(len(x.Data)) + ":{" + x.Data + "}")
+		return nil
+	case PhpEnum:
+		name := x.Class + ":" + x.Case
+		s.buf.WriteString("E:" + strconv.Itoa(len(name)) + `:"` + name + `";`)
+		return nil
+	case []byte:
+		s.string(string(x))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			s.buf.WriteString("b:1;")
+		} else {
+			s.buf.WriteString("b:0;")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s.buf.WriteString("i:" + strconv.FormatInt(v.Int(), 10) + ";")
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u > math.MaxInt64 {
+			s.float(float64(u))
+		} else {
+			s.buf.WriteString("i:" + strconv.FormatUint(u, 10) + ";")
+		}
+	case reflect.Float32, reflect.Float64:
+		s.float(v.Float())
+	case reflect.String:
+		s.string(v.String())
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			s.buf.WriteString("N;")
+			return nil
+		}
+		s.n--
+		return s.serialize(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			s.buf.WriteString("a:0:{}")
+			return nil
+		}
+		return s.array(v.Len(), func(i int) (interface{}, reflect.Value) {
+			return i, v.Index(i)
+		})
+	case reflect.Map:
+		keys := make([]interface{}, 0, v.Len())
+		values := map[interface{}]reflect.Value{}
+		for _, k := range v.MapKeys() {
+			key := phpArrayKey(k.Interface())
+			if _, ok := values[key]; !ok {
+				keys = append(keys, key)
+			}
+			values[key] = v.MapIndex(k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			ki, iok := keys[i].(int64)
+			kj, jok := keys[j].(int64)
+			if iok != jok {
+				return iok
+			}
+			if iok {
+				return ki < kj
+			}
+			return keys[i].(string) < keys[j].(string)
+		})
+		return s.array(len(keys), func(i int) (interface{}, reflect.Value) {
+			return keys[i], values[keys[i]]
+		})
+	case reflect.Struct:
+		class := v.Type().Name()
+		if namer, ok := v.Interface().(PhpClassNamer); ok {
+			class = namer.PhpClassName()
+		} else if class == "" {
+			class = "stdClass"
+		}
+		var props PhpArray
+		for _, f := range phpStructFields(v.Type()) {
+			props = append(props, PhpArrayItem{f.name, v.FieldByIndex(f.index).Interface()})
+		}
+		return s.object(&PhpObject{class, props})
+	default:
+		return fmt.Errorf("serialize(): Serialization of '%s' is not allowed", v.Type())
+	}
+	return nil
+}
+
+func (s *phpSerializer) array(n int, item func(i int) (interface{}, reflect.Value)) error {
+	s.buf.WriteString("a:" + strconv.Itoa(n) + ":{")
+	for i := 0; i < n; i++ {
+		key, value := item(i)
+		s.key(key)
+		if err := s.serialize(value); err != nil {
+			return err
+		}
+	}
+	s.buf.WriteByte('}')
+	return nil
+}
+
+func (s *phpSerializer) object(o *PhpObject) error {
+	class, props := o.Class, o.Properties
+	// the incomplete objects of Unserialize are serialized back as their original class
+	if class == phpIncompleteClass && len(props) > 0 && props[0].Key == phpIncompleteClass+"_Name" {
+		class, props = phpStringVal(props[0].Value), props[1:]
+	}
+	s.buf.WriteString("O:" + strconv.Itoa(len(class)) + `:"` + class + `":` + strconv.Itoa(len(props)) + ":{")
+	for _, p := range props {
+		s.string(phpStringVal(p.Key))
+		if err := s.serialize(reflect.ValueOf(p.Value)); err != nil {
+			return err
+		}
+	}
+	s.buf.WriteByte('}')
+	return nil
+}
+
+type phpStructField struct {
+	name  string
+	index []int
+}
+
+// phpStructFields the exported fields named by their "php" tag, "-" skips a field, the fields of
+// embedded structs without a tag are promoted
+func phpStructFields(t reflect.Type) []phpStructField {
+	var fields []phpStructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("php")
+		if tag == "-" || !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && tag == "" && ft.Kind() == reflect.Struct && f.Type.Kind() == reflect.Struct {
+			for _, sub := range phpStructFields(ft) {
+				fields = append(fields, phpStructField{sub.name, append([]int{i}, sub.index...)})
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+		fields = append(fields, phpStructField{name, []int{i}})
+	}
+	return fields
+}
+
+// Unserialize unserialize()
+// N, b, i, d and s give nil, bool, int64, float64 and string, a gives a PhpArray, O a *PhpObject, C a PhpSerializable
+// and E a PhpEnum. The r: references give the same *PhpObject and the R: references a copy of the value.
+// The "allowed_classes" option is true, false or a []string of the class names, case-insensitive, allowed to be
+// objects, the others are objects of the class __PHP_Incomplete_Class with their class name as the first property
+// "__PHP_Incomplete_Class_Name". The "max_depth" option is the maximum depth of arrays and objects, 4096 by default, 0 for no limit.
+// Unserialize(`a:1:{s:3:"foo";O:8:"stdClass":0:{}}`, map[string]interface{}{"allowed_classes": false})
+func Unserialize(data string, options ...map[string]interface{}) (interface{}, error) {
+	u := &phpUnserializer{data: data, allowAll: true, maxDepth: 4096}
+	if len(options) > 0 {
+		if err := u.options(options[0]); err != nil {
+			return nil, err
+		}
+	}
+	v, err := u.value()
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// UnserializeInto unserialize() into v, a pointer to a value of the type of the data like JSONDecode.
+// Structs are filled from arrays and objects by the property names of their "php" tag or their field names,
+// protected and private properties included, the options are those of Unserialize.
+func UnserializeInto(data string, v interface{}, options ...map[string]interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("unserialize(): the destination must be a non-nil pointer")
+	}
+	value, err := Unserialize(data, options...)
+	if err != nil {
+		return err
+	}
+	a := &phpAssigner{pointers: map[phpAssignedObject]reflect.Value{}}
+	return a.assign(rv.Elem(), value)
+}
+
+type phpUnserializer struct {
+	data     string
+	pos      int
+	vars     []interface{}
+	allowAll bool
+	allowed  map[string]bool
+	maxDepth int
+	depth    int
+}
+
+func (u *phpUnserializer) options(options map[string]interface{}) error {
+	if v, ok := options["allowed_classes"]; ok {
+		switch a := v.(type) {
+		case bool:
+			u.allowAll = a
+		case []string:
+			u.allowAll, u.allowed = false, map[string]bool{}
+			for _, class := range a {
+				u.allowed[strings.ToLower(class)] = true
+			}
+		default:
+			return errors.New(`unserialize(): Option "allowed_classes" must be an array or of type bool`)
+		}
+	}
+	if v, ok := options["max_depth"]; ok {
+		depth, isInt := v.(int)
+		if !isInt {
+			return errors.New(`unserialize(): Option "max_depth" must be of type int`)
+		}
+		if depth < 0 {
+			return errors.New(`unserialize(): Option "max_depth" must be greater than or equal to 0`)
+		}
+		u.maxDepth = depth
+	}
+	return nil
+}
+
+func (u *phpUnserializer) classAllowed(class string) bool {
+	return u.allowAll || u.allowed[strings.ToLower(class)]
+}
+
+func (u *phpUnserializer) fail(pos int) error {
+	return fmt.Errorf("unserialize(): Error at offset %d of %d bytes", pos, len(u.data))
+}
+
+// expect the literal s at the position
+func (u *phpUnserializer) expect(s string) bool {
+	if strings.HasPrefix(u.data[u.pos:], s) {
+		u.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// digits the unsigned decimal number at the position, up to the terminator
+func (u *phpUnserializer) uint(end byte) (int, bool) {
+	start := u.pos
+	for u.pos < len(u.data) && u.data[u.pos] >= '0' && u.data[u.pos] <= '9' {
+		u.pos++
+	}
+	if u.pos == start || u.pos >= len(u.data) || u.data[u.pos] != end {
+		return 0, false
+	}
+	n, err := strconv.Atoi(u.data[start:u.pos])
+	u.pos++
+	return n, err == nil && n >= 0
+}
+
+// quoted the "name" of length n
+func (u *phpUnserializer) quoted(n int) (string, bool) {
+	if !u.expect(`"`) || n > len(u.data)-u.pos {
+		return "", false
+	}
+	s := u.data[u.pos : u.pos+n]
+	u.pos += n
+	return s, u.expect(`"`)
+}
+
+func (u *phpUnserializer) value() (interface{}, error) {
+	slot := -1
+	if u.pos < len(u.data) && u.data[u.pos] != 'R' {
+		slot = len(u.vars)
+		u.vars = append(u.vars, nil)
+	}
+	v, err := u.parse()
+	if err != nil {
+		return nil, err
+	}
+	if slot >= 0 {
+		u.vars[slot] = v
+	}
+	return v, nil
+}
+
+func (u *phpUnserializer) parse() (interface{}, error) {
+	start := u.pos
+	if u.expect("N;") {
+		return nil, nil
+	}
+	if start+1 >= len(u.data) || u.data[start+1] != ':' {
+		return nil, u.fail(start)
+	}
+	tag := u.data[start]
+	u.pos += 2
+	switch tag {
+	case 'b':
+		if u.expect("0;") {
+			return false, nil
+		}
+		if u.expect("1;") {
+			return true, nil
+		}
+	case 'i':
+		end := strings.IndexByte(u.data[u.pos:], ';')
+		if end < 0 {
+			break
+		}
+		s := u.data[u.pos : u.pos+end]
+		digits := strings.TrimLeft(s, "+-")
+		if len(s)-len(digits) > 1 || !phpAllDigits(digits) {
+			break
+		}
+		i, err := strconv.ParseInt(strings.TrimPrefix(s, "+"), 10, 64)
+		if err != nil {
+			return nil, errors.New("unserialize(): Numerical result out of range")
+		}
+		u.pos += end + 1
+		return i, nil
+	case 'd':
+		end := strings.IndexByte(u.data[u.pos:], ';')
+		if end < 0 {
+			break
+		}
+		s := u.data[u.pos : u.pos+end]
+		var f float64
+		switch s {
+		case "INF":
+			f = math.Inf(1)
+		case "-INF":
+			f = math.Inf(-1)
+		case "NAN":
+			f = math.NaN()
+		default:
+			if !phpIsSerializedFloat(s) {
+				return nil, u.fail(start)
+			}
+			// out of range values are infinite or zero like zend_strtod
+			f, _ = strconv.ParseFloat(s, 64)
+		}
+		u.pos += end + 1
+		return f, nil
+	case 's':
+		n, ok := u.uint(':')
+		if !ok {
+			break
+		}
+		s, ok := u.quoted(n)
+		if ok && u.expect(";") {
+			return s, nil
+		}
+	case 'a':
+		n, ok := u.uint(':')
+		if !ok || !u.expect("{") {
+			break
+		}
+		if err := u.enter(); err != nil {
+			return nil, err
+		}
+		var array PhpArray
+		index := map[interface{}]int{}
+		for i := 0; i < n; i++ {
+			key, err := u.key()
+			if err != nil {
+				return nil, err
+			}
+			key = phpArrayKey(key)
+			value, err := u.value()
+			if err != nil {
+				return nil, err
+			}
+			if j, ok := index[key]; ok {
+				array[j].Value = value
+				continue
+			}
+			index[key] = len(array)
+			array = append(array, PhpArrayItem{key, value})
+		}
+		if !u.expect("}") {
+			return nil, u.fail(u.pos)
+		}
+		u.depth--
+		if array == nil {
+			array = PhpArray{}
+		}
+		return array, nil
+	case 'O', 'C':
+		n, ok := u.uint(':')
+		if !ok {
+			break
+		}
+		class, ok := u.quoted(n)
+		if !ok || !u.expect(":") || !phpValidClassName(class) {
+			break
+		}
+		n, ok = u.uint(':')
+		if !ok || !u.expect("{") {
+			break
+		}
+		if tag == 'C' {
+			if n > len(u.data)-u.pos {
+				break
+			}
+			data := u.data[u.pos : u.pos+n]
+			u.pos += n
+			if !u.expect("}") {
+				break
+			}
+			if !u.classAllowed(class) {
+				return &PhpObject{phpIncompleteClass, PhpArray{{phpIncompleteClass + "_Name", class}}}, nil
+			}
+			return PhpSerializable{class, data}, nil
+		}
+		return u.object(class, n)
+	case 'E':
+		n, ok := u.uint(':')
+		if !ok {
+			break
+		}
+		name, ok := u.quoted(n)
+		if !ok || !u.expect(";") {
+			break
+		}
+		colon := strings.IndexByte(name, ':')
+		if colon < 0 {
+			return nil, fmt.Errorf("unserialize(): Invalid enum name '%s' (missing colon)", name)
+		}
+		if !u.classAllowed(name[:colon]) {
+			break
+		}
+		return PhpEnum{name[:colon], name[colon+1:]}, nil
+	case 'r', 'R':
+		n, ok := u.uint(';')
+		if !ok || n < 1 || n > len(u.vars) {
+			break
+		}
+		v := u.vars[n-1]
+		if _, isObject := v.(*PhpObject); tag == 'r' && !isObject {
+			break
+		}
+		return v, nil
+	}
+	return nil, u.fail(start)
+}
+
+func (u *phpUnserializer) enter() error {
+	u.depth++
+	if u.maxDepth > 0 && u.depth > u.maxDepth {
+		return fmt.Errorf("unserialize(): Maximum depth of %d exceeded. The depth limit can be changed using the max_depth unserialize() option or the unserialize_max_depth ini setting", u.maxDepth)
+	}
+	return nil
+}
+
+// key an i: or s: key of an array or an object
+func (u *phpUnserializer) key() (interface{}, error) {
+	start := u.pos
+	if u.pos < len(u.data) && (u.data[u.pos] == 'i' || u.data[u.pos] == 's') {
+		if key, err := u.parse(); err == nil {
+			return key, nil
+		}
+	}
+	return nil, u.fail(start)
+}
+
+func (u *phpUnserializer) object(class string, n int) (interface{}, error) {
+	if err := u.enter(); err != nil {
+		return nil, err
+	}
+	o := &PhpObject{Class: class}
+	if !u.classAllowed(class) {
+		o.Class = phpIncompleteClass
+		o.Properties = PhpArray{{phpIncompleteClass + "_Name", class}}
+	}
+	u.vars[len(u.vars)-1] = o
+	index := map[string]int{}
+	for i := 0; i < n; i++ {
+		key, err := u.key()
+		if err != nil {
+			return nil, err
+		}
+		name := phpStringVal(key)
+		value, err := u.value()
+		if err != nil {
+			return nil, err
+		}
+		if j, ok := index[name]; ok {
+			o.Properties[j].Value = value
+			continue
+		}
+		index[name] = len(o.Properties)
+		o.Properties = append(o.Properties, PhpArrayItem{name, value})
+	}
+	if !u.expect("}") {
+		return nil, u.fail(u.pos)
+	}
+	u.depth--
+	return o, nil
+}
+
+func phpAllDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// phpIsSerializedFloat the iv, nv and nvexp forms of the d: of var_unserializer.re
+func phpIsSerializedFloat(s string) bool {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	mantissa, exp := s, ""
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa, exp = s[:i], s[i+1:]
+		if d := strings.TrimLeft(exp, "+-"); len(exp)-len(d) > 1 || !phpAllDigits(d) {
+			return false
+		}
+	}
+	whole, frac, dot := strings.Cut(mantissa, ".")
+	if !dot {
+		return phpAllDigits(whole)
+	}
+	return (whole == "" || phpAllDigits(whole)) && (frac == "" || phpAllDigits(frac)) && whole+frac != ""
+}
+
+// phpValidClassName the characters allowed in class names, namespaces included
+func phpValidClassName(name string) bool {
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '\\' || c >= 0x80) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// phpPropertyName the name of a property without the "\x00*\x00" or "\x00Class\x00" of protected and private ones
+func phpPropertyName(name string) string {
+	if len(name) > 0 && name[0] == 0 {
+		if i := strings.IndexByte(name[1:], 0); i >= 0 {
+			return name[i+2:]
+		}
+	}
+	return name
+}
+
+// phpAssigner sets Go values to unserialized values, the pointers already set for an object are reused
+// so the r: references of cyclic objects give cyclic pointers
+type phpAssigner struct {
+	pointers map[phpAssignedObject]reflect.Value
+}
+
+type phpAssignedObject struct {
+	object *PhpObject
+	typ    reflect.Type
+}
+
+// assign sets dst to the unserialized value src with the conversions of PHP for scalars
+func (a *phpAssigner) assign(dst reflect.Value, src interface{}) error {
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		if src == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+		} else {
+			dst.Set(reflect.ValueOf(src))
+		}
+		return nil
+	}
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if sv := reflect.ValueOf(src); sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+	var items PhpArray
+	switch s := src.(type) {
+	case PhpArray:
+		items = s
+	case *PhpObject:
+		items = make(PhpArray, len(s.Properties))
+		for i, p := range s.Properties {
+			items[i] = PhpArrayItem{phpPropertyName(phpStringVal(p.Key)), p.Value}
+		}
+	case PhpSerializable, PhpEnum:
+		return fmt.Errorf("unserialize(): cannot unserialize %T into %s", src, dst.Type())
+	}
+	isScalar := items == nil
+	switch dst.Kind() {
+	case reflect.Pointer:
+		o, isObject := src.(*PhpObject)
+		if p, ok := a.pointers[phpAssignedObject{o, dst.Type()}]; isObject && ok {
+			dst.Set(p)
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		if isObject {
+			a.pointers[phpAssignedObject{o, dst.Type()}] = dst.Elem().Addr()
+		}
+		return a.assign(dst.Elem(), src)
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.String:
+		if !isScalar {
+			return fmt.Errorf("unserialize(): cannot unserialize an array into %s", dst.Type())
+		}
+		switch dst.Kind() {
+		case reflect.Bool:
+			dst.SetBool(phpBoolVal(src))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i := phpIntVal(src)
+			if dst.OverflowInt(i) {
+				return fmt.Errorf("unserialize(): %s overflows %s", phpStringVal(src), dst.Type())
+			}
+			dst.SetInt(i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			i := phpIntVal(src)
+			if i < 0 || dst.OverflowUint(uint64(i)) {
+				return fmt.Errorf("unserialize(): %s overflows %s", phpStringVal(src), dst.Type())
+			}
+			dst.SetUint(uint64(i))
+		case reflect.Float32, reflect.Float64:
+			f := phpFloatVal(src)
+			if dst.OverflowFloat(f) {
+				return fmt.Errorf("unserialize(): %s overflows %s", phpStringVal(src), dst.Type())
+			}
+			dst.SetFloat(f)
+		case reflect.String:
+			dst.SetString(phpStringVal(src))
+		}
+		return nil
+	case reflect.Slice:
+		if s, ok := src.(string); ok && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes([]byte(s))
+			return nil
+		}
+		if isScalar {
+			break
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := a.assign(slice.Index(i), item.Value); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Array:
+		if isScalar {
+			break
+		}
+		for i := 0; i < dst.Len(); i++ {
+			if i < len(items) {
+				if err := a.assign(dst.Index(i), items[i].Value); err != nil {
+					return err
+				}
+			} else {
+				dst.Index(i).Set(reflect.Zero(dst.Type().Elem()))
+			}
+		}
+		return nil
+	case reflect.Map:
+		if isScalar {
+			break
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(items))
+		for _, item := range items {
+			k := reflect.New(dst.Type().Key()).Elem()
+			if err := a.assign(k, item.Key); err != nil {
+				return err
+			}
+			v := reflect.New(dst.Type().Elem()).Elem()
+			if err := a.assign(v, item.Value); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, v)
+		}
+		dst.Set(m)
+		return nil
+	case reflect.Struct:
+		if isScalar {
+			break
+		}
+		if o, ok := src.(*PhpObject); ok && dst.CanAddr() {
+			key := phpAssignedObject{o, reflect.PointerTo(dst.Type())}
+			if _, ok := a.pointers[key]; !ok {
+				a.pointers[key] = dst.Addr()
+			}
+		}
+		for _, f := range phpStructFields(dst.Type()) {
+			var value interface{}
+			found := false
+			for _, item := range items {
+				if phpStringVal(item.Key) == f.name {
+					value, found = item.Value, true
+					break
+				}
+			}
+			if !found {
+				for _, item := range items {
+					if strings.EqualFold(phpStringVal(item.Key), f.name) {
+						value, found = item.Value, true
+						break
+					}
+				}
+			}
+			if !found {
+				continue
+			}
+			field := dst.Field(f.index[0])
+			for _, i := range f.index[1:] {
+				if field.Kind() == reflect.Pointer {
+					if field.IsNil() {
+						field.Set(reflect.New(field.Type().Elem()))
+					}
+					field = field.Elem()
+				}
+				field = field.Field(i)
+			}
+			if err := a.assign(field, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unserialize(): cannot unserialize %T into %s", src, dst.Type())
+}
+
+// phpBoolVal the PHP boolean conversion of a scalar
+func phpBoolVal(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "0"
+	case float64:
+		return v != 0
+	}
+	return phpIntVal(value) != 0
+}
@@ -0,0 +1,561 @@
+package php2go
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sessionMaxIDLength PS_MAX_SID_LENGTH
+const sessionMaxIDLength = 256
+
+// sessionBinaryMaxKey PS_BIN_MAX, the longest key of the php_binary format
+const sessionBinaryMaxKey = 127
+
+// SessionEncode session_encode() of the session variables with the serialize_handler "php", the default,
+// "php_binary" or "php_serialize". Like PHP the php and php_binary handlers skip the integer keys
+// and php_binary the keys longer than 127 bytes.
+// SessionEncode(PhpArray{{"user", "bob"}, {"n", 2}}) gives user|s:3:"bob";n|i:2;
+func SessionEncode(vars PhpArray, handler ...string) (string, error) {
+	name := "php"
+	if len(handler) > 0 {
+		name = handler[0]
+	}
+	if name == "php_serialize" {
+		return Serialize(vars)
+	}
+	if name != "php" && name != "php_binary" {
+		return "", fmt.Errorf(`session_encode(): Cannot find session serialization handler "%s"`, name)
+	}
+	// the variables share the references like in one serialize() call
+	s := &phpSerializer{objects: map[*PhpObject]int{}}
+	for _, v := range vars {
+		key, ok := v.Key.(string)
+		if !ok {
+			continue
+		}
+		if name == "php" {
+			if strings.Contains(key, "|") {
+				return "", fmt.Errorf(`session_encode(): Failed to encode session data, the key "%s" contains "|"`, key)
+			}
+			s.buf.WriteString(key + "|")
+		} else {
+			if len(key) > sessionBinaryMaxKey {
+				continue
+			}
+			s.buf.WriteByte(byte(len(key)))
+			s.buf.WriteString(key)
+		}
+		if err := s.serialize(reflect.ValueOf(v.Value)); err != nil {
+			return "", err
+		}
+	}
+	return s.buf.String(), nil
+}
+
+// SessionDecode session_decode() of data in the format of the serialize_handler "php", the default, "php_binary" or "php_serialize"
+func SessionDecode(data string, handler ...string) (PhpArray, error) {
+	name := "php"
+	if len(handler) > 0 {
+		name = handler[0]
+	}
+	errDecode := errors.New("session_decode(): Failed to decode session object. Session has been destroyed")
+	vars := PhpArray{}
+	switch name {
+	case "php_serialize":
+		if data == "" {
+			return vars, nil
+		}
+		v, err := Unserialize(data)
+		if err != nil {
+			return nil, errDecode
+		}
+		array, ok := v.(PhpArray)
+		if !ok {
+			return nil, errDecode
+		}
+		return array, nil
+	case "php", "php_binary":
+	default:
+		return nil, fmt.Errorf(`session_decode(): Cannot find session serialization handler "%s"`, name)
+	}
+
+	u := &phpUnserializer{data: data, allowAll: true, maxDepth: 4096}
+	for u.pos < len(data) {
+		var key string
+		if name == "php" {
+			// a trailing name without a value is ignored
+			end := strings.IndexByte(data[u.pos:], '|')
+			if end < 0 {
+				break
+			}
+			key = data[u.pos : u.pos+end]
+			u.pos += end + 1
+		} else {
+			n := int(data[u.pos] &^ 0x80)
+			if u.pos+n >= len(data) {
+				return nil, errDecode
+			}
+			key = data[u.pos+1 : u.pos+1+n]
+			u.pos += n + 1
+		}
+		value, err := u.value()
+		if err != nil {
+			return nil, errDecode
+		}
+		vars = sessionSet(vars, key, value)
+	}
+	return vars, nil
+}
+
+// sessionSet sets the variable key of vars, in place when it exists
+func sessionSet(vars PhpArray, key interface{}, value interface{}) PhpArray {
+	for i := range vars {
+		if vars[i].Key == key {
+			vars[i].Value = value
+			return vars
+		}
+	}
+	return append(vars, PhpArrayItem{key, value})
+}
+
+// sessionValidID ps_files_valid_key(), the ids of at most 256 characters of a-z, A-Z, 0-9, "-" and ","
+func sessionValidID(id string) bool {
+	if id == "" || len(id) > sessionMaxIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == ',') {
+			return false
+		}
+	}
+	return true
+}
+
+// SessionCreateID session_create_id(), 32 random hexadecimal characters like the default
+// session.sid_length and session.sid_bits_per_character, after the prefix
+func SessionCreateID(prefix ...string) (string, error) {
+	id := ""
+	if len(prefix) > 0 {
+		id = prefix[0]
+		if !sessionValidID(id) {
+			return "", errors.New(`session_create_id(): Argument #1 ($prefix) can only contain characters "a-zA-Z0-9,-"`)
+		}
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return id + hex.EncodeToString(b), nil
+}
+
+// SessionFileStore the files save handler of PHP sessions, the sess_<id> files of a session.save_path
+type SessionFileStore struct {
+	dir      string
+	depth    int
+	fileMode os.FileMode
+}
+
+// NewSessionFileStore the store of savePath, a session.save_path of the form "/path", "N;/path" or "N;MODE;/path"
+// where N is the depth of the subdirectories named after the first characters of the ids, which must exist
+// like in PHP, and MODE the octal mode of the files, 600 by default. An empty path is the temporary directory.
+func NewSessionFileStore(savePath string) (*SessionFileStore, error) {
+	s := &SessionFileStore{fileMode: 0600}
+	parts := strings.Split(savePath, ";")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf(`session_start(): Invalid session.save_path "%s"`, savePath)
+	}
+	s.dir = parts[len(parts)-1]
+	if len(parts) > 1 {
+		depth, err := strconv.Atoi(parts[0])
+		if err != nil || depth < 0 {
+			return nil, fmt.Errorf("session_start(): The first parameter in session.save_path is invalid")
+		}
+		s.depth = depth
+	}
+	if len(parts) == 3 {
+		mode, err := strconv.ParseUint(parts[1], 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("session_start(): The second parameter in session.save_path is invalid")
+		}
+		s.fileMode = os.FileMode(mode)
+	}
+	if s.dir == "" {
+		s.dir = os.TempDir()
+	}
+	return s, nil
+}
+
+// path the file of the session id, in the subdirectories of the first characters of the id
+func (s *SessionFileStore) path(id string) (string, error) {
+	if !sessionValidID(id) {
+		return "", errors.New(`session_start(): Session ID is too long or contains illegal characters. Only the A-Z, a-z, 0-9, "-", and "," characters are allowed`)
+	}
+	if len(id) <= s.depth {
+		return "", fmt.Errorf("session_start(): The session id is shorter than the depth %d of session.save_path", s.depth)
+	}
+	dir := s.dir
+	for i := 0; i < s.depth; i++ {
+		dir = filepath.Join(dir, id[i:i+1])
+	}
+	return filepath.Join(dir, "sess_"+id), nil
+}
+
+// Exists whether the session id has a file, the check of session.use_strict_mode
+func (s *SessionFileStore) Exists(id string) bool {
+	path, err := s.path(id)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(path)
+	return err == nil
+}
+
+// Open opens the file of the session id, creating it when missing, and locks it with flock(LOCK_EX)
+// until Close, so the requests of a session run one after the other like in PHP
+func (s *SessionFileStore) Open(id string) (*SessionFile, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, sessionOpenFlags, s.fileMode)
+	if err != nil {
+		return nil, fmt.Errorf("session_start(): open(%s, O_RDWR) failed: %w", path, err)
+	}
+	if err = sessionLock(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("session_start(): flock(%s) failed: %w", path, err)
+	}
+	return &SessionFile{f: f, path: path}, nil
+}
+
+// Gc removes the session files not modified for maxLifetime seconds and returns their number.
+// Like PHP nothing is removed when the save path has subdirectories.
+func (s *SessionFileStore) Gc(maxLifetime int) (int, error) {
+	if s.depth > 0 {
+		return 0, nil
+	}
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, err
+	}
+	expire := time.Now().Add(-time.Duration(maxLifetime) * time.Second)
+	n := 0
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "sess_") || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(expire) {
+			continue
+		}
+		if os.Remove(filepath.Join(s.dir, entry.Name())) == nil {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// SessionFile an open and locked session file of a SessionFileStore
+type SessionFile struct {
+	f    *os.File
+	path string
+}
+
+// Read the session data
+func (f *SessionFile) Read() (string, error) {
+	if _, err := f.f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	b, err := io.ReadAll(f.f)
+	return string(b), err
+}
+
+// Write replaces the session data
+func (f *SessionFile) Write(data string) error {
+	if err := f.f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.f.WriteAt([]byte(data), 0)
+	return err
+}
+
+// UpdateTimestamp sets the modification time of the file to now, what session.lazy_write does for unchanged data
+func (f *SessionFile) UpdateTimestamp() error {
+	now := time.Now()
+	return os.Chtimes(f.path, now, now)
+}
+
+// Close unlocks and closes the file
+func (f *SessionFile) Close() error {
+	sessionUnlock(f.f)
+	return f.f.Close()
+}
+
+// Destroy closes and removes the file
+func (f *SessionFile) Destroy() error {
+	f.Close()
+	return os.Remove(f.path)
+}
+
+// SessionManager the session.* settings of the sessions started by Start and Middleware like session_start()
+type SessionManager struct {
+	Store *SessionFileStore
+	// Name session.name, PHPSESSID
+	Name string
+	// SerializeHandler session.serialize_handler, php
+	SerializeHandler string
+	// GcMaxlifetime session.gc_maxlifetime, 1440 seconds
+	GcMaxlifetime int
+	// GcProbability and GcDivisor session.gc_probability and session.gc_divisor, the chance 1/100 of a Gc at start
+	GcProbability int
+	GcDivisor     int
+	// UseStrictMode session.use_strict_mode, the ids without a file are replaced by new ones
+	UseStrictMode bool
+	// LazyWrite session.lazy_write, unchanged data only updates the modification time of the file
+	LazyWrite bool
+	// the session.cookie_* settings, a CookieLifetime of 0 seconds is a cookie until the browser is closed
+	CookieLifetime int
+	CookiePath     string
+	CookieDomain   string
+	CookieSecure   bool
+	CookieHttponly bool
+	CookieSamesite http.SameSite
+}
+
+// NewSessionManager the manager of the sessions of store with the default settings of php.ini
+func NewSessionManager(store *SessionFileStore) *SessionManager {
+	return &SessionManager{
+		Store:            store,
+		Name:             "PHPSESSID",
+		SerializeHandler: "php",
+		GcMaxlifetime:    1440,
+		GcProbability:    1,
+		GcDivisor:        100,
+		LazyWrite:        true,
+		CookiePath:       "/",
+	}
+}
+
+type sessionContextKey struct{}
+
+// Middleware starts a session for each request, available to next with SessionFromContext,
+// and writes and closes it when next returns unless it was already
+func (m *SessionManager) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s, err := m.Start(w, r)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		defer s.WriteClose()
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), sessionContextKey{}, s)))
+	})
+}
+
+// SessionFromContext the session started by SessionManager.Middleware, nil when there is none
+func SessionFromContext(ctx context.Context) *Session {
+	s, _ := ctx.Value(sessionContextKey{}).(*Session)
+	return s
+}
+
+// Start session_start(), resumes the session of the cookie of r or creates a new one, sending its cookie with w.
+// The session is locked until WriteClose or Destroy.
+func (m *SessionManager) Start(w http.ResponseWriter, r *http.Request) (*Session, error) {
+	s := &Session{manager: m, w: w}
+	if c, err := r.Cookie(m.Name); err == nil && sessionValidID(c.Value) && len(c.Value) > m.Store.depth {
+		if !m.UseStrictMode || m.Store.Exists(c.Value) {
+			s.id = c.Value
+		}
+	}
+	sendCookie := s.id == "" || m.CookieLifetime > 0
+	if s.id == "" {
+		id, err := m.createID()
+		if err != nil {
+			return nil, err
+		}
+		s.id = id
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	if sendCookie {
+		s.sendCookie()
+	}
+	if m.GcProbability > 0 && m.GcDivisor > 0 {
+		if n, err := rand.Int(rand.Reader, big.NewInt(int64(m.GcDivisor))); err == nil && n.Int64() < int64(m.GcProbability) {
+			m.Store.Gc(m.GcMaxlifetime)
+		}
+	}
+	return s, nil
+}
+
+// createID a new session id without a file
+func (m *SessionManager) createID() (string, error) {
+	for {
+		id, err := SessionCreateID()
+		if err != nil || !m.Store.Exists(id) {
+			return id, err
+		}
+	}
+}
+
+// Session a session started by a SessionManager, Vars is $_SESSION
+type Session struct {
+	Vars    PhpArray
+	manager *SessionManager
+	w       http.ResponseWriter
+	id      string
+	file    *SessionFile
+	data    string
+}
+
+// open locks and reads the file of the session id
+func (s *Session) open() error {
+	file, err := s.manager.Store.Open(s.id)
+	if err != nil {
+		return err
+	}
+	data, err := file.Read()
+	if err == nil {
+		s.Vars, err = SessionDecode(data, s.manager.SerializeHandler)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	s.file, s.data = file, data
+	return nil
+}
+
+// sendCookie sets the session cookie, replacing the one set before like php_session_remove_cookie()
+func (s *Session) sendCookie() {
+	m := s.manager
+	header := s.w.Header()
+	cookies := header.Values("Set-Cookie")
+	header.Del("Set-Cookie")
+	for _, c := range cookies {
+		if !strings.HasPrefix(c, m.Name+"=") {
+			header.Add("Set-Cookie", c)
+		}
+	}
+	c := &http.Cookie{
+		Name:     m.Name,
+		Value:    s.id,
+		Path:     m.CookiePath,
+		Domain:   m.CookieDomain,
+		Secure:   m.CookieSecure,
+		HttpOnly: m.CookieHttponly,
+		SameSite: m.CookieSamesite,
+	}
+	if m.CookieLifetime > 0 {
+		c.Expires = time.Now().Add(time.Duration(m.CookieLifetime) * time.Second)
+		c.MaxAge = m.CookieLifetime
+	}
+	http.SetCookie(s.w, c)
+}
+
+// ID session_id()
+func (s *Session) ID() string {
+	return s.id
+}
+
+// Get $_SESSION[key]
+func (s *Session) Get(key string) (interface{}, bool) {
+	return s.Vars.Get(key)
+}
+
+// Set $_SESSION[key] = value
+func (s *Session) Set(key string, value interface{}) {
+	s.Vars = sessionSet(s.Vars, phpArrayKey(key), value)
+}
+
+// Delete unset($_SESSION[key])
+func (s *Session) Delete(key string) {
+	k := phpArrayKey(key)
+	for i, v := range s.Vars {
+		if v.Key == k {
+			s.Vars = append(s.Vars[:i], s.Vars[i+1:]...)
+			return
+		}
+	}
+}
+
+// RegenerateID session_regenerate_id(), moves the variables to a new id and sends its cookie,
+// the old session is written or, with deleteOldSession, removed
+func (s *Session) RegenerateID(deleteOldSession ...bool) error {
+	if s.file == nil {
+		return errors.New("session_regenerate_id(): Session ID cannot be regenerated when there is no active session")
+	}
+	var err error
+	if len(deleteOldSession) > 0 && deleteOldSession[0] {
+		err = s.file.Destroy()
+	} else if err = s.write(); err == nil {
+		err = s.file.Close()
+	}
+	s.file = nil
+	if err != nil {
+		return err
+	}
+	id, err := s.manager.createID()
+	if err != nil {
+		return err
+	}
+	file, err := s.manager.Store.Open(id)
+	if err != nil {
+		return err
+	}
+	s.id, s.file, s.data = id, file, ""
+	s.sendCookie()
+	return nil
+}
+
+// write the variables to the file, the modification time only when they did not change and session.lazy_write is on
+func (s *Session) write() error {
+	data, err := SessionEncode(s.Vars, s.manager.SerializeHandler)
+	if err != nil {
+		return err
+	}
+	if s.manager.LazyWrite && data == s.data {
+		return s.file.UpdateTimestamp()
+	}
+	if err = s.file.Write(data); err == nil {
+		s.data = data
+	}
+	return err
+}
+
+// WriteClose session_write_close(), writes the variables and unlocks the session
+func (s *Session) WriteClose() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.write()
+	if cerr := s.file.Close(); err == nil {
+		err = cerr
+	}
+	s.file = nil
+	return err
+}
+
+// Destroy session_destroy(), removes the session file without writing the variables
+func (s *Session) Destroy() error {
+	if s.file == nil {
+		return errors.New("session_destroy(): Trying to destroy uninitialized session")
+	}
+	err := s.file.Destroy()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+// +build !windows
+
+package php2go
+
+import (
+	"os"
+	"syscall"
+)
+
+// sessionOpenFlags the flags of the open() of the session files, which are not followed when they are symlinks
+const sessionOpenFlags = os.O_CREATE | os.O_RDWR | syscall.O_NOFOLLOW
+
+// sessionLock flock(LOCK_EX), waits for the other holders of the file
+func sessionLock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// sessionUnlock flock(LOCK_UN)
+func sessionUnlock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package php2go
+
+import (
+	"math"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// sessionOpenFlags the flags of the open() of the session files
+const sessionOpenFlags = os.O_CREATE | os.O_RDWR
+
+// sessionLock the flock(LOCK_EX) of PHP on Windows, LockFileEx() of the whole file
+func sessionLock(f *os.File) error {
+	return windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+}
+
+// sessionUnlock flock(LOCK_UN)
+func sessionUnlock(f *os.File) error {
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, math.MaxUint32, math.MaxUint32, new(windows.Overlapped))
+}
@@ -0,0 +1,742 @@
+package php2go
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	// SodiumCryptoSecretboxKeybytes SODIUM_CRYPTO_SECRETBOX_KEYBYTES
+	SodiumCryptoSecretboxKeybytes = 32
+	// SodiumCryptoSecretboxNoncebytes SODIUM_CRYPTO_SECRETBOX_NONCEBYTES
+	SodiumCryptoSecretboxNoncebytes = 24
+	// SodiumCryptoSecretboxMacbytes SODIUM_CRYPTO_SECRETBOX_MACBYTES
+	SodiumCryptoSecretboxMacbytes = 16
+
+	// SodiumCryptoBoxSecretkeybytes SODIUM_CRYPTO_BOX_SECRETKEYBYTES
+	SodiumCryptoBoxSecretkeybytes = 32
+	// SodiumCryptoBoxPublickeybytes SODIUM_CRYPTO_BOX_PUBLICKEYBYTES
+	SodiumCryptoBoxPublickeybytes = 32
+	// SodiumCryptoBoxKeypairbytes SODIUM_CRYPTO_BOX_KEYPAIRBYTES
+	SodiumCryptoBoxKeypairbytes = 64
+	// SodiumCryptoBoxSeedbytes SODIUM_CRYPTO_BOX_SEEDBYTES
+	SodiumCryptoBoxSeedbytes = 32
+	// SodiumCryptoBoxNoncebytes SODIUM_CRYPTO_BOX_NONCEBYTES
+	SodiumCryptoBoxNoncebytes = 24
+	// SodiumCryptoBoxMacbytes SODIUM_CRYPTO_BOX_MACBYTES
+	SodiumCryptoBoxMacbytes = 16
+	// SodiumCryptoBoxSealbytes SODIUM_CRYPTO_BOX_SEALBYTES
+	SodiumCryptoBoxSealbytes = 48
+
+	// SodiumCryptoSignBytes SODIUM_CRYPTO_SIGN_BYTES
+	SodiumCryptoSignBytes = 64
+	// SodiumCryptoSignSeedbytes SODIUM_CRYPTO_SIGN_SEEDBYTES
+	SodiumCryptoSignSeedbytes = 32
+	// SodiumCryptoSignPublickeybytes SODIUM_CRYPTO_SIGN_PUBLICKEYBYTES
+	SodiumCryptoSignPublickeybytes = 32
+	// SodiumCryptoSignSecretkeybytes SODIUM_CRYPTO_SIGN_SECRETKEYBYTES
+	SodiumCryptoSignSecretkeybytes = 64
+	// SodiumCryptoSignKeypairbytes SODIUM_CRYPTO_SIGN_KEYPAIRBYTES
+	SodiumCryptoSignKeypairbytes = 96
+
+	// SodiumCryptoAeadXchacha20poly1305IetfKeybytes SODIUM_CRYPTO_AEAD_XCHACHA20POLY1305_IETF_KEYBYTES
+	SodiumCryptoAeadXchacha20poly1305IetfKeybytes = 32
+	// SodiumCryptoAeadXchacha20poly1305IetfNpubbytes SODIUM_CRYPTO_AEAD_XCHACHA20POLY1305_IETF_NPUBBYTES
+	SodiumCryptoAeadXchacha20poly1305IetfNpubbytes = 24
+	// SodiumCryptoAeadXchacha20poly1305IetfAbytes SODIUM_CRYPTO_AEAD_XCHACHA20POLY1305_IETF_ABYTES
+	SodiumCryptoAeadXchacha20poly1305IetfAbytes = 16
+
+	// SodiumCryptoGenerichashBytes SODIUM_CRYPTO_GENERICHASH_BYTES
+	SodiumCryptoGenerichashBytes = 32
+	// SodiumCryptoGenerichashBytesMin SODIUM_CRYPTO_GENERICHASH_BYTES_MIN
+	SodiumCryptoGenerichashBytesMin = 16
+	// SodiumCryptoGenerichashBytesMax SODIUM_CRYPTO_GENERICHASH_BYTES_MAX
+	SodiumCryptoGenerichashBytesMax = 64
+	// SodiumCryptoGenerichashKeybytes SODIUM_CRYPTO_GENERICHASH_KEYBYTES
+	SodiumCryptoGenerichashKeybytes = 32
+	// SodiumCryptoGenerichashKeybytesMin SODIUM_CRYPTO_GENERICHASH_KEYBYTES_MIN
+	SodiumCryptoGenerichashKeybytesMin = 16
+	// SodiumCryptoGenerichashKeybytesMax SODIUM_CRYPTO_GENERICHASH_KEYBYTES_MAX
+	SodiumCryptoGenerichashKeybytesMax = 64
+
+	// SodiumCryptoPwhashSaltbytes SODIUM_CRYPTO_PWHASH_SALTBYTES
+	SodiumCryptoPwhashSaltbytes = 16
+	// SodiumCryptoPwhashAlgArgon2i13 SODIUM_CRYPTO_PWHASH_ALG_ARGON2I13
+	SodiumCryptoPwhashAlgArgon2i13 = 1
+	// SodiumCryptoPwhashAlgArgon2id13 SODIUM_CRYPTO_PWHASH_ALG_ARGON2ID13
+	SodiumCryptoPwhashAlgArgon2id13 = 2
+	// SodiumCryptoPwhashAlgDefault SODIUM_CRYPTO_PWHASH_ALG_DEFAULT
+	SodiumCryptoPwhashAlgDefault = SodiumCryptoPwhashAlgArgon2id13
+	// SodiumCryptoPwhashOpslimitInteractive SODIUM_CRYPTO_PWHASH_OPSLIMIT_INTERACTIVE
+	SodiumCryptoPwhashOpslimitInteractive = 2
+	// SodiumCryptoPwhashMemlimitInteractive SODIUM_CRYPTO_PWHASH_MEMLIMIT_INTERACTIVE in bytes
+	SodiumCryptoPwhashMemlimitInteractive = 67108864
+	// SodiumCryptoPwhashOpslimitModerate SODIUM_CRYPTO_PWHASH_OPSLIMIT_MODERATE
+	SodiumCryptoPwhashOpslimitModerate = 3
+	// SodiumCryptoPwhashMemlimitModerate SODIUM_CRYPTO_PWHASH_MEMLIMIT_MODERATE in bytes
+	SodiumCryptoPwhashMemlimitModerate = 268435456
+	// SodiumCryptoPwhashOpslimitSensitive SODIUM_CRYPTO_PWHASH_OPSLIMIT_SENSITIVE
+	SodiumCryptoPwhashOpslimitSensitive = 4
+	// SodiumCryptoPwhashMemlimitSensitive SODIUM_CRYPTO_PWHASH_MEMLIMIT_SENSITIVE in bytes
+	SodiumCryptoPwhashMemlimitSensitive = 1073741824
+	// SodiumCryptoPwhashStrprefix SODIUM_CRYPTO_PWHASH_STRPREFIX
+	SodiumCryptoPwhashStrprefix = "$argon2id$"
+
+	// SodiumCryptoKxSeedbytes SODIUM_CRYPTO_KX_SEEDBYTES
+	SodiumCryptoKxSeedbytes = 32
+	// SodiumCryptoKxSessionkeybytes SODIUM_CRYPTO_KX_SESSIONKEYBYTES
+	SodiumCryptoKxSessionkeybytes = 32
+	// SodiumCryptoKxPublickeybytes SODIUM_CRYPTO_KX_PUBLICKEYBYTES
+	SodiumCryptoKxPublickeybytes = 32
+	// SodiumCryptoKxSecretkeybytes SODIUM_CRYPTO_KX_SECRETKEYBYTES
+	SodiumCryptoKxSecretkeybytes = 32
+	// SodiumCryptoKxKeypairbytes SODIUM_CRYPTO_KX_KEYPAIRBYTES
+	SodiumCryptoKxKeypairbytes = 64
+
+	// SodiumBase64VariantOriginal SODIUM_BASE64_VARIANT_ORIGINAL
+	SodiumBase64VariantOriginal = 1
+	// SodiumBase64VariantOriginalNoPadding SODIUM_BASE64_VARIANT_ORIGINAL_NO_PADDING
+	SodiumBase64VariantOriginalNoPadding = 3
+	// SodiumBase64VariantUrlsafe SODIUM_BASE64_VARIANT_URLSAFE
+	SodiumBase64VariantUrlsafe = 5
+	// SodiumBase64VariantUrlsafeNoPadding SODIUM_BASE64_VARIANT_URLSAFE_NO_PADDING
+	SodiumBase64VariantUrlsafeNoPadding = 7
+)
+
+// sodiumPwhashMemlimitMin crypto_pwhash_MEMLIMIT_MIN, in bytes
+const sodiumPwhashMemlimitMin = 8192
+
+// errSodiumDecrypt the false of PHP when a message can't be verified or decrypted
+var errSodiumDecrypt = errors.New("sodium: message forged or corrupted")
+
+// sodiumLength the ValueError of a string argument with the wrong length
+func sodiumLength(fn string, arg int, name string, s string, size int, constant string) error {
+	if len(s) != size {
+		return fmt.Errorf("%s(): Argument #%d ($%s) must be %s bytes long", fn, arg, name, constant)
+	}
+	return nil
+}
+
+func sodiumRandom(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// SodiumCryptoSecretboxKeygen sodium_crypto_secretbox_keygen()
+func SodiumCryptoSecretboxKeygen() (string, error) {
+	return sodiumRandom(SodiumCryptoSecretboxKeybytes)
+}
+
+// SodiumCryptoSecretbox sodium_crypto_secretbox()
+// XSalsa20-Poly1305, the ciphertext is prefixed with the 16 bytes MAC like crypto_secretbox_easy
+func SodiumCryptoSecretbox(message, nonce, key string) (string, error) {
+	if err := sodiumLength("sodium_crypto_secretbox", 2, "nonce", nonce, SodiumCryptoSecretboxNoncebytes, "SODIUM_CRYPTO_SECRETBOX_NONCEBYTES"); err != nil {
+		return "", err
+	}
+	if err := sodiumLength("sodium_crypto_secretbox", 3, "key", key, SodiumCryptoSecretboxKeybytes, "SODIUM_CRYPTO_SECRETBOX_KEYBYTES"); err != nil {
+		return "", err
+	}
+	var n [24]byte
+	var k [32]byte
+	copy(n[:], nonce)
+	copy(k[:], key)
+	return string(secretbox.Seal(nil, []byte(message), &n, &k)), nil
+}
+
+// SodiumCryptoSecretboxOpen sodium_crypto_secretbox_open()
+func SodiumCryptoSecretboxOpen(ciphertext, nonce, key string) (string, error) {
+	if err := sodiumLength("sodium_crypto_secretbox_open", 2, "nonce", nonce, SodiumCryptoSecretboxNoncebytes, "SODIUM_CRYPTO_SECRETBOX_NONCEBYTES"); err != nil {
+		return "", err
+	}
+	if err := sodiumLength("sodium_crypto_secretbox_open", 3, "key", key, SodiumCryptoSecretboxKeybytes, "SODIUM_CRYPTO_SECRETBOX_KEYBYTES"); err != nil {
+		return "", err
+	}
+	var n [24]byte
+	var k [32]byte
+	copy(n[:], nonce)
+	copy(k[:], key)
+	message, ok := secretbox.Open(nil, []byte(ciphertext), &n, &k)
+	if !ok {
+		return "", errSodiumDecrypt
+	}
+	return string(message), nil
+}
+
+// SodiumCryptoBoxKeypair sodium_crypto_box_keypair()
+// The X25519 secret key followed by its public key
+func SodiumCryptoBoxKeypair() (string, error) {
+	sk, err := sodiumRandom(SodiumCryptoBoxSecretkeybytes)
+	if err != nil {
+		return "", err
+	}
+	pk, _ := curve25519.X25519([]byte(sk), curve25519.Basepoint)
+	return sk + string(pk), nil
+}
+
+// SodiumCryptoBoxSeedKeypair sodium_crypto_box_seed_keypair()
+// The secret key is the first 32 bytes of the SHA-512 of seed like crypto_box_seed_keypair
+func SodiumCryptoBoxSeedKeypair(seed string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box_seed_keypair", 1, "seed", seed, SodiumCryptoBoxSeedbytes, "SODIUM_CRYPTO_BOX_SEEDBYTES"); err != nil {
+		return "", err
+	}
+	sum := sha512.Sum512([]byte(seed))
+	pk, _ := curve25519.X25519(sum[:32], curve25519.Basepoint)
+	return string(sum[:32]) + string(pk), nil
+}
+
+// SodiumCryptoBoxKeypairFromSecretkeyAndPublickey sodium_crypto_box_keypair_from_secretkey_and_publickey()
+func SodiumCryptoBoxKeypairFromSecretkeyAndPublickey(secretKey, publicKey string) (string, error) {
+	fn := "sodium_crypto_box_keypair_from_secretkey_and_publickey"
+	if err := sodiumLength(fn, 1, "secret_key", secretKey, SodiumCryptoBoxSecretkeybytes, "SODIUM_CRYPTO_BOX_SECRETKEYBYTES"); err != nil {
+		return "", err
+	}
+	if err := sodiumLength(fn, 2, "public_key", publicKey, SodiumCryptoBoxPublickeybytes, "SODIUM_CRYPTO_BOX_PUBLICKEYBYTES"); err != nil {
+		return "", err
+	}
+	return secretKey + publicKey, nil
+}
+
+// SodiumCryptoBoxSecretkey sodium_crypto_box_secretkey()
+func SodiumCryptoBoxSecretkey(keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box_secretkey", 1, "key_pair", keyPair, SodiumCryptoBoxKeypairbytes, "SODIUM_CRYPTO_BOX_KEYPAIRBYTES"); err != nil {
+		return "", err
+	}
+	return keyPair[:SodiumCryptoBoxSecretkeybytes], nil
+}
+
+// SodiumCryptoBoxPublickey sodium_crypto_box_publickey()
+func SodiumCryptoBoxPublickey(keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box_publickey", 1, "key_pair", keyPair, SodiumCryptoBoxKeypairbytes, "SODIUM_CRYPTO_BOX_KEYPAIRBYTES"); err != nil {
+		return "", err
+	}
+	return keyPair[SodiumCryptoBoxSecretkeybytes:], nil
+}
+
+// SodiumCryptoBoxPublickeyFromSecretkey sodium_crypto_box_publickey_from_secretkey()
+func SodiumCryptoBoxPublickeyFromSecretkey(secretKey string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box_publickey_from_secretkey", 1, "secret_key", secretKey, SodiumCryptoBoxSecretkeybytes, "SODIUM_CRYPTO_BOX_SECRETKEYBYTES"); err != nil {
+		return "", err
+	}
+	pk, _ := curve25519.X25519([]byte(secretKey), curve25519.Basepoint)
+	return string(pk), nil
+}
+
+func sodiumBoxKeys(fn string, keyPair string) (sk, pk *[32]byte, err error) {
+	if err = sodiumLength(fn, 3, "key_pair", keyPair, SodiumCryptoBoxKeypairbytes, "SODIUM_CRYPTO_BOX_KEYPAIRBYTES"); err != nil {
+		return nil, nil, err
+	}
+	sk, pk = new([32]byte), new([32]byte)
+	copy(sk[:], keyPair)
+	copy(pk[:], keyPair[SodiumCryptoBoxSecretkeybytes:])
+	return sk, pk, nil
+}
+
+// SodiumCryptoBox sodium_crypto_box()
+// X25519-XSalsa20-Poly1305, keyPair is the secret key of the sender followed by the public key of the recipient
+// SodiumCryptoBox(message, nonce, SodiumCryptoBoxKeypairFromSecretkeyAndPublickey(senderSecretKey, recipientPublicKey))
+func SodiumCryptoBox(message, nonce, keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box", 2, "nonce", nonce, SodiumCryptoBoxNoncebytes, "SODIUM_CRYPTO_BOX_NONCEBYTES"); err != nil {
+		return "", err
+	}
+	sk, pk, err := sodiumBoxKeys("sodium_crypto_box", keyPair)
+	if err != nil {
+		return "", err
+	}
+	var n [24]byte
+	copy(n[:], nonce)
+	return string(box.Seal(nil, []byte(message), &n, pk, sk)), nil
+}
+
+// SodiumCryptoBoxOpen sodium_crypto_box_open()
+// keyPair is the secret key of the recipient followed by the public key of the sender
+func SodiumCryptoBoxOpen(ciphertext, nonce, keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box_open", 2, "nonce", nonce, SodiumCryptoBoxNoncebytes, "SODIUM_CRYPTO_BOX_NONCEBYTES"); err != nil {
+		return "", err
+	}
+	sk, pk, err := sodiumBoxKeys("sodium_crypto_box_open", keyPair)
+	if err != nil {
+		return "", err
+	}
+	var n [24]byte
+	copy(n[:], nonce)
+	message, ok := box.Open(nil, []byte(ciphertext), &n, pk, sk)
+	if !ok {
+		return "", errSodiumDecrypt
+	}
+	return string(message), nil
+}
+
+// SodiumCryptoBoxSeal sodium_crypto_box_seal()
+// The anonymous box of crypto_box_seal, an ephemeral public key followed by the box with the nonce
+// of the BLAKE2b of the ephemeral and the recipient public keys
+func SodiumCryptoBoxSeal(message, publicKey string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box_seal", 2, "public_key", publicKey, SodiumCryptoBoxPublickeybytes, "SODIUM_CRYPTO_BOX_PUBLICKEYBYTES"); err != nil {
+		return "", err
+	}
+	var pk [32]byte
+	copy(pk[:], publicKey)
+	sealed, err := box.SealAnonymous(nil, []byte(message), &pk, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return string(sealed), nil
+}
+
+// SodiumCryptoBoxSealOpen sodium_crypto_box_seal_open()
+// keyPair is the key pair of the recipient
+func SodiumCryptoBoxSealOpen(ciphertext, keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_box_seal_open", 2, "key_pair", keyPair, SodiumCryptoBoxKeypairbytes, "SODIUM_CRYPTO_BOX_KEYPAIRBYTES"); err != nil {
+		return "", err
+	}
+	var sk, pk [32]byte
+	copy(sk[:], keyPair)
+	copy(pk[:], keyPair[SodiumCryptoBoxSecretkeybytes:])
+	message, ok := box.OpenAnonymous(nil, []byte(ciphertext), &pk, &sk)
+	if !ok {
+		return "", errSodiumDecrypt
+	}
+	return string(message), nil
+}
+
+// SodiumCryptoSignKeypair sodium_crypto_sign_keypair()
+// The Ed25519 secret key, the seed followed by the public key, followed by the public key again
+func SodiumCryptoSignKeypair() (string, error) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return string(sk) + string(pk), nil
+}
+
+// SodiumCryptoSignSeedKeypair sodium_crypto_sign_seed_keypair()
+func SodiumCryptoSignSeedKeypair(seed string) (string, error) {
+	if err := sodiumLength("sodium_crypto_sign_seed_keypair", 1, "seed", seed, SodiumCryptoSignSeedbytes, "SODIUM_CRYPTO_SIGN_SEEDBYTES"); err != nil {
+		return "", err
+	}
+	sk := ed25519.NewKeyFromSeed([]byte(seed))
+	return string(sk) + string(sk[SodiumCryptoSignSeedbytes:]), nil
+}
+
+// SodiumCryptoSignKeypairFromSecretkeyAndPublickey sodium_crypto_sign_keypair_from_secretkey_and_publickey()
+func SodiumCryptoSignKeypairFromSecretkeyAndPublickey(secretKey, publicKey string) (string, error) {
+	fn := "sodium_crypto_sign_keypair_from_secretkey_and_publickey"
+	if err := sodiumLength(fn, 1, "secret_key", secretKey, SodiumCryptoSignSecretkeybytes, "SODIUM_CRYPTO_SIGN_SECRETKEYBYTES"); err != nil {
+		return "", err
+	}
+	if err := sodiumLength(fn, 2, "public_key", publicKey, SodiumCryptoSignPublickeybytes, "SODIUM_CRYPTO_SIGN_PUBLICKEYBYTES"); err != nil {
+		return "", err
+	}
+	return secretKey + publicKey, nil
+}
+
+// SodiumCryptoSignSecretkey sodium_crypto_sign_secretkey()
+func SodiumCryptoSignSecretkey(keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_sign_secretkey", 1, "key_pair", keyPair, SodiumCryptoSignKeypairbytes, "SODIUM_CRYPTO_SIGN_KEYPAIRBYTES"); err != nil {
+		return "", err
+	}
+	return keyPair[:SodiumCryptoSignSecretkeybytes], nil
+}
+
+// SodiumCryptoSignPublickey sodium_crypto_sign_publickey()
+func SodiumCryptoSignPublickey(keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_sign_publickey", 1, "key_pair", keyPair, SodiumCryptoSignKeypairbytes, "SODIUM_CRYPTO_SIGN_KEYPAIRBYTES"); err != nil {
+		return "", err
+	}
+	return keyPair[SodiumCryptoSignSecretkeybytes:], nil
+}
+
+// SodiumCryptoSignPublickeyFromSecretkey sodium_crypto_sign_publickey_from_secretkey()
+func SodiumCryptoSignPublickeyFromSecretkey(secretKey string) (string, error) {
+	if err := sodiumLength("sodium_crypto_sign_publickey_from_secretkey", 1, "secret_key", secretKey, SodiumCryptoSignSecretkeybytes, "SODIUM_CRYPTO_SIGN_SECRETKEYBYTES"); err != nil {
+		return "", err
+	}
+	return secretKey[SodiumCryptoSignSeedbytes:], nil
+}
+
+// SodiumCryptoSign sodium_crypto_sign()
+// The 64 bytes Ed25519 signature followed by the message
+func SodiumCryptoSign(message, secretKey string) (string, error) {
+	if err := sodiumLength("sodium_crypto_sign", 2, "secret_key", secretKey, SodiumCryptoSignSecretkeybytes, "SODIUM_CRYPTO_SIGN_SECRETKEYBYTES"); err != nil {
+		return "", err
+	}
+	return string(ed25519.Sign(ed25519.PrivateKey(secretKey), []byte(message))) + message, nil
+}
+
+// SodiumCryptoSignOpen sodium_crypto_sign_open()
+// The message of a signed message when the signature is valid
+func SodiumCryptoSignOpen(signedMessage, publicKey string) (string, error) {
+	if len(signedMessage) < SodiumCryptoSignBytes {
+		return "", errors.New("sodium_crypto_sign_open(): Argument #1 ($signed_message) must be at least SODIUM_CRYPTO_SIGN_BYTES bytes long")
+	}
+	if err := sodiumLength("sodium_crypto_sign_open", 2, "public_key", publicKey, SodiumCryptoSignPublickeybytes, "SODIUM_CRYPTO_SIGN_PUBLICKEYBYTES"); err != nil {
+		return "", err
+	}
+	message := signedMessage[SodiumCryptoSignBytes:]
+	if !ed25519.Verify(ed25519.PublicKey(publicKey), []byte(message), []byte(signedMessage[:SodiumCryptoSignBytes])) {
+		return "", errSodiumDecrypt
+	}
+	return message, nil
+}
+
+// SodiumCryptoSignDetached sodium_crypto_sign_detached()
+func SodiumCryptoSignDetached(message, secretKey string) (string, error) {
+	if err := sodiumLength("sodium_crypto_sign_detached", 2, "secret_key", secretKey, SodiumCryptoSignSecretkeybytes, "SODIUM_CRYPTO_SIGN_SECRETKEYBYTES"); err != nil {
+		return "", err
+	}
+	return string(ed25519.Sign(ed25519.PrivateKey(secretKey), []byte(message))), nil
+}
+
+// SodiumCryptoSignVerifyDetached sodium_crypto_sign_verify_detached()
+func SodiumCryptoSignVerifyDetached(signature, message, publicKey string) (bool, error) {
+	fn := "sodium_crypto_sign_verify_detached"
+	if err := sodiumLength(fn, 1, "signature", signature, SodiumCryptoSignBytes, "SODIUM_CRYPTO_SIGN_BYTES"); err != nil {
+		return false, err
+	}
+	if err := sodiumLength(fn, 3, "public_key", publicKey, SodiumCryptoSignPublickeybytes, "SODIUM_CRYPTO_SIGN_PUBLICKEYBYTES"); err != nil {
+		return false, err
+	}
+	return ed25519.Verify(ed25519.PublicKey(publicKey), []byte(message), []byte(signature)), nil
+}
+
+// SodiumCryptoAeadXchacha20poly1305IetfKeygen sodium_crypto_aead_xchacha20poly1305_ietf_keygen()
+func SodiumCryptoAeadXchacha20poly1305IetfKeygen() (string, error) {
+	return sodiumRandom(SodiumCryptoAeadXchacha20poly1305IetfKeybytes)
+}
+
+// SodiumCryptoAeadXchacha20poly1305IetfEncrypt sodium_crypto_aead_xchacha20poly1305_ietf_encrypt()
+// The ciphertext is followed by the 16 bytes tag
+func SodiumCryptoAeadXchacha20poly1305IetfEncrypt(message, additionalData, nonce, key string) (string, error) {
+	fn := "sodium_crypto_aead_xchacha20poly1305_ietf_encrypt"
+	if err := sodiumLength(fn, 3, "nonce", nonce, SodiumCryptoAeadXchacha20poly1305IetfNpubbytes, "SODIUM_CRYPTO_AEAD_XCHACHA20POLY1305_IETF_NPUBBYTES"); err != nil {
+		return "", err
+	}
+	if err := sodiumLength(fn, 4, "key", key, SodiumCryptoAeadXchacha20poly1305IetfKeybytes, "SODIUM_CRYPTO_AEAD_XCHACHA20POLY1305_IETF_KEYBYTES"); err != nil {
+		return "", err
+	}
+	aead, _ := chacha20poly1305.NewX([]byte(key))
+	return string(aead.Seal(nil, []byte(nonce), []byte(message), []byte(additionalData))), nil
+}
+
+// SodiumCryptoAeadXchacha20poly1305IetfDecrypt sodium_crypto_aead_xchacha20poly1305_ietf_decrypt()
+func SodiumCryptoAeadXchacha20poly1305IetfDecrypt(ciphertext, additionalData, nonce, key string) (string, error) {
+	fn := "sodium_crypto_aead_xchacha20poly1305_ietf_decrypt"
+	if err := sodiumLength(fn, 3, "nonce", nonce, SodiumCryptoAeadXchacha20poly1305IetfNpubbytes, "SODIUM_CRYPTO_AEAD_XCHACHA20POLY1305_IETF_NPUBBYTES"); err != nil {
+		return "", err
+	}
+	if err := sodiumLength(fn, 4, "key", key, SodiumCryptoAeadXchacha20poly1305IetfKeybytes, "SODIUM_CRYPTO_AEAD_XCHACHA20POLY1305_IETF_KEYBYTES"); err != nil {
+		return "", err
+	}
+	aead, _ := chacha20poly1305.NewX([]byte(key))
+	message, err := aead.Open(nil, []byte(nonce), []byte(ciphertext), []byte(additionalData))
+	if err != nil {
+		return "", errSodiumDecrypt
+	}
+	return string(message), nil
+}
+
+// SodiumGenerichashState the state of the incremental BLAKE2b of SodiumCryptoGenerichashInit
+type SodiumGenerichashState struct {
+	h hash.Hash
+}
+
+// SodiumCryptoGenerichashKeygen sodium_crypto_generichash_keygen()
+func SodiumCryptoGenerichashKeygen() (string, error) {
+	return sodiumRandom(SodiumCryptoGenerichashKeybytes)
+}
+
+func sodiumGenerichash(fn, key string, length []int) (hash.Hash, error) {
+	size := SodiumCryptoGenerichashBytes
+	if len(length) > 0 {
+		size = length[0]
+	}
+	if key != "" && (len(key) < SodiumCryptoGenerichashKeybytesMin || len(key) > SodiumCryptoGenerichashKeybytesMax) {
+		return nil, errors.New(fn + "(): Argument #2 ($key) must be between SODIUM_CRYPTO_GENERICHASH_KEYBYTES_MIN and SODIUM_CRYPTO_GENERICHASH_KEYBYTES_MAX bytes long")
+	}
+	if size < SodiumCryptoGenerichashBytesMin || size > SodiumCryptoGenerichashBytesMax {
+		return nil, errors.New(fn + "(): Argument #3 ($length) must be between SODIUM_CRYPTO_GENERICHASH_BYTES_MIN and SODIUM_CRYPTO_GENERICHASH_BYTES_MAX")
+	}
+	return blake2b.New(size, []byte(key))
+}
+
+// SodiumCryptoGenerichash sodium_crypto_generichash()
+// The BLAKE2b of length bytes, 32 by default, keyed with key unless it is empty
+// SodiumCryptoGenerichash("message", ""), SodiumCryptoGenerichash("message", key, 64)
+func SodiumCryptoGenerichash(message, key string, length ...int) (string, error) {
+	h, err := sodiumGenerichash("sodium_crypto_generichash", key, length)
+	if err != nil {
+		return "", err
+	}
+	h.Write([]byte(message))
+	return string(h.Sum(nil)), nil
+}
+
+// SodiumCryptoGenerichashInit sodium_crypto_generichash_init()
+func SodiumCryptoGenerichashInit(key string, length ...int) (*SodiumGenerichashState, error) {
+	h, err := sodiumGenerichash("sodium_crypto_generichash_init", key, length)
+	if err != nil {
+		return nil, err
+	}
+	return &SodiumGenerichashState{h}, nil
+}
+
+// SodiumCryptoGenerichashUpdate sodium_crypto_generichash_update()
+func SodiumCryptoGenerichashUpdate(state *SodiumGenerichashState, message string) error {
+	if state == nil || state.h == nil {
+		return errors.New("sodium_crypto_generichash_update(): Argument #1 ($state) must have a correct length")
+	}
+	state.h.Write([]byte(message))
+	return nil
+}
+
+// SodiumCryptoGenerichashFinal sodium_crypto_generichash_final()
+// The first length bytes, 32 by default, of the hash of the length of SodiumCryptoGenerichashInit,
+// the state can't be updated anymore
+func SodiumCryptoGenerichashFinal(state *SodiumGenerichashState, length ...int) (string, error) {
+	size := SodiumCryptoGenerichashBytes
+	if len(length) > 0 {
+		size = length[0]
+	}
+	if state == nil || state.h == nil {
+		return "", errors.New("sodium_crypto_generichash_final(): Argument #1 ($state) must have a correct length")
+	}
+	if size < SodiumCryptoGenerichashBytesMin || size > state.h.Size() {
+		return "", errors.New("sodium_crypto_generichash_final(): Argument #2 ($length) must be between SODIUM_CRYPTO_GENERICHASH_BYTES_MIN and SODIUM_CRYPTO_GENERICHASH_BYTES_MAX")
+	}
+	sum := state.h.Sum(nil)
+	state.h = nil
+	return string(sum[:size]), nil
+}
+
+// SodiumCryptoPwhash sodium_crypto_pwhash()
+// A key of length bytes derived from password with Argon2id, or Argon2i with SodiumCryptoPwhashAlgArgon2i13,
+// of opslimit passes over memlimit bytes and a single lane like crypto_pwhash
+// SodiumCryptoPwhash(32, password, salt, SodiumCryptoPwhashOpslimitInteractive, SodiumCryptoPwhashMemlimitInteractive)
+func SodiumCryptoPwhash(length int, password, salt string, opslimit, memlimit int, algo ...int) (string, error) {
+	alg := SodiumCryptoPwhashAlgDefault
+	if len(algo) > 0 {
+		alg = algo[0]
+	}
+	if length < 16 {
+		return "", errors.New("sodium_crypto_pwhash(): Argument #1 ($length) must be greater than or equal to 16")
+	}
+	if err := sodiumLength("sodium_crypto_pwhash", 3, "salt", salt, SodiumCryptoPwhashSaltbytes, "SODIUM_CRYPTO_PWHASH_SALTBYTES"); err != nil {
+		return "", err
+	}
+	p := argon2Params{algo: PasswordArgon2id, version: argon2.Version, threads: 1, salt: []byte(salt)}
+	minOps := 1
+	switch alg {
+	case SodiumCryptoPwhashAlgArgon2id13:
+	case SodiumCryptoPwhashAlgArgon2i13:
+		p.algo, minOps = PasswordArgon2i, 3
+	default:
+		return "", errors.New("sodium_crypto_pwhash(): Argument #6 ($algo) must be a valid password hashing algorithm")
+	}
+	if err := sodiumPwhashLimits("sodium_crypto_pwhash", 4, opslimit, minOps, memlimit); err != nil {
+		return "", err
+	}
+	p.timeCost, p.memoryCost = uint32(opslimit), uint32(memlimit/1024)
+	return string(p.key(password, uint32(length))), nil
+}
+
+func sodiumPwhashLimits(fn string, arg, opslimit, minOps, memlimit int) error {
+	if opslimit < minOps || uint64(opslimit) > 0xffffffff {
+		return fmt.Errorf("%s(): Argument #%d ($opslimit) must be greater than or equal to %d", fn, arg, minOps)
+	}
+	if memlimit < sodiumPwhashMemlimitMin || uint64(memlimit/1024) > 0xffffffff {
+		return fmt.Errorf("%s(): Argument #%d ($memlimit) must be greater than or equal to %d", fn, arg+1, sodiumPwhashMemlimitMin)
+	}
+	return nil
+}
+
+// SodiumCryptoPwhashStr sodium_crypto_pwhash_str()
+// The encoded Argon2id hash of crypto_pwhash_str, $argon2id$v=19$m=65536,t=2,p=1$salt$hash
+func SodiumCryptoPwhashStr(password string, opslimit, memlimit int) (string, error) {
+	if err := sodiumPwhashLimits("sodium_crypto_pwhash_str", 2, opslimit, 1, memlimit); err != nil {
+		return "", err
+	}
+	salt, err := sodiumRandom(SodiumCryptoPwhashSaltbytes)
+	if err != nil {
+		return "", err
+	}
+	p := argon2Params{algo: PasswordArgon2id, version: argon2.Version, threads: 1, salt: []byte(salt),
+		timeCost: uint32(opslimit), memoryCost: uint32(memlimit / 1024)}
+	p.hash = p.key(password, 32)
+	return p.String(), nil
+}
+
+// SodiumCryptoPwhashStrVerify sodium_crypto_pwhash_str_verify()
+// Works for the Argon2id and Argon2i hashes of crypto_pwhash_str and PasswordHash
+func SodiumCryptoPwhashStrVerify(hash, password string) bool {
+	p, ok := parseArgon2(hash)
+	return ok && subtle.ConstantTimeCompare(p.hash, p.key(password, uint32(len(p.hash)))) == 1
+}
+
+// SodiumCryptoPwhashStrNeedsRehash sodium_crypto_pwhash_str_needs_rehash()
+// True unless hash is an Argon2id hash of opslimit and memlimit
+func SodiumCryptoPwhashStrNeedsRehash(hash string, opslimit, memlimit int) bool {
+	p, ok := parseArgon2(hash)
+	return !ok || p.algo != PasswordArgon2id || p.version != argon2.Version ||
+		int(p.timeCost) != opslimit || int(p.memoryCost) != memlimit/1024
+}
+
+// SodiumCryptoKxKeypair sodium_crypto_kx_keypair()
+// The X25519 secret key followed by its public key
+func SodiumCryptoKxKeypair() (string, error) {
+	return SodiumCryptoBoxKeypair()
+}
+
+// SodiumCryptoKxSeedKeypair sodium_crypto_kx_seed_keypair()
+// The secret key is the 32 bytes BLAKE2b of seed like crypto_kx_seed_keypair
+func SodiumCryptoKxSeedKeypair(seed string) (string, error) {
+	if err := sodiumLength("sodium_crypto_kx_seed_keypair", 1, "seed", seed, SodiumCryptoKxSeedbytes, "SODIUM_CRYPTO_KX_SEEDBYTES"); err != nil {
+		return "", err
+	}
+	sk := blake2b.Sum256([]byte(seed))
+	pk, _ := curve25519.X25519(sk[:], curve25519.Basepoint)
+	return string(sk[:]) + string(pk), nil
+}
+
+// SodiumCryptoKxSecretkey sodium_crypto_kx_secretkey()
+func SodiumCryptoKxSecretkey(keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_kx_secretkey", 1, "key_pair", keyPair, SodiumCryptoKxKeypairbytes, "SODIUM_CRYPTO_KX_KEYPAIRBYTES"); err != nil {
+		return "", err
+	}
+	return keyPair[:SodiumCryptoKxSecretkeybytes], nil
+}
+
+// SodiumCryptoKxPublickey sodium_crypto_kx_publickey()
+func SodiumCryptoKxPublickey(keyPair string) (string, error) {
+	if err := sodiumLength("sodium_crypto_kx_publickey", 1, "key_pair", keyPair, SodiumCryptoKxKeypairbytes, "SODIUM_CRYPTO_KX_KEYPAIRBYTES"); err != nil {
+		return "", err
+	}
+	return keyPair[SodiumCryptoKxSecretkeybytes:], nil
+}
+
+// sodiumKx the 64 bytes BLAKE2b of the X25519 shared secret, the client public key and the server public key
+func sodiumKx(fn, keyPair, publicKey string, client bool) ([]byte, error) {
+	pairName, keyName := "client_key_pair", "server_key"
+	if !client {
+		pairName, keyName = "server_key_pair", "client_key"
+	}
+	if err := sodiumLength(fn, 1, pairName, keyPair, SodiumCryptoKxKeypairbytes, "SODIUM_CRYPTO_KX_KEYPAIRBYTES"); err != nil {
+		return nil, err
+	}
+	if err := sodiumLength(fn, 2, keyName, publicKey, SodiumCryptoKxPublickeybytes, "SODIUM_CRYPTO_KX_PUBLICKEYBYTES"); err != nil {
+		return nil, err
+	}
+	q, err := curve25519.X25519([]byte(keyPair[:SodiumCryptoKxSecretkeybytes]), []byte(publicKey))
+	if err != nil {
+		return nil, errors.New(fn + "(): Internal error")
+	}
+	h, _ := blake2b.New512(nil)
+	h.Write(q)
+	if client {
+		h.Write([]byte(keyPair[SodiumCryptoKxSecretkeybytes:]))
+		h.Write([]byte(publicKey))
+	} else {
+		h.Write([]byte(publicKey))
+		h.Write([]byte(keyPair[SodiumCryptoKxSecretkeybytes:]))
+	}
+	return h.Sum(nil), nil
+}
+
+// SodiumCryptoKxClientSessionKeys sodium_crypto_kx_client_session_keys()
+// The keys to receive from and to transmit to the server
+// rx, tx, err := SodiumCryptoKxClientSessionKeys(clientKeyPair, serverPublicKey)
+func SodiumCryptoKxClientSessionKeys(clientKeyPair, serverKey string) (rx, tx string, err error) {
+	h, err := sodiumKx("sodium_crypto_kx_client_session_keys", clientKeyPair, serverKey, true)
+	if err != nil {
+		return "", "", err
+	}
+	return string(h[:SodiumCryptoKxSessionkeybytes]), string(h[SodiumCryptoKxSessionkeybytes:]), nil
+}
+
+// SodiumCryptoKxServerSessionKeys sodium_crypto_kx_server_session_keys()
+// The keys to receive from and to transmit to the client
+// rx, tx, err := SodiumCryptoKxServerSessionKeys(serverKeyPair, clientPublicKey)
+func SodiumCryptoKxServerSessionKeys(serverKeyPair, clientKey string) (rx, tx string, err error) {
+	h, err := sodiumKx("sodium_crypto_kx_server_session_keys", serverKeyPair, clientKey, false)
+	if err != nil {
+		return "", "", err
+	}
+	return string(h[SodiumCryptoKxSessionkeybytes:]), string(h[:SodiumCryptoKxSessionkeybytes]), nil
+}
+
+func sodiumBase64Encoding(id int) *base64.Encoding {
+	switch id {
+	case SodiumBase64VariantOriginal:
+		return base64.StdEncoding
+	case SodiumBase64VariantOriginalNoPadding:
+		return base64.RawStdEncoding
+	case SodiumBase64VariantUrlsafe:
+		return base64.URLEncoding
+	case SodiumBase64VariantUrlsafeNoPadding:
+		return base64.RawURLEncoding
+	}
+	return nil
+}
+
+// SodiumBin2base64 sodium_bin2base64()
+// id is one of the SodiumBase64Variant* constants
+func SodiumBin2base64(str string, id int) (string, error) {
+	enc := sodiumBase64Encoding(id)
+	if enc == nil {
+		return "", errors.New("sodium_bin2base64(): Argument #2 ($id) must be a valid base64 variant identifier")
+	}
+	return enc.EncodeToString([]byte(str)), nil
+}
+
+// SodiumBase642bin sodium_base642bin()
+// Strict like sodium_base642bin2, the padding must match the variant and the unused bits must be zero,
+// the characters of ignore are skipped
+func SodiumBase642bin(str string, id int, ignore ...string) (string, error) {
+	enc := sodiumBase64Encoding(id)
+	if enc == nil {
+		return "", errors.New("sodium_base642bin(): Argument #2 ($id) must be a valid base64 variant identifier")
+	}
+	if len(ignore) > 0 && ignore[0] != "" {
+		str = strings.Map(func(r rune) rune {
+			if strings.ContainsRune(ignore[0], r) {
+				return -1
+			}
+			return r
+		}, str)
+	}
+	if strings.ContainsAny(str, "\r\n") {
+		return "", errors.New("sodium_base642bin(): Argument #1 ($string) must be a valid base64 string")
+	}
+	b, err := enc.Strict().DecodeString(str)
+	if err != nil {
+		return "", errors.New("sodium_base642bin(): Argument #1 ($string) must be a valid base64 string")
+	}
+	return string(b), nil
+}
+
+// SodiumMemcmp sodium_memcmp()
+// 0 when the strings are equal and -1 otherwise, in constant time
+func SodiumMemcmp(string1, string2 string) (int, error) {
+	if len(string1) != len(string2) {
+		return 0, errors.New("sodium_memcmp(): arguments have different sizes")
+	}
+	if subtle.ConstantTimeCompare([]byte(string1), []byte(string2)) == 1 {
+		return 0, nil
+	}
+	return -1, nil
+}
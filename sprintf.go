@@ -0,0 +1,353 @@
+package php2go
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	sprintfIntMax       = math.MaxInt32
+	sprintfFloatDigits  = 6
+	sprintfMaxPrecision = 53
+)
+
+// phpSprintf php_formatted_print()
+// extra is the number of parameters before the arguments in the PHP signature, used by the error messages,
+// -1 when the arguments come from an array (vsprintf, vprintf).
+func phpSprintf(format string, args []interface{}, extra int) (string, error) {
+	at := func(i int) byte {
+		if i < len(format) {
+			return format[i]
+		}
+		return 0
+	}
+	buf := make([]byte, 0, len(format))
+	currarg, maxMissing := 0, -1
+	for i := 0; i < len(format); {
+		if format[i] != '%' {
+			buf = append(buf, format[i])
+			i++
+			continue
+		}
+		if at(i+1) == '%' {
+			buf = append(buf, '%')
+			i += 2
+			continue
+		}
+		i++
+
+		// starting a new format specifier
+		alignLeft, alwaysSign, adjPrecision, expprec := false, false, false, false
+		padding := byte(' ')
+		width, precision, argnum := 0, 0, -1
+		if c := at(i); c < 0x80 && !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z') {
+			// first look for argnum
+			var err error
+			if argnum, err = sprintfArgnum(format, &i); err != nil {
+				return "", err
+			}
+
+			// after argnum comes modifiers
+		modifiers:
+			for ; ; i++ {
+				switch at(i) {
+				case ' ', '0':
+					padding = at(i)
+				case '-':
+					alignLeft = true
+				case '+':
+					alwaysSign = true
+				case '\'':
+					if i+1 >= len(format) {
+						return "", errors.New("Missing padding character")
+					}
+					i++
+					padding = format[i]
+				default:
+					break modifiers
+				}
+			}
+
+			// after modifiers comes width
+			if at(i) == '*' {
+				i++
+				argnumWidth, err := sprintfArgnum(format, &i)
+				if err != nil {
+					return "", err
+				}
+				if argnumWidth < 0 {
+					argnumWidth = currarg
+					currarg++
+				}
+				if argnumWidth >= len(args) {
+					if argnumWidth > maxMissing {
+						maxMissing = argnumWidth
+					}
+					continue
+				}
+				w, ok := sprintfInt(args[argnumWidth])
+				if !ok {
+					return "", errors.New("Width must be an integer")
+				}
+				if w < 0 || w > sprintfIntMax {
+					return "", fmt.Errorf("Width must be greater than or equal to zero and less than %d", sprintfIntMax)
+				}
+				width = int(w)
+			} else if isDigit(at(i)) {
+				if width = sprintfNumber(format, &i); width < 0 {
+					return "", fmt.Errorf("Width must be greater than zero and less than %d", sprintfIntMax)
+				}
+			}
+
+			// after width and argnum comes precision
+			if at(i) == '.' {
+				i++
+				adjPrecision = true
+				if at(i) == '*' {
+					i++
+					argnumPrecision, err := sprintfArgnum(format, &i)
+					if err != nil {
+						return "", err
+					}
+					if argnumPrecision < 0 {
+						argnumPrecision = currarg
+						currarg++
+					}
+					if argnumPrecision >= len(args) {
+						if argnumPrecision > maxMissing {
+							maxMissing = argnumPrecision
+						}
+						continue
+					}
+					p, ok := sprintfInt(args[argnumPrecision])
+					if !ok {
+						return "", errors.New("Precision must be an integer")
+					}
+					if p < -1 || p > sprintfIntMax {
+						return "", fmt.Errorf("Precision must be between -1 and %d", sprintfIntMax)
+					}
+					precision = int(p)
+					expprec = true
+				} else if isDigit(at(i)) {
+					if precision = sprintfNumber(format, &i); precision < 0 {
+						return "", fmt.Errorf("Precision must be greater than zero and less than %d", sprintfIntMax)
+					}
+					expprec = true
+				}
+			}
+		}
+
+		if at(i) == 'l' {
+			i++
+		}
+		if argnum < 0 {
+			argnum = currarg
+			currarg++
+		}
+		if argnum >= len(args) {
+			if argnum > maxMissing {
+				maxMissing = argnum
+			}
+			continue
+		}
+		if expprec && precision == -1 && !strings.ContainsRune("gGhH", rune(at(i))) {
+			return "", errors.New("Precision -1 is only supported for %g, %G, %h and %H")
+		}
+
+		// now we expect to find a type specifier
+		arg := args[argnum]
+		switch c := at(i); c {
+		case 's':
+			buf = sprintfAppendString(buf, phpStringVal(arg), width, precision, padding, alignLeft, false, expprec, false)
+		case 'd':
+			buf = sprintfAppendInt(buf, phpIntVal(arg), width, padding, alignLeft, alwaysSign)
+		case 'u':
+			if alignLeft && padding == '0' {
+				padding = ' '
+			}
+			buf = sprintfAppendString(buf, strconv.FormatUint(uint64(phpIntVal(arg)), 10), width, 0, padding, alignLeft, false, false, false)
+		case 'e', 'E', 'f', 'F', 'g', 'G', 'h', 'H':
+			buf = sprintfAppendDouble(buf, phpFloatVal(arg), width, padding, alignLeft, precision, adjPrecision, c, alwaysSign)
+		case 'c':
+			buf = append(buf, byte(phpIntVal(arg)))
+		case 'o', 'x', 'X', 'b':
+			base := map[byte]int{'o': 8, 'x': 16, 'X': 16, 'b': 2}[c]
+			s := strconv.FormatUint(uint64(phpIntVal(arg)), base)
+			if c == 'X' {
+				s = strings.ToUpper(s)
+			}
+			buf = sprintfAppendString(buf, s, width, 0, padding, alignLeft, false, expprec, false)
+		case '%':
+			buf = append(buf, '%')
+		default:
+			if i >= len(format) {
+				return "", errors.New("Missing format specifier at end of string")
+			}
+			return "", fmt.Errorf("Unknown format specifier \"%c\"", c)
+		}
+		i++
+	}
+
+	if maxMissing >= 0 {
+		if extra < 0 {
+			return "", fmt.Errorf("The arguments array must contain %d items, %d given", maxMissing+1, len(args))
+		}
+		return "", fmt.Errorf("%d arguments are required, %d given", maxMissing+extra+1, len(args)+extra)
+	}
+	return string(buf), nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// sprintfNumber the decimal number at format[*i:], -1 when it is not less than the max int32
+func sprintfNumber(format string, i *int) int {
+	start := *i
+	for *i < len(format) && isDigit(format[*i]) {
+		*i++
+	}
+	n, err := strconv.ParseInt(format[start:*i], 10, 64)
+	if err != nil || n >= sprintfIntMax {
+		return -1
+	}
+	return int(n)
+}
+
+// sprintfArgnum the zero based index of a "n$" argument number specifier, -1 when there is none
+func sprintfArgnum(format string, i *int) (int, error) {
+	j := *i
+	for j < len(format) && isDigit(format[j]) {
+		j++
+	}
+	if j >= len(format) || format[j] != '$' {
+		return -1, nil
+	}
+	argnum := sprintfNumber(format, i)
+	if argnum <= 0 {
+		return 0, fmt.Errorf("Argument number specifier must be greater than zero and less than %d", sprintfIntMax)
+	}
+	*i++
+	return argnum - 1, nil
+}
+
+// sprintfInt the value of an integer argument, PHP only accepts int for * width and precision
+func sprintfInt(value interface{}) (int64, bool) {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return phpIntVal(value), true
+	}
+	return 0, false
+}
+
+// sprintfAppendString php_sprintf_appendstring()
+func sprintfAppendString(buf []byte, add string, minWidth, precision int, padding byte, alignLeft, neg, expprec, alwaysSign bool) []byte {
+	copyLen := len(add)
+	if expprec && precision < copyLen {
+		copyLen = precision
+	}
+	npad := 0
+	if minWidth > copyLen {
+		npad = minWidth - copyLen
+	}
+	if !alignLeft {
+		if (neg || alwaysSign) && padding == '0' {
+			// the sign goes before the zeros
+			if neg {
+				buf = append(buf, '-')
+			} else {
+				buf = append(buf, '+')
+			}
+			add = add[1:]
+			copyLen--
+		}
+		for ; npad > 0; npad-- {
+			buf = append(buf, padding)
+		}
+	}
+	buf = append(buf, add[:copyLen]...)
+	for ; npad > 0; npad-- {
+		buf = append(buf, padding)
+	}
+	return buf
+}
+
+// sprintfAppendInt php_sprintf_appendint()
+func sprintfAppendInt(buf []byte, number int64, width int, padding byte, alignLeft, alwaysSign bool) []byte {
+	// can't right-pad 0's on integers
+	if alignLeft && padding == '0' {
+		padding = ' '
+	}
+	s := strconv.FormatInt(number, 10)
+	if number >= 0 && alwaysSign {
+		s = "+" + s
+	}
+	return sprintfAppendString(buf, s, width, 0, padding, alignLeft, number < 0, false, alwaysSign)
+}
+
+// sprintfAppendDouble php_sprintf_appenddouble()
+func sprintfAppendDouble(buf []byte, number float64, width int, padding byte, alignLeft bool, precision int, adjPrecision bool, format byte, alwaysSign bool) []byte {
+	if !adjPrecision {
+		precision = sprintfFloatDigits
+	} else if precision > sprintfMaxPrecision {
+		precision = sprintfMaxPrecision
+	}
+
+	if math.IsNaN(number) {
+		return sprintfAppendString(buf, "NaN", 3, 0, padding, alignLeft, false, false, alwaysSign)
+	}
+	if math.IsInf(number, 0) {
+		s := "Inf"
+		if number < 0 {
+			s = "-Inf"
+		} else if alwaysSign {
+			s = "+Inf"
+		}
+		return sprintfAppendString(buf, s, width, 0, padding, alignLeft, number < 0, false, alwaysSign)
+	}
+
+	var s string
+	neg := false
+	switch format {
+	case 'e', 'E', 'f', 'F':
+		neg = number < 0
+		s = sprintfConvFp(format, math.Abs(number), precision)
+		if neg {
+			s = "-" + s
+		} else if alwaysSign {
+			s = "+" + s
+		}
+	default:
+		if precision == 0 {
+			precision = sprintfFloatDigits
+		}
+		exp := byte('e')
+		if format == 'G' || format == 'H' {
+			exp = 'E'
+		}
+		s = phpGcvt(number, precision, exp)
+		neg = s[0] == '-'
+		if !neg && alwaysSign {
+			s = "+" + s
+		}
+	}
+	return sprintfAppendString(buf, s, width, 0, padding, alignLeft, neg, false, alwaysSign)
+}
+
+// sprintfConvFp php_conv_fp(), the exponent has no leading zeros: 1.5e+3
+func sprintfConvFp(format byte, number float64, precision int) string {
+	if format == 'f' || format == 'F' {
+		return strconv.FormatFloat(number, 'f', precision, 64)
+	}
+	s := strconv.FormatFloat(number, 'e', precision, 64)
+	i := strings.IndexByte(s, 'e')
+	exp, _ := strconv.Atoi(s[i+1:])
+	sign := byte('+')
+	if exp < 0 {
+		sign, exp = '-', -exp
+	}
+	return s[:i] + string([]byte{format, sign}) + strconv.Itoa(exp)
+}
@@ -0,0 +1,956 @@
+package php2go
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateSpecialWeekday = iota + 1
+	dateSpecialDayOfWeekInMonth
+	dateSpecialLastDayOfWeekInMonth
+)
+
+const (
+	dateFirstDayOf = 1
+	dateLastDayOf  = 2
+)
+
+// dateRelative the relative part of a parsed date: "+1 week", "next monday", "last day of"
+type dateRelative struct {
+	y, m, d, h, i, s, us int
+	weekday              int
+	weekdayBehavior      int
+	haveWeekday          bool
+	firstLast            int
+	special              int
+	specialAmount        int
+	haveSpecial          bool
+}
+
+// building blocks of php's strtotime grammar (timelib parse_date.re)
+const (
+	reSpace         = `[ \t]+`
+	reFrac          = `\.[0-9]+`
+	reHour24        = `(?:2[0-4]|[01]?[0-9])`
+	reHour24lz      = `(?:[01][0-9]|2[0-4])`
+	reHour12        = `(?:1[0-2]|0?[1-9])`
+	reMinute        = `[0-5]?[0-9]`
+	reMinutelz      = `[0-5][0-9]`
+	reSecond        = `(?:60|[0-5]?[0-9])`
+	reSecondlz      = `(?:60|[0-5][0-9])`
+	reMeridian      = `[ap]\.?m\.?[\x00\t ]`
+	reTz            = `(?:\(?[a-z]{1,6}\)?|(?-i:[A-Z][a-z]+(?:[_/-][A-Za-z]+)+))`
+	reTzCorrection  = `(?:gmt)?[+-](?:` + reHour24lz + `:?` + reMinutelz + `:?` + reSecondlz + `|` + reHour24 + `(?::?` + reMinute + `)?)`
+	reDay           = `(?:3[01]|[0-2]?[0-9])(?:st|nd|rd|th)?`
+	reMonth         = `(?:1[0-2]|0?[0-9])`
+	reYear          = `[0-9]{1,4}`
+	reYear2         = `[0-9]{2}`
+	reYear4         = `[0-9]{4}`
+	reMonthlz       = `(?:0[0-9]|1[0-2])`
+	reDaylz         = `(?:0[0-9]|[12][0-9]|3[01])`
+	reDayOfYear     = `(?:00[1-9]|0[1-9][0-9]|[12][0-9][0-9]|3[0-5][0-9]|36[0-6])`
+	reWeekOfYear    = `(?:0[1-9]|[1-4][0-9]|5[0-3])`
+	reDayFull       = `(?:sundays?|mondays?|tuesdays?|wednesdays?|thursdays?|fridays?|saturdays?)`
+	reDayAbbr       = `(?:sun|mon|tue|wed|thu|fri|sat)`
+	reDayText       = `(?:` + reDayFull + `|` + reDayAbbr + `|weekdays?)`
+	reMonthFull     = `(?:january|february|march|april|may|june|july|august|september|october|november|december)`
+	reMonthAbbr     = `(?:jan|feb|mar|apr|may|jun|jul|aug|sept?|oct|nov|dec)`
+	reMonthText     = `(?:` + reMonthFull + `|` + reMonthAbbr + `|(?-i:XII|XI|X|IX|VIII|VII|VI|V|IV|III|II|I))`
+	reRelTextNumber = `(?:first|second|third|fourth|fifth|sixth|seventh|eighth|eight|ninth|tenth|eleventh|twelfth)`
+	reRelTextText   = `(?:next|last|previous|this)`
+	reRelTextUnit   = `(?:ms|µs|(?:msec|millisecond|µsec|microsecond|usec|sec|second|min|minute|hour|day|fortnight|forthnight|month|year)s?|weeks|` + reDayText + `)`
+
+	reTimeShort12   = reHour12 + `[:.]` + reMinute + `(?:` + reSpace + `)?` + reMeridian
+	reTimeLong12    = reHour12 + `[:.]` + reMinute + `[:.]` + reSecond + `(?:` + reSpace + `)?` + reMeridian
+	reTimeShort24   = `t?` + reHour24 + `[:.]` + reMinute
+	reTimeLong24    = `t?` + reHour24 + `[:.]` + reMinute + `[:.]` + reSecond
+	reIso8601Long   = `t?` + reHour24 + `[:.]` + reMinute + `[:.]` + reSecond + reFrac
+	reIso8601NormTz = `t?` + reHour24 + `[:.]` + reMinute + `[:.]` + reSecondlz + `(?:` + reSpace + `)?(?:` + reTzCorrection + `|` + reTz + `)`
+	reDateNoYear    = reMonthText + `[ .\t-]*` + reDay + `[,.stndrh\t ]*`
+)
+
+type dateRule struct {
+	re     *regexp.Regexp
+	action func(p *dateParsed, c *dateScanner)
+}
+
+var dateRules []dateRule
+
+func init() {
+	rule := func(pattern string, action func(p *dateParsed, c *dateScanner)) {
+		re := regexp.MustCompile(`^(?i:` + pattern + `)`)
+		re.Longest()
+		dateRules = append(dateRules, dateRule{re, action})
+	}
+	rule(`yesterday`, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		p.unhaveTime()
+		p.rel.d = -1
+	})
+	rule(`now`, func(p *dateParsed, c *dateScanner) {})
+	rule(`noon`, func(p *dateParsed, c *dateScanner) {
+		p.unhaveTime()
+		p.setHaveTime(c)
+		p.h = 12
+	})
+	rule(`midnight|today`, func(p *dateParsed, c *dateScanner) {
+		p.unhaveTime()
+	})
+	rule(`tomorrow`, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		p.unhaveTime()
+		p.rel.d = 1
+	})
+	timestamp := func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		p.haveDate, p.haveTime = false, false
+		p.y, p.m, p.d, p.h, p.i, p.s, p.us = 1970, 1, 1, 0, 0, 0, 0
+		n := c.signedNr(24)
+		p.rel.s += n
+		if c.peek() == '.' {
+			us := c.frac()
+			if n < 0 || strings.HasPrefix(c.str, "@-") {
+				us = -us
+			}
+			p.rel.us += us
+		}
+		p.setZone(c, dateZoneTypeOffset, 0)
+	}
+	rule(`@-?[0-9]+`, timestamp)
+	rule(`@-?[0-9]+\.[0-9]{0,6}`, timestamp)
+	rule(`(?:first|last) day of`, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		if c.str[0] == 'l' || c.str[0] == 'L' {
+			p.rel.firstLast = dateLastDayOf
+		} else {
+			p.rel.firstLast = dateFirstDayOf
+		}
+	})
+	rule(`(?:back|front) of `+reHour24+`(?:(?:`+reSpace+`)?`+reMeridian+`)?`, func(p *dateParsed, c *dateScanner) {
+		p.unhaveTime()
+		p.setHaveTime(c)
+		back := c.str[0] == 'b' || c.str[0] == 'B'
+		p.h = c.nr(2)
+		if c.hasMeridian() {
+			p.h += c.meridian(p.h)
+		}
+		if back {
+			p.i = 15
+		} else {
+			p.h--
+			p.i = 45
+		}
+	})
+	rule(`(?:`+reRelTextNumber+`|`+reRelTextText+`)`+reSpace+`(?:`+reDayFull+`|`+reDayAbbr+`)`+reSpace+`of`, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		p.rel.haveSpecial = true
+		amount, behavior := c.relativeText()
+		if amount > 0 {
+			p.rel.special = dateSpecialDayOfWeekInMonth
+			p.setRelative(c, amount, 1, false)
+		} else {
+			p.rel.special = dateSpecialLastDayOfWeekInMonth
+			p.setRelative(c, amount, behavior, false)
+		}
+	})
+	rule(reHour12+`(?:`+reSpace+`)?`+reMeridian+`|`+reTimeShort12+`|`+reTimeLong12, func(p *dateParsed, c *dateScanner) {
+		p.setHaveTime(c)
+		p.h = c.nr(2)
+		if c.peek() == ':' || c.peek() == '.' {
+			p.i = c.nr(2)
+			if c.peek() == ':' || c.peek() == '.' {
+				p.s = c.nr(2)
+			}
+		}
+		p.h += c.meridian(p.h)
+	})
+	rule(reHour12+`:`+reMinutelz+`:`+reSecondlz+`[:.][0-9]+`+reMeridian, func(p *dateParsed, c *dateScanner) {
+		p.setHaveTime(c)
+		p.h = c.nr(2)
+		p.i = c.nr(2)
+		p.s = c.nr(2)
+		if c.peek() == ':' || c.peek() == '.' {
+			p.us = c.frac()
+		}
+		p.h += c.meridian(p.h)
+	})
+	rule(`t`+reHour24+`|`+reTimeShort24+`|`+reTimeLong24+`|`+reIso8601Long, func(p *dateParsed, c *dateScanner) {
+		p.setHaveTime(c)
+		p.h = c.nr(2)
+		if c.peek() == ':' || c.peek() == '.' {
+			p.i = c.nr(2)
+			if c.peek() == ':' || c.peek() == '.' {
+				p.s = c.nr(2)
+				if c.peek() == '.' {
+					p.us = c.frac()
+				}
+			}
+		}
+	})
+	rule(`t?`+reHour24lz+reMinutelz, func(p *dateParsed, c *dateScanner) {
+		switch {
+		case !p.haveTime:
+			p.setHaveTime(c)
+			p.h = c.nr(2)
+			p.i = c.nr(2)
+			p.s = 0
+		case p.y == dateUnset:
+			p.y = c.nr(4)
+		default:
+			p.addError(c.begin, "Double time specification")
+		}
+	})
+	rule(`t?`+reHour24lz+reMinutelz+reSecondlz, func(p *dateParsed, c *dateScanner) {
+		p.setHaveTime(c)
+		p.h = c.nr(2)
+		p.i = c.nr(2)
+		p.s = c.nr(2)
+	})
+	rule(reMonth+`/`+reDay+`(?:/`+reYear+`)?`, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.m = c.nr(2)
+		p.d = c.nr(2)
+		if c.peek() == '/' {
+			p.y = c.year(4)
+		}
+	})
+	rule(`[+-]?`+reYear4+`-`+reMonthlz+`-`+reDaylz+`|`+reYear4+`/`+reMonthlz+`/`+reDaylz+`/?|`+reYear4+`/`+reMonth+`/`+reDay, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.signedNr(4)
+		p.m = c.nr(2)
+		p.d = c.nr(2)
+	})
+	rule(reYear2+`-`+reMonthlz+`-`+reDaylz, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.year(4)
+		p.m = c.nr(2)
+		p.d = c.nr(2)
+	})
+	rule(`[+-][0-9]{5,19}-`+reMonthlz+`-`+reDaylz, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.signedNr(19)
+		p.m = c.nr(2)
+		p.d = c.nr(2)
+	})
+	rule(reYear4+`-`+reMonth, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.year(4)
+		p.m = c.nr(2)
+		p.d = 1
+	})
+	rule(reYear+`-`+reMonth+`-`+reDay, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.year(4)
+		p.m = c.nr(2)
+		p.d = c.nr(2)
+	})
+	rule(reDay+`[ \t.-]*`+reMonthText+`[ \t.-]*`+reYear, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.d = c.nr(2)
+		c.skipDaySuffix()
+		p.m = c.month()
+		p.y = c.year(4)
+	})
+	rule(reDay+`[.\t-]`+reMonth+`[.-]`+reYear4, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.d = c.nr(2)
+		p.m = c.nr(2)
+		p.y = c.year(4)
+	})
+	rule(reDay+`[.\t]`+reMonth+`\.`+reYear2, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.d = c.nr(2)
+		p.m = c.nr(2)
+		p.y = c.year(2)
+	})
+	rule(reMonthText+`[ .\t-]*`+reYear4, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.m = c.month()
+		p.y = c.year(4)
+		p.d = 1
+	})
+	rule(reYear4+`[ .\t-]*`+reMonthText, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.year(4)
+		p.m = c.month()
+		p.d = 1
+	})
+	rule(reMonthText+`[ .\t-]*`+reDay+`[,.stndrh\t ]*`+reYear+`|`+reDateNoYear, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.m = c.month()
+		p.d = c.nr(2)
+		p.y = c.year(4)
+	})
+	rule(reDay+`[ .\t-]*`+reMonthText, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.d = c.nr(2)
+		c.skipDaySuffix()
+		p.m = c.month()
+	})
+	rule(reYear4+reMonthlz+reDaylz, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.nr(4)
+		p.m = c.nr(2)
+		p.d = c.nr(2)
+	})
+	rule(reYear4+reMonthlz+reDaylz+`t`+reHour24+reMinutelz+reSecondlz+
+		`|`+reYear4+`-`+reMonthlz+`-`+reDaylz+`t`+reHour24lz+`:`+reMinutelz+`:`+reSecondlz+reFrac+`(?:`+reTzCorrection+`)?`+
+		`|`+reYear4+`-`+reMonth+`-`+reDay+`t`+reHour24+`:`+reMinute+`:`+reSecond+
+		`|`+reYear4+`:`+reMonthlz+`:`+reDaylz+` `+reHour24lz+`:`+reMinutelz+`:`+reSecondlz, func(p *dateParsed, c *dateScanner) {
+		p.setHaveTime(c)
+		p.setHaveDate(c)
+		p.y = c.nr(4)
+		p.m = c.nr(2)
+		p.d = c.nr(2)
+		p.h = c.nr(2)
+		p.i = c.nr(2)
+		p.s = c.nr(2)
+		if c.peek() == '.' {
+			p.us = c.frac()
+		}
+		if c.pos < len(c.str) {
+			p.parseZone(c)
+		}
+	})
+	rule(reYear4+`\.?`+reDayOfYear, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.year(4)
+		p.d = c.nr(3)
+		p.m = 1
+	})
+	isoWeek := func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.haveRel = true
+		p.y = c.nr(4)
+		w := c.nr(2)
+		d := c.nr(1)
+		if d == dateUnset {
+			d = 1
+		}
+		p.m, p.d = 1, 1
+		p.rel.d = dateDaynrFromWeeknr(p.y, w, d)
+	}
+	rule(reYear4+`-?w`+reWeekOfYear+`-?[0-7]`, isoWeek)
+	rule(reYear4+`-?w`+reWeekOfYear, isoWeek)
+	rule(reMonthAbbr+`-`+reDaylz+`-`+reYear, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.m = c.month()
+		p.d = c.nr(2)
+		p.y = c.year(4)
+	})
+	rule(reYear+`-`+reMonthAbbr+`-`+reDaylz, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.y = c.year(4)
+		p.m = c.month()
+		p.d = c.nr(2)
+	})
+	rule(reDay+`/`+reMonthAbbr+`/`+reYear4+`:`+reHour24lz+`:`+reMinutelz+`:`+reSecondlz+reSpace+reTzCorrection, func(p *dateParsed, c *dateScanner) {
+		p.setHaveTime(c)
+		p.setHaveDate(c)
+		p.d = c.nr(2)
+		p.m = c.month()
+		p.y = c.nr(4)
+		p.h = c.nr(2)
+		p.i = c.nr(2)
+		p.s = c.nr(2)
+		p.parseZone(c)
+	})
+	rule(reYear4, func(p *dateParsed, c *dateScanner) {
+		p.y = c.nr(4)
+	})
+	rule(`ago`, func(p *dateParsed, c *dateScanner) {
+		r := &p.rel
+		r.y, r.m, r.d, r.h, r.i, r.s, r.us = -r.y, -r.m, -r.d, -r.h, -r.i, -r.s, -r.us
+		r.weekday = -r.weekday
+		if r.weekday == 0 {
+			r.weekday = -7
+		}
+		if r.haveSpecial && r.special == dateSpecialWeekday {
+			r.specialAmount = -r.specialAmount
+		}
+	})
+	rule(reDayFull+`|`+reDayAbbr, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		p.rel.haveWeekday = true
+		p.unhaveTime()
+		unit, _ := c.relunit()
+		p.rel.weekday = unit.multiplier
+		if p.rel.weekdayBehavior != 2 {
+			p.rel.weekdayBehavior = 1
+		}
+	})
+	rule(reRelTextText+reSpace+`week`, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		for c.pos < len(c.str) {
+			start := c.pos
+			amount, behavior := c.relativeText()
+			p.setRelative(c, amount, behavior, false)
+			p.rel.weekdayBehavior = 2
+			if c.pos == start {
+				break
+			}
+			if !p.rel.haveWeekday {
+				p.rel.haveWeekday = true
+				p.rel.weekday = 1
+			}
+		}
+	})
+	rule(`(?:`+reRelTextNumber+`|`+reRelTextText+`)`+reSpace+reRelTextUnit, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		for c.pos < len(c.str) {
+			start := c.pos
+			amount, behavior := c.relativeText()
+			p.setRelative(c, amount, behavior, false)
+			if c.pos == start {
+				break
+			}
+		}
+	})
+	rule(reMonthFull+`|`+reMonthAbbr, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.m = c.month()
+	})
+	rule(reTzCorrection+`|`+reTz, func(p *dateParsed, c *dateScanner) {
+		p.parseZone(c)
+	})
+	rule(reDateNoYear+reTimeShort12+`|`+reDateNoYear+reTimeLong12, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.m = c.month()
+		p.d = c.nr(2)
+		p.setHaveTime(c)
+		p.h = c.nr(2)
+		p.i = c.nr(2)
+		if c.peek() == ':' || c.peek() == '.' {
+			p.s = c.nr(2)
+			if c.peek() == '.' {
+				p.us = c.frac()
+			}
+		}
+		p.h += c.meridian(p.h)
+	})
+	rule(reDateNoYear+reTimeShort24+`|`+reDateNoYear+reTimeLong24+`|`+reDateNoYear+reIso8601NormTz, func(p *dateParsed, c *dateScanner) {
+		p.setHaveDate(c)
+		p.m = c.month()
+		p.d = c.nr(2)
+		p.setHaveTime(c)
+		p.h = c.nr(2)
+		p.i = c.nr(2)
+		if c.peek() == ':' {
+			p.s = c.nr(2)
+			if c.peek() == '.' {
+				p.us = c.frac()
+			}
+		}
+		if c.pos < len(c.str) {
+			p.parseZone(c)
+		}
+	})
+	rule(`[+-]*[ \t]*[0-9]{1,13}(?:`+reSpace+`)?(?:`+reRelTextUnit+`|week)`, func(p *dateParsed, c *dateScanner) {
+		p.haveRel = true
+		for c.pos < len(c.str) {
+			start := c.pos
+			amount := c.signedNr(24)
+			c.eatSpaces()
+			p.setRelative(c, amount, 1, true)
+			if c.pos == start {
+				break
+			}
+		}
+	})
+	rule(`[ .,\t]`, func(p *dateParsed, c *dateScanner) {})
+	rule(`[\x00\n]`, func(p *dateParsed, c *dateScanner) {})
+}
+
+// dateDaynrFromWeeknr day of the year (0 based, relative to January 1st) of an ISO week date
+func dateDaynrFromWeeknr(year, week, day int) int {
+	dow := int(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Weekday())
+	d := -dow
+	if dow > 4 {
+		d = 7 - dow
+	}
+	return d + (week-1)*7 + day
+}
+
+// dateParseString parse a date/time string with php's strtotime grammar
+func dateParseString(str string) *dateParsed {
+	p := newDateParsed()
+	str += "\x00"
+	pos := 0
+	for pos < len(str) {
+		best, length := -1, 0
+		for k, r := range dateRules {
+			if loc := r.re.FindStringIndex(str[pos:]); loc != nil && loc[1] > length {
+				best, length = k, loc[1]
+			}
+		}
+		if best < 0 {
+			p.addError(pos, "Unexpected character")
+			pos++
+			continue
+		}
+		c := &dateScanner{str: strings.TrimRight(str[pos:pos+length], "\x00"), begin: pos}
+		dateRules[best].action(p, c)
+		pos += length
+	}
+	if p.y != dateUnset && p.m != dateUnset && p.d != dateUnset && !p.rel.haveSpecial && !Checkdate(p.m, p.d, p.y) {
+		p.addWarning(len(str)-1, "The parsed date was invalid")
+	}
+	return p
+}
+
+func (p *dateParsed) setHaveTime(c *dateScanner) {
+	if p.haveTime {
+		p.addError(c.begin, "Double time specification")
+		return
+	}
+	p.haveTime = true
+	p.h, p.i, p.s, p.us = 0, 0, 0, 0
+}
+
+func (p *dateParsed) unhaveTime() {
+	p.haveTime = false
+	p.h, p.i, p.s, p.us = 0, 0, 0, 0
+}
+
+func (p *dateParsed) setHaveDate(c *dateScanner) {
+	if p.haveDate {
+		p.addError(c.begin, "Double date specification")
+		return
+	}
+	p.haveDate = true
+}
+
+func (p *dateParsed) setZone(c *dateScanner, zoneType int, offset int) {
+	p.haveZone = true
+	p.zoneType, p.zoneOffset, p.zoneDst, p.zoneAbbr, p.loc = zoneType, offset, false, "", nil
+}
+
+func (p *dateParsed) parseZone(c *dateScanner) {
+	if p.haveZone {
+		p.addError(c.begin, "Double timezone specification")
+		return
+	}
+	if dateParseZone(c.str[c.pos:], p) == 0 {
+		p.addError(c.begin, "The timezone could not be found in the database")
+		return
+	}
+	p.haveZone = true
+	c.pos = len(c.str)
+}
+
+// setRelative apply "amount unit" to the relative part
+func (p *dateParsed) setRelative(c *dateScanner, amount int, behavior int, keepTime bool) {
+	unit, ok := c.relunit()
+	if !ok {
+		return
+	}
+	r := &p.rel
+	switch unit.unit {
+	case 'u':
+		r.us += amount * unit.multiplier
+	case 's':
+		r.s += amount * unit.multiplier
+	case 'i':
+		r.i += amount * unit.multiplier
+	case 'h':
+		r.h += amount * unit.multiplier
+	case 'd':
+		r.d += amount * unit.multiplier
+	case 'm':
+		r.m += amount * unit.multiplier
+	case 'y':
+		r.y += amount * unit.multiplier
+	case 'w':
+		r.haveWeekday = true
+		if !keepTime {
+			p.unhaveTime()
+		}
+		if amount > 0 {
+			r.d += (amount - 1) * 7
+		} else {
+			r.d += amount * 7
+		}
+		r.weekday = unit.multiplier
+		r.weekdayBehavior = behavior
+	case 'x':
+		r.haveSpecial = true
+		if !keepTime {
+			p.unhaveTime()
+		}
+		r.special = dateSpecialWeekday
+		r.specialAmount = amount
+	}
+}
+
+// dateScanner reads the fields of a matched token
+type dateScanner struct {
+	str   string
+	pos   int
+	begin int
+}
+
+func (c *dateScanner) peek() byte {
+	if c.pos < len(c.str) {
+		return c.str[c.pos]
+	}
+	return 0
+}
+
+// nr skip to the next digit and read at most maxLen digits
+func (c *dateScanner) nr(maxLen int) int {
+	n, _ := c.nrLen(maxLen)
+	return n
+}
+
+func (c *dateScanner) nrLen(maxLen int) (int, int) {
+	for c.pos < len(c.str) && !dateIsDigit(c.str, c.pos) {
+		c.pos++
+	}
+	start := c.pos
+	n := dateGetNr(c.str, &c.pos, maxLen)
+	return n, c.pos - start
+}
+
+// year read a year, two digit years are 1970-2069
+func (c *dateScanner) year(maxLen int) int {
+	n, l := c.nrLen(maxLen)
+	if n == dateUnset {
+		return n
+	}
+	return dateProcessYear(n, l)
+}
+
+func (c *dateScanner) signedNr(maxLen int) int {
+	for c.pos < len(c.str) && !dateIsDigit(c.str, c.pos) && c.str[c.pos] != '+' && c.str[c.pos] != '-' {
+		c.pos++
+	}
+	sign := 1
+	for c.pos < len(c.str) && (c.str[c.pos] == '+' || c.str[c.pos] == '-') {
+		if c.str[c.pos] == '-' {
+			sign = -sign
+		}
+		c.pos++
+	}
+	n := c.nr(maxLen)
+	if n == dateUnset {
+		return 0
+	}
+	return sign * n
+}
+
+// frac read ".123" as microseconds
+func (c *dateScanner) frac() int {
+	if c.pos < len(c.str) && (c.str[c.pos] == '.' || c.str[c.pos] == ':') {
+		c.pos++
+	}
+	start := c.pos
+	for c.pos < len(c.str) && dateIsDigit(c.str, c.pos) {
+		c.pos++
+	}
+	f, _ := strconv.ParseFloat("0."+c.str[start:c.pos], 64)
+	return int(math.Round(f * 1e6))
+}
+
+func (c *dateScanner) eatSpaces() {
+	for c.pos < len(c.str) && (c.str[c.pos] == ' ' || c.str[c.pos] == '\t') {
+		c.pos++
+	}
+}
+
+func (c *dateScanner) word() string {
+	start := c.pos
+	for c.pos < len(c.str) && strings.IndexByte(" ,\t;:/.-()", c.str[c.pos]) < 0 && !dateIsDigit(c.str, c.pos) {
+		c.pos++
+	}
+	return c.str[start:c.pos]
+}
+
+func (c *dateScanner) skipDaySuffix() {
+	if c.pos+2 <= len(c.str) {
+		switch strings.ToLower(c.str[c.pos : c.pos+2]) {
+		case "st", "nd", "rd", "th":
+			c.pos += 2
+		}
+	}
+}
+
+var dateRomanMonths = []string{"I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX", "X", "XI", "XII"}
+
+// month read a month name, abbreviation or roman numeral
+func (c *dateScanner) month() int {
+	for c.pos < len(c.str) && strings.IndexByte(" \t-./", c.str[c.pos]) >= 0 {
+		c.pos++
+	}
+	start := c.pos
+	for c.pos < len(c.str) && (c.str[c.pos] >= 'a' && c.str[c.pos] <= 'z' || c.str[c.pos] >= 'A' && c.str[c.pos] <= 'Z') {
+		c.pos++
+	}
+	word := c.str[start:c.pos]
+	for k, name := range dateRomanMonths {
+		if word == name {
+			return k + 1
+		}
+	}
+	for k, name := range dateMonthNames {
+		if strings.EqualFold(word, name) || strings.EqualFold(word, name[:3]) {
+			return k + 1
+		}
+	}
+	if strings.EqualFold(word, "sept") {
+		return 9
+	}
+	return dateUnset
+}
+
+func (c *dateScanner) hasMeridian() bool {
+	return strings.ContainsAny(c.str[c.pos:], "AaPp")
+}
+
+// meridian the correction to apply to hour for am/pm
+func (c *dateScanner) meridian(hour int) int {
+	for c.pos < len(c.str) && strings.IndexByte("AaPp", c.str[c.pos]) < 0 {
+		c.pos++
+	}
+	if c.pos >= len(c.str) {
+		return 0
+	}
+	ret := 0
+	if c.str[c.pos] == 'a' || c.str[c.pos] == 'A' {
+		if hour == 12 {
+			ret = -12
+		}
+	} else if hour != 12 {
+		ret = 12
+	}
+	c.pos++
+	for c.pos < len(c.str) && strings.IndexByte(".mM", c.str[c.pos]) >= 0 {
+		c.pos++
+	}
+	return ret
+}
+
+var dateRelativeTexts = map[string]int{
+	"last": -1, "previous": -1, "this": 0, "next": 1,
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "sixth": 6,
+	"seventh": 7, "eight": 8, "eighth": 8, "ninth": 9, "tenth": 10, "eleventh": 11, "twelfth": 12,
+}
+
+// relativeText read "next", "last", "third"... and return the amount and weekday behavior
+func (c *dateScanner) relativeText() (int, int) {
+	for c.pos < len(c.str) && strings.IndexByte(" \t-/", c.str[c.pos]) >= 0 {
+		c.pos++
+	}
+	word := strings.ToLower(c.word())
+	behavior := 0
+	if word == "this" {
+		behavior = 1
+	}
+	return dateRelativeTexts[word], behavior
+}
+
+type dateRelunit struct {
+	unit       byte
+	multiplier int
+}
+
+var dateRelunits = map[string]dateRelunit{
+	"ms": {'u', 1000}, "msec": {'u', 1000}, "msecs": {'u', 1000}, "millisecond": {'u', 1000}, "milliseconds": {'u', 1000},
+	"µs": {'u', 1}, "usec": {'u', 1}, "usecs": {'u', 1}, "µsec": {'u', 1}, "µsecs": {'u', 1}, "microsecond": {'u', 1}, "microseconds": {'u', 1},
+	"sec": {'s', 1}, "secs": {'s', 1}, "second": {'s', 1}, "seconds": {'s', 1},
+	"min": {'i', 1}, "mins": {'i', 1}, "minute": {'i', 1}, "minutes": {'i', 1},
+	"hour": {'h', 1}, "hours": {'h', 1},
+	"day": {'d', 1}, "days": {'d', 1},
+	"week": {'d', 7}, "weeks": {'d', 7},
+	"fortnight": {'d', 14}, "fortnights": {'d', 14}, "forthnight": {'d', 14}, "forthnights": {'d', 14},
+	"month": {'m', 1}, "months": {'m', 1},
+	"year": {'y', 1}, "years": {'y', 1},
+	"weekday": {'x', 1}, "weekdays": {'x', 1},
+}
+
+func init() {
+	for k, name := range dateDayNames {
+		lower := strings.ToLower(name)
+		dateRelunits[lower] = dateRelunit{'w', k}
+		dateRelunits[lower+"s"] = dateRelunit{'w', k}
+		dateRelunits[lower[:3]] = dateRelunit{'w', k}
+	}
+}
+
+// relunit read a relative unit: "day", "weeks", "monday"...
+func (c *dateScanner) relunit() (dateRelunit, bool) {
+	c.eatSpaces()
+	start := c.pos
+	for c.pos < len(c.str) && strings.IndexByte(" ,\t;:/.-()", c.str[c.pos]) < 0 {
+		c.pos++
+	}
+	unit, ok := dateRelunits[strings.ToLower(c.str[start:c.pos])]
+	return unit, ok
+}
+
+// resolve a date parsed with dateParseString against now, like timelib_fill_holes and timelib_update_ts
+func (p *dateParsed) resolve(now time.Time) time.Time {
+	loc := p.location(now.Location())
+	now = now.In(loc)
+	if p.haveDate && !p.haveTime {
+		p.h, p.i, p.s, p.us = 0, 0, 0, 0
+	}
+	if p.us == dateUnset {
+		p.us = 0
+		if p.y == dateUnset && p.m == dateUnset && p.d == dateUnset && p.h == dateUnset && p.i == dateUnset && p.s == dateUnset {
+			p.us = now.Nanosecond() / 1000
+		}
+	}
+	y, m, d, h, i, s, us := p.y, p.m, p.d, p.h, p.i, p.s, p.us
+	if y == dateUnset {
+		y = now.Year()
+	}
+	if m == dateUnset {
+		m = int(now.Month())
+	}
+	if d == dateUnset {
+		d = now.Day()
+	}
+	if h == dateUnset {
+		h = now.Hour()
+	}
+	if i == dateUnset {
+		i = now.Minute()
+	}
+	if s == dateUnset {
+		s = now.Second()
+	}
+	r := p.rel
+	// first/last weekday of a month
+	if r.haveSpecial {
+		switch r.special {
+		case dateSpecialDayOfWeekInMonth:
+			d = 1
+			m += r.m
+			r.m = 0
+		case dateSpecialLastDayOfWeekInMonth:
+			d = 1
+			m += r.m + 1
+			r.m = 0
+		}
+	}
+	t := time.Date(y, time.Month(m), d, h, i, s, 0, loc)
+	if r.haveWeekday {
+		t = t.AddDate(0, 0, dateWeekdayDifference(t, r))
+	}
+	y, m, d = t.Year(), int(t.Month()), t.Day()
+	h, i, s = t.Hour(), t.Minute(), t.Second()
+	if p.haveRel {
+		y, m, d, h, i, s, us = y+r.y, m+r.m, d+r.d, h+r.h, i+r.i, s+r.s, us+r.us
+	}
+	switch r.firstLast {
+	case dateFirstDayOf:
+		d = 1
+	case dateLastDayOf:
+		d = 0
+		m++
+	}
+	t = time.Date(y, time.Month(m), d, h, i, s, 0, loc).Add(time.Duration(us) * time.Microsecond)
+	if r.haveSpecial && r.special == dateSpecialWeekday {
+		t = dateAddWeekdays(t, r.specialAmount)
+	}
+	return t
+}
+
+// dateWeekdayDifference days to move to reach the relative weekday
+func dateWeekdayDifference(t time.Time, r dateRelative) int {
+	dow := int(t.Weekday())
+	weekday := r.weekday
+	if r.weekdayBehavior == 2 {
+		// "this week" when today is sunday, "sunday this week" when it is not
+		if dow == 0 && weekday != 0 {
+			weekday -= 7
+		}
+		if weekday == 0 && dow != 0 {
+			weekday = 7
+		}
+		return weekday - dow
+	}
+	diff := weekday - dow
+	if r.d < 0 && diff < 0 || r.d >= 0 && diff <= -r.weekdayBehavior {
+		diff += 7
+	}
+	if weekday >= 0 {
+		return diff
+	}
+	abs := -weekday
+	return -(7 - (abs - dow))
+}
+
+// dateAddWeekdays move n business days, skipping saturdays and sundays
+func dateAddWeekdays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	if n == 0 {
+		for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return t
+}
+
+// Strtotime strtotime()
+// Parse about any English textual datetime description into a Unix timestamp, relative to the base timestamp or now.
+// An empty or blank datetime is an error, like the false of PHP.
+// options are an optional base timestamp (int64 or int) and an optional *time.Location replacing the default timezone.
+// Strtotime("now"), Strtotime("+1 week 2 days"), Strtotime("last day of next month"),
+// Strtotime("first monday of January 2025"), Strtotime("@1700000000"), Strtotime("2008-08-07T18:11:31+02:00"),
+// Strtotime("tomorrow", 1524799394), Strtotime("tomorrow", 1524799394, shanghai)
+func Strtotime(datetime string, options ...interface{}) (int64, error) {
+	var loc *time.Location
+	var base *int64
+	for _, option := range options {
+		switch v := option.(type) {
+		case int64:
+			base = &v
+		case int:
+			ts := int64(v)
+			base = &ts
+		case *time.Location:
+			loc = v
+		default:
+			panic("options: must be a timestamp or a *time.Location")
+		}
+	}
+	now := time.Now().In(dateLocation([]*time.Location{loc}))
+	if base != nil {
+		now = time.Unix(*base, 0).In(now.Location())
+	}
+	if strings.TrimSpace(datetime) == "" {
+		return 0, errors.New("Empty string")
+	}
+	p := dateParseString(datetime)
+	if err := p.firstError(len(datetime)); err != nil {
+		return 0, err
+	}
+	return p.resolve(now).Unix(), nil
+}
+
+// DateCreate date_create()
+// Parse datetime like Strtotime, in the timezone found in datetime, loc, or the default timezone.
+// DateCreate("2018-04-27 11:23:14", shanghai), DateCreate("tomorrow")
+func DateCreate(datetime string, loc ...*time.Location) (time.Time, error) {
+	p := dateParseString(datetime)
+	if err := p.firstError(len(datetime)); err != nil {
+		return time.Time{}, err
+	}
+	return p.resolve(time.Now().In(dateLocation(loc))), nil
+}
@@ -0,0 +1,342 @@
+package php2go
+
+import (
+	"archive/zip"
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DateTimeZoneAfrica DateTimeZone::AFRICA
+	DateTimeZoneAfrica = 1 << iota
+	// DateTimeZoneAmerica DateTimeZone::AMERICA
+	DateTimeZoneAmerica
+	// DateTimeZoneAntarctica DateTimeZone::ANTARCTICA
+	DateTimeZoneAntarctica
+	// DateTimeZoneArctic DateTimeZone::ARCTIC
+	DateTimeZoneArctic
+	// DateTimeZoneAsia DateTimeZone::ASIA
+	DateTimeZoneAsia
+	// DateTimeZoneAtlantic DateTimeZone::ATLANTIC
+	DateTimeZoneAtlantic
+	// DateTimeZoneAustralia DateTimeZone::AUSTRALIA
+	DateTimeZoneAustralia
+	// DateTimeZoneEurope DateTimeZone::EUROPE
+	DateTimeZoneEurope
+	// DateTimeZoneIndian DateTimeZone::INDIAN
+	DateTimeZoneIndian
+	// DateTimeZonePacific DateTimeZone::PACIFIC
+	DateTimeZonePacific
+	// DateTimeZoneUTC DateTimeZone::UTC
+	DateTimeZoneUTC
+	// DateTimeZoneAll DateTimeZone::ALL
+	DateTimeZoneAll = 2047
+	// DateTimeZoneAllWithBC DateTimeZone::ALL_WITH_BC
+	DateTimeZoneAllWithBC = 4095
+	// DateTimeZonePerCountry DateTimeZone::PER_COUNTRY
+	DateTimeZonePerCountry = 4096
+)
+
+var timezoneGroups = []string{"Africa/", "America/", "Antarctica/", "Arctic/", "Asia/", "Atlantic/",
+	"Australia/", "Europe/", "Indian/", "Pacific/", "UTC"}
+
+// dateDefaultTimezone the timezone used by all date functions, time.Local until DateDefaultTimezoneSet is called
+var dateDefaultTimezone = struct {
+	sync.RWMutex
+	loc *time.Location
+}{loc: time.Local}
+
+// dateLocation the first non nil loc, or the default timezone
+func dateLocation(loc []*time.Location) *time.Location {
+	if len(loc) > 0 && loc[0] != nil {
+		return loc[0]
+	}
+	dateDefaultTimezone.RLock()
+	defer dateDefaultTimezone.RUnlock()
+	return dateDefaultTimezone.loc
+}
+
+// DateDefaultTimezoneSet date_default_timezone_set()
+// Sets the timezone used by all date functions, DateDefaultTimezoneSet("Asia/Shanghai")
+func DateDefaultTimezoneSet(timezoneID string) error {
+	if timezoneID == "" || timezoneID == "Local" {
+		return errors.New("Timezone ID '" + timezoneID + "' is invalid")
+	}
+	loc, err := time.LoadLocation(timezoneID)
+	if err != nil {
+		return errors.New("Timezone ID '" + timezoneID + "' is invalid")
+	}
+	dateDefaultTimezone.Lock()
+	dateDefaultTimezone.loc = loc
+	dateDefaultTimezone.Unlock()
+	return nil
+}
+
+// DateDefaultTimezoneGet date_default_timezone_get()
+// Before DateDefaultTimezoneSet, the name of the system timezone (TZ or /etc/localtime), UTC when unknown.
+func DateDefaultTimezoneGet() string {
+	loc := dateLocation(nil)
+	if loc != time.Local {
+		return loc.String()
+	}
+	if name := dateLocalName(); name != "" {
+		return name
+	}
+	return "UTC"
+}
+
+var dateLocalNameOnce struct {
+	sync.Once
+	name string
+}
+
+// dateLocalName the timezone ID time.Local was loaded from, "" when unknown
+func dateLocalName() string {
+	dateLocalNameOnce.Do(func() {
+		name, ok := os.LookupEnv("TZ")
+		if !ok {
+			name, _ = os.Readlink("/etc/localtime")
+		}
+		name = strings.TrimPrefix(name, ":")
+		if i := strings.Index(name, "zoneinfo/"); i >= 0 {
+			name = name[i+len("zoneinfo/"):]
+		}
+		if name == "" && ok {
+			name = "UTC"
+		}
+		if _, err := time.LoadLocation(name); err != nil || name == "Local" {
+			name = ""
+		}
+		dateLocalNameOnce.name = name
+	})
+	return dateLocalNameOnce.name
+}
+
+// TimezoneOpen timezone_open()
+// Accepts timezone IDs (Europe/London), abbreviations (CEST) and offsets (+08:00)
+func TimezoneOpen(timezone string) (*time.Location, error) {
+	if strings.Contains(timezone, "/") || timezone == "UTC" {
+		if loc, err := time.LoadLocation(timezone); err == nil {
+			return loc, nil
+		}
+	}
+	p := newDateParsed()
+	if n := dateParseZone(timezone, p); n == 0 || n != len(timezone) {
+		return nil, errors.New("Unknown or bad timezone (" + timezone + ")")
+	}
+	return p.location(nil), nil
+}
+
+// TimezoneNameGet timezone_name_get()
+func TimezoneNameGet(loc *time.Location) string {
+	if loc == time.Local {
+		return DateDefaultTimezoneGet()
+	}
+	if loc.String() == "" {
+		return dateOffset(time.Unix(0, 0).In(loc), true, false)
+	}
+	return loc.String()
+}
+
+// TimezoneOffsetGet timezone_offset_get()
+// The offset from UTC in seconds of loc at t
+func TimezoneOffsetGet(loc *time.Location, t time.Time) int {
+	_, offset := t.In(loc).Zone()
+	return offset
+}
+
+// TimezoneTransitionsGet timezone_transitions_get()
+// The first element is the state at begin, then every transition of the zoneinfo in (begin, end].
+// Keys: ts, time, offset, isdst, abbr. Transitions are listed up to the year 2100.
+func TimezoneTransitionsGet(loc *time.Location, begin, end int64) []map[string]interface{} {
+	ret := []map[string]interface{}{timezoneTransition(loc, begin)}
+	if high := time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC).Unix(); end > high {
+		end = high
+	}
+	t := time.Unix(begin, 0).In(loc)
+	for {
+		_, next := t.ZoneBounds()
+		if next.IsZero() || next.Unix() > end {
+			break
+		}
+		ret = append(ret, timezoneTransition(loc, next.Unix()))
+		t = next
+	}
+	return ret
+}
+
+type timezoneZone struct {
+	abbr   string
+	offset int
+	isdst  bool
+}
+
+func timezoneState(loc *time.Location, ts int64) timezoneZone {
+	t := time.Unix(ts, 0).In(loc)
+	abbr, offset := t.Zone()
+	return timezoneZone{abbr, offset, t.IsDST()}
+}
+
+func timezoneTransition(loc *time.Location, ts int64) map[string]interface{} {
+	state := timezoneState(loc, ts)
+	return map[string]interface{}{
+		"ts":     ts,
+		"time":   DateFormat(time.Unix(ts, 0).UTC(), "Y-m-d\\TH:i:sO"),
+		"offset": state.offset,
+		"isdst":  state.isdst,
+		"abbr":   state.abbr,
+	}
+}
+
+// TimezoneIdentifiersList timezone_identifiers_list()
+// group is a DateTimeZone* constant, with DateTimeZonePerCountry the ISO 3166-1 country code is required.
+// TimezoneIdentifiersList(DateTimeZoneAsia|DateTimeZoneEurope), TimezoneIdentifiersList(DateTimeZonePerCountry, "CN")
+func TimezoneIdentifiersList(group int, country ...string) []string {
+	db := timezoneDatabase()
+	var ret []string
+	switch {
+	case group == DateTimeZonePerCountry:
+		if len(country) == 0 {
+			return ret
+		}
+		for _, id := range db.ids {
+			if db.countries[id] == strings.ToUpper(country[0]) {
+				ret = append(ret, id)
+			}
+		}
+	case group == DateTimeZoneAllWithBC:
+		ret = append(ret, db.all...)
+	default:
+		for _, id := range db.ids {
+			for k, prefix := range timezoneGroups {
+				if group&(1<<uint(k)) != 0 && (strings.HasPrefix(id, prefix) || id == prefix) {
+					ret = append(ret, id)
+					break
+				}
+			}
+		}
+	}
+	return ret
+}
+
+type timezoneData struct {
+	ids       []string
+	all       []string
+	countries map[string]string
+}
+
+var (
+	timezoneDB     timezoneData
+	timezoneDBOnce sync.Once
+)
+
+// timezoneDatabase the zones of zone.tab and every file of the system zoneinfo database,
+// or Go's zoneinfo.zip and the embedded zone.tab when there is none
+func timezoneDatabase() *timezoneData {
+	timezoneDBOnce.Do(func() {
+		timezoneDB.countries = map[string]string{}
+		dirs := []string{"/usr/share/zoneinfo/", "/usr/share/lib/zoneinfo/", "/usr/lib/locale/TZ/"}
+		if dir := os.Getenv("ZONEINFO"); dir != "" {
+			dirs = append([]string{dir}, dirs...)
+		}
+		for _, dir := range dirs {
+			if strings.HasSuffix(dir, ".zip") {
+				timezoneDB.all = timezoneZipNames(dir)
+			} else {
+				dir = strings.TrimSuffix(dir, "/") + "/"
+				timezoneDB.all = timezoneDirNames(dir)
+			}
+			if len(timezoneDB.all) == 0 {
+				continue
+			}
+			if f, err := os.Open(filepath.Join(dir, "zone.tab")); err == nil {
+				scanner := bufio.NewScanner(f)
+				for scanner.Scan() {
+					fields := strings.Split(scanner.Text(), "\t")
+					if len(fields) < 3 || strings.HasPrefix(fields[0], "#") {
+						continue
+					}
+					timezoneDB.ids = append(timezoneDB.ids, fields[2])
+					timezoneDB.countries[fields[2]] = fields[0]
+				}
+				f.Close()
+			}
+			break
+		}
+		if len(timezoneDB.all) == 0 {
+			timezoneDB.all = timezoneZipNames(filepath.Join(runtime.GOROOT(), "lib", "time", "zoneinfo.zip"))
+		}
+		if len(timezoneDB.ids) == 0 {
+			timezoneDB.ids, timezoneDB.countries = timezoneEmbeddedZoneTab()
+		}
+		timezoneDB.ids = append(timezoneDB.ids, "UTC")
+		sort.Strings(timezoneDB.ids)
+	})
+	return &timezoneDB
+}
+
+// timezoneEmbeddedZoneTab the zones and their countries of timezoneZoneTab
+func timezoneEmbeddedZoneTab() ([]string, map[string]string) {
+	var ids []string
+	countries := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(timezoneZoneTab), "\n") {
+		country, id, _ := strings.Cut(line, " ")
+		ids = append(ids, id)
+		countries[id] = country
+	}
+	return ids, countries
+}
+
+func timezoneDirNames(dir string) []string {
+	var names []string
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := filepath.ToSlash(strings.TrimPrefix(path, dir))
+		if info.IsDir() {
+			if name == "posix" || name == "right" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if name == "" || name[0] < 'A' || name[0] > 'Z' || name == "Factory" {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		magic := make([]byte, 4)
+		if n, _ := f.Read(magic); n == 4 && string(magic) == "TZif" {
+			names = append(names, name)
+		}
+		f.Close()
+		return nil
+	})
+	sort.Strings(names)
+	return names
+}
+
+func timezoneZipNames(file string) []string {
+	r, err := zip.OpenReader(file)
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+	var names []string
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, "/") && f.Name != "Factory" {
+			names = append(names, f.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}